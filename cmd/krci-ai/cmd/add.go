@@ -0,0 +1,155 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/KubeRocketCI/kuberocketai/internal/assets"
+	"github.com/KubeRocketCI/kuberocketai/internal/cli"
+	"github.com/KubeRocketCI/kuberocketai/internal/discovery"
+	"github.com/KubeRocketCI/kuberocketai/internal/lockfile"
+)
+
+// addCmd represents the add command
+var addCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Add components to an existing installation",
+	Long: `Add components to a framework installation made with 'krci-ai install'.
+
+Examples:
+  krci-ai add agent dev            # Add the dev agent and its dependencies`,
+}
+
+// addAgentCmd represents the add agent command
+var addAgentCmd = &cobra.Command{
+	Use:   "agent <name>...",
+	Short: "Add agents to an existing installation",
+	Long: `Install agents with their tasks, templates and data into an existing installation
+and generate their files for every IDE integration of the project.
+
+Files already installed for other agents are shared and recorded for both, so that
+'krci-ai remove agent' removes a file only once no installed agent needs it.
+Agents installed with --task are restored with all their tasks.
+
+Examples:
+  krci-ai add agent dev                 # Add the dev agent
+  krci-ai add agent pm architect        # Add several agents
+  krci-ai add agent qa --force          # Reinstall an agent that is already installed
+  krci-ai add agent qa --from ../golden-agents  # Add an agent of an agent pack`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runAddAgent,
+}
+
+func init() {
+	rootCmd.AddCommand(addCmd)
+	addCmd.AddCommand(addAgentCmd)
+
+	addAgentCmd.Flags().Bool("force", false, "Reinstall agents that are already installed")
+	addAgentCmd.Flags().String("from", "", "Add agents from a pack: <git-url|https-tarball|local-dir>[@ref] instead of the embedded framework")
+}
+
+func runAddAgent(cmd *cobra.Command, args []string) error {
+	output := cli.NewOutputHandler()
+	errorHandler := cli.NewErrorHandler()
+	force, _ := cmd.Flags().GetBool("force")
+
+	agentNames := ParseAgentList(strings.Join(args, " "))
+	if len(agentNames) == 0 {
+		return fmt.Errorf("no valid agent names provided")
+	}
+
+	// Failures past argument parsing are not usage errors
+	cmd.SilenceUsage = true
+
+	projectRoot, err := discovery.GetProjectRoot()
+	if err != nil {
+		return err
+	}
+
+	lock, err := loadInstalledLockfile(projectRoot)
+	if err != nil {
+		return err
+	}
+
+	var pending []string
+	for _, name := range agentNames {
+		_, trimmed := lock.Tasks[name]
+		if slices.Contains(lock.Agents, name) && !trimmed && !force {
+			output.PrintInfo(fmt.Sprintf("Agent %s is already installed, use --force to reinstall it", name))
+			continue
+		}
+		pending = append(pending, name)
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	output.PrintProgress(fmt.Sprintf("Adding agents: %s", strings.Join(pending, ", ")))
+
+	installer, cleanup, err := newInstaller(cmd, projectRoot, "", output, errorHandler)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	if err := installer.InstallSelective(pending); err != nil {
+		return fmt.Errorf("failed to add agents: %w", err)
+	}
+
+	ides := slices.Clone(lock.IDEs)
+	for _, ide := range installedIDEs(installer) {
+		if !slices.Contains(ides, ide) {
+			ides = append(ides, ide)
+		}
+	}
+	for _, ide := range ides {
+		if err := installer.GenerateIDEFiles(ide, pending); err != nil {
+			return err
+		}
+	}
+
+	if err := installer.UpdateLockfile(ides); err != nil {
+		return err
+	}
+	if err := installer.Commit(); err != nil {
+		return err
+	}
+
+	showBackupNotice(installer.Backup(), output)
+	showInlinedFiles(cmd, installer, output)
+	if len(ides) > 0 {
+		output.PrintInfo(fmt.Sprintf("IDE integrations updated: %s", strings.Join(ides, ", ")))
+	}
+	output.PrintSuccess(fmt.Sprintf("Agents added successfully: %s", strings.Join(pending, ", ")))
+
+	return nil
+}
+
+// loadInstalledLockfile loads the lockfile of an existing installation
+func loadInstalledLockfile(projectRoot string) (*lockfile.Lockfile, error) {
+	lock, err := lockfile.Load(assets.GetKrciPath(projectRoot))
+	if errors.Is(err, lockfile.ErrNotFound) {
+		return nil, fmt.Errorf("%w: run 'krci-ai install' first", err)
+	}
+
+	return lock, err
+}
@@ -0,0 +1,165 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KubeRocketCI/kuberocketai/internal/assets"
+	"github.com/KubeRocketCI/kuberocketai/internal/lockfile"
+)
+
+// testAgent returns an agent definition with one task sharing the review template
+func testAgent(name string) string {
+	return fmt.Sprintf(`agent:
+  identity:
+    name: "%[2]s"
+    id: %[1]s-v1
+    version: "1.0.0"
+    description: "%[2]s agent for command tests"
+    role: "%[2]s"
+    goal: "Test agent commands"
+    icon: "🧪"
+
+  commands:
+    help: "Show available commands"
+    review: "Review work by executing task review-%[1]s"
+
+  tasks:
+    - ./.krci-ai/tasks/review-%[1]s.md
+`, name, name+" tester")
+}
+
+// writeFiles writes files given by slash separated paths relative to the root
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+
+	for path, content := range files {
+		target := filepath.Join(root, filepath.FromSlash(path))
+		require.NoError(t, os.MkdirAll(filepath.Dir(target), 0755))
+		require.NoError(t, os.WriteFile(target, []byte(content), 0644))
+	}
+}
+
+// prepareAgentProject creates a framework with the alpha and beta agents and a project
+// where the given agents are installed with the claude and agentsmd integrations
+func prepareAgentProject(t *testing.T, agentNames ...string) (string, string) {
+	t.Helper()
+
+	sourceDir := t.TempDir()
+	files := map[string]string{"templates/review.md": "# Review\n"}
+	for _, name := range []string{"alpha", "beta"} {
+		files["agents/"+name+".yaml"] = testAgent(name)
+		files["tasks/review-"+name+".md"] = "---\ndependencies:\n  templates:\n    - review.md\n---\n# Review " + name + "\n"
+	}
+	writeFiles(t, sourceDir, files)
+
+	projectDir := t.TempDir()
+	ides := []string{"claude", "agentsmd"}
+	installer := assets.NewInstallerFromSource(projectDir, assets.OSFileSystem{}, sourceDir, assets.NewDiscovery(sourceDir))
+	require.NoError(t, installer.Begin())
+	require.NoError(t, installer.InstallSelective(agentNames))
+	for _, ide := range ides {
+		require.NoError(t, installer.InstallIDE(ide))
+	}
+	require.NoError(t, installer.UpdateLockfile(ides))
+	require.NoError(t, installer.Commit())
+
+	t.Setenv("KRCI_AI_PROJECT_DIR", projectDir)
+
+	return sourceDir, projectDir
+}
+
+// setFlags sets command flags for a test and restores their defaults afterwards
+func setFlags(t *testing.T, cmd *cobra.Command, flags map[string]string) {
+	t.Helper()
+
+	for name, value := range flags {
+		flag := cmd.Flags().Lookup(name)
+		require.NotNil(t, flag, name)
+		require.NoError(t, cmd.Flags().Set(name, value))
+		t.Cleanup(func() {
+			_ = flag.Value.Set(flag.DefValue)
+			flag.Changed = false
+		})
+	}
+}
+
+// loadProjectLockfile loads the lockfile of a test project
+func loadProjectLockfile(t *testing.T, projectDir string) *lockfile.Lockfile {
+	t.Helper()
+
+	lock, err := lockfile.Load(assets.GetKrciPath(projectDir))
+	require.NoError(t, err)
+	return lock
+}
+
+func TestAddAgent(t *testing.T) {
+	sourceDir, projectDir := prepareAgentProject(t, "alpha")
+	setFlags(t, addAgentCmd, map[string]string{"from": sourceDir})
+
+	require.NoError(t, runAddAgent(addAgentCmd, []string{"beta"}))
+
+	assert.FileExists(t, filepath.Join(projectDir, ".krci-ai", "agents", "beta.yaml"))
+	assert.FileExists(t, filepath.Join(projectDir, ".krci-ai", "tasks", "review-beta.md"))
+	assert.FileExists(t, filepath.Join(projectDir, ".claude", "commands", "krci-ai", "beta.md"))
+
+	agentsMD, err := os.ReadFile(filepath.Join(projectDir, "AGENTS.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(agentsMD), "(`alpha`)")
+	assert.Contains(t, string(agentsMD), "(`beta`)")
+
+	lock := loadProjectLockfile(t, projectDir)
+	assert.ElementsMatch(t, []string{"alpha", "beta"}, lock.Agents)
+	assert.ElementsMatch(t, []string{"claude", "agentsmd"}, lock.IDEs)
+
+	template, ok := lock.GetFile(".krci-ai/templates/review.md")
+	require.True(t, ok)
+	assert.ElementsMatch(t, []string{"alpha", "beta"}, template.Agents)
+
+	section, ok := lock.GetFile("AGENTS.md")
+	require.True(t, ok)
+	assert.ElementsMatch(t, []string{"alpha", "beta"}, section.Agents)
+
+	checks, err := lock.Modified(projectDir)
+	require.NoError(t, err)
+	assert.Empty(t, checks)
+
+	t.Run("already installed", func(t *testing.T) {
+		require.NoError(t, runAddAgent(addAgentCmd, []string{"beta"}))
+		assert.Equal(t, lock, loadProjectLockfile(t, projectDir))
+	})
+
+	t.Run("unknown agent", func(t *testing.T) {
+		require.Error(t, runAddAgent(addAgentCmd, []string{"gamma"}))
+		assert.NoFileExists(t, filepath.Join(projectDir, ".krci-ai", "agents", "gamma.yaml"))
+		assert.Equal(t, lock, loadProjectLockfile(t, projectDir))
+	})
+}
+
+func TestAddAgentRequiresInstallation(t *testing.T) {
+	t.Setenv("KRCI_AI_PROJECT_DIR", t.TempDir())
+
+	err := runAddAgent(addAgentCmd, []string{"alpha"})
+	require.ErrorIs(t, err, lockfile.ErrNotFound)
+}
@@ -28,13 +28,14 @@ import (
 	"github.com/KubeRocketCI/kuberocketai/internal/assets"
 	"github.com/KubeRocketCI/kuberocketai/internal/bundle"
 	"github.com/KubeRocketCI/kuberocketai/internal/cli"
+	"github.com/KubeRocketCI/kuberocketai/internal/config"
 	"github.com/KubeRocketCI/kuberocketai/internal/discovery"
 	"github.com/KubeRocketCI/kuberocketai/internal/tokens"
+	"github.com/KubeRocketCI/kuberocketai/internal/values"
 )
 
 // Constants
 const (
-	bundleDir      = "bundle"
 	dirPermissions = 0755
 )
 
@@ -65,10 +66,9 @@ Examples:
 
 // BundleContent represents the aggregated content for bundle generation
 type BundleContent struct {
-	Agents    []assets.Agent
-	Tasks     map[string]string
-	Templates map[string]string
-	DataFiles map[string]string
+	*assets.AgentContent
+	Agents []assets.Agent
+	Values values.Values
 }
 
 func init() {
@@ -220,6 +220,13 @@ func runBundle(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	// Load project values for placeholder substitution
+	bundleContent.Values, err = values.Load(assets.GetKrciPath(projectRoot))
+	if err != nil {
+		errorHandler.HandleError(err, "Failed to load project values")
+		return err
+	}
+
 	// Check dry-run flag
 	bundleDryRun, err := cmd.Flags().GetBool("dry-run")
 	if err != nil {
@@ -237,8 +244,16 @@ func runBundle(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to read output flag: %w", err)
 	}
 
+	// Resolve bundle output directory and token model from configuration
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		errorHandler.HandleError(err, "Failed to load configuration")
+		return err
+	}
+	bundleDirPath := resolveProjectPath(projectRoot, cfg.String(config.KeyBundleOutputDir))
+
 	// Generate and write bundle
-	return generateAndWriteBundle(cmd.Context(), projectRoot, selectedAgents, bundleContent, bundleOutput, discovery, output, errorHandler)
+	return generateAndWriteBundle(cmd.Context(), bundleDirPath, cfg.String(config.KeyTokensModel), selectedAgents, bundleContent, bundleOutput, discovery, output, errorHandler)
 }
 
 // parseAndValidateAgents handles agent parsing and validation logic
@@ -277,14 +292,12 @@ func collectBundleContent(ctx context.Context, discovery *assets.Discovery, sele
 	}
 
 	content := &BundleContent{
-		Agents:    agents,
-		Tasks:     make(map[string]string),
-		Templates: make(map[string]string),
-		DataFiles: make(map[string]string),
+		AgentContent: assets.NewAgentContent(),
+		Agents:       agents,
 	}
 
 	for _, agent := range agents {
-		if err := collectAgentContent(discovery, agent, content); err != nil {
+		if err := content.Collect(agent, discovery.ReadFile); err != nil {
 			return nil, err
 		}
 	}
@@ -309,54 +322,11 @@ func getAgentsForBundle(ctx context.Context, discovery *assets.Discovery, select
 	return agents, nil
 }
 
-// collectAgentContent collects all content for a specific agent
-func collectAgentContent(discovery *assets.Discovery, agent assets.Agent, content *BundleContent) error {
-	if err := collectFilePaths(discovery, agent.GetAllTasksPaths(), content.Tasks); err != nil {
-		return err
-	}
-
-	if err := collectFilePaths(discovery, agent.GetAllReferencedTasksPaths(), content.Tasks); err != nil {
-		return err
-	}
-
-	if err := collectFilePaths(discovery, agent.GetAllTemplatesPaths(), content.Templates); err != nil {
-		return err
-	}
-
-	if err := collectFilePaths(discovery, agent.GetAllDataFilesPaths(), content.DataFiles); err != nil {
-		return err
-	}
-
-	return nil
-}
-
-// collectFilePaths collects content for a list of file paths into the target map
-func collectFilePaths(discovery *assets.Discovery, paths []string, target map[string]string) error {
-	for _, path := range paths {
-		if _, exists := target[path]; !exists {
-			content, err := readFileContent(discovery, path)
-			if err != nil {
-				return fmt.Errorf("failed to read file %s: %w", path, err)
-			}
-			target[path] = content
-		}
-	}
-	return nil
-}
-
-// readFileContent reads file content using the discovery filesystem
-func readFileContent(discovery *assets.Discovery, filePath string) (string, error) {
-	data, err := discovery.ReadFile(filePath)
-	if err != nil {
-		return "", err
-	}
-	return string(data), nil
-}
-
 // generateAndWriteBundle handles bundle generation and file writing
 func generateAndWriteBundle(
 	ctx context.Context,
-	projectRoot string,
+	bundleDirPath string,
+	tokenModel string,
 	selectedAgents []string,
 	bundleContent *BundleContent,
 	bundleOutput string,
@@ -366,7 +336,6 @@ func generateAndWriteBundle(
 ) error {
 	// Generate bundle filename
 	bundleFilename := bundle.GenerateBundleFilename(bundleOutput, selectedAgents)
-	bundleDirPath := filepath.Join(projectRoot, assets.KrciAIDir, bundleDir)
 	bundlePath := filepath.Join(bundleDirPath, bundleFilename)
 
 	output.PrintInfo(fmt.Sprintf("Generating bundle: %s", bundlePath))
@@ -390,7 +359,7 @@ func generateAndWriteBundle(
 	output.PrintInfo(fmt.Sprintf("Bundle size: %d bytes", len(bundleMarkdown)))
 
 	// Calculate and display token summary (with graceful error handling)
-	if err := displayBundleTokenSummary(ctx, bundleMarkdown, output); err != nil {
+	if err := displayBundleTokenSummary(ctx, tokenModel, bundleMarkdown, output); err != nil {
 		// Token calculation error should not prevent bundle generation success
 		output.PrintWarning(fmt.Sprintf("Token analysis failed: %v", err))
 		output.PrintInfo("Bundle was created successfully despite token calculation error")
@@ -469,7 +438,8 @@ func generateBundleMarkdown(content *BundleContent, discovery *assets.Discovery)
 	// Add shared data files
 	addSharedDataFiles(&result, content)
 
-	return result.String()
+	// Substitute project values into the final bundle
+	return values.Render(result.String(), content.Values)
 }
 
 // makeRelativePath converts an absolute path to a relative path starting from .krci-ai
@@ -591,8 +561,8 @@ func writeBundleFile(bundlePath, content string) error {
 }
 
 // displayBundleTokenSummary calculates and displays token summary for the generated bundle
-func displayBundleTokenSummary(ctx context.Context, bundleContent string, output *cli.OutputHandler) error {
-	engine, err := tokens.NewDefaultEngine()
+func displayBundleTokenSummary(ctx context.Context, tokenModel, bundleContent string, output *cli.OutputHandler) error {
+	engine, err := tokens.NewEngineForModel(tokenModel)
 	if err != nil {
 		return fmt.Errorf("failed to create tokens calculator: %w", err)
 	}
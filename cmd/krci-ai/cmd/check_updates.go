@@ -17,14 +17,21 @@ package cmd
 
 import (
 	"fmt"
+	"net/http"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 
+	"github.com/KubeRocketCI/kuberocketai/internal/config"
+	"github.com/KubeRocketCI/kuberocketai/internal/github"
 	"github.com/KubeRocketCI/kuberocketai/internal/update"
 	"github.com/KubeRocketCI/kuberocketai/internal/version"
 )
 
+// defaultUpdateRetries is the number of update check retries when no configuration is loaded
+const defaultUpdateRetries = 2
+
 // checkUpdatesCmd represents the check-updates command
 var checkUpdatesCmd = &cobra.Command{
 	Use:   "check-updates",
@@ -35,6 +42,9 @@ This command queries the GitHub API to detect available updates and displays
 release information. If network connectivity fails, it provides a direct
 link to the GitHub releases page for manual checking.
 
+The number of retries and the HTTP timeout can be configured with the
+update.retries and update.timeout configuration keys.
+
 Examples:
   krci-ai check-updates              # Check for updates online`,
 	RunE: runCheckUpdates,
@@ -45,10 +55,20 @@ func init() {
 }
 
 func runCheckUpdates(cmd *cobra.Command, args []string) error {
-	return checkOnlineUpdates()
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	return checkOnlineUpdatesWithSettings(cfg.Int(config.KeyUpdateRetries), cfg.Duration(config.KeyUpdateTimeout))
 }
 
 func checkOnlineUpdates() error {
+	return checkOnlineUpdatesWithSettings(defaultUpdateRetries, github.DefaultTimeout)
+}
+
+// checkOnlineUpdatesWithSettings checks for updates using the given retry count and HTTP timeout
+func checkOnlineUpdatesWithSettings(retries int, timeout time.Duration) error {
 	currentVersion := version.GetCurrentVersion()
 
 	color.Cyan("🔍 Checking for Updates")
@@ -58,10 +78,12 @@ func checkOnlineUpdates() error {
 	color.White("Current version: %s", currentVersion)
 
 	// Create update checker
-	checker := update.NewChecker()
+	client := github.NewClientWith(github.DefaultBaseURL, github.DefaultUserAgent,
+		&http.Client{Timeout: timeout})
+	checker := update.NewCheckerWithClient(client, "KubeRocketCI", "kuberocketai")
 
 	// Check for updates with retry
-	updateInfo := checker.CheckForUpdatesWithRetry(currentVersion, 2)
+	updateInfo := checker.CheckForUpdatesWithRetry(currentVersion, retries)
 
 	// Display results
 	if updateInfo.Error != "" {
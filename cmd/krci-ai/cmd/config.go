@@ -0,0 +1,216 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/KubeRocketCI/kuberocketai/internal/assets"
+	"github.com/KubeRocketCI/kuberocketai/internal/cli"
+	"github.com/KubeRocketCI/kuberocketai/internal/config"
+	"github.com/KubeRocketCI/kuberocketai/internal/discovery"
+)
+
+// configCmd represents the config command
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage KubeRocketAI CLI configuration",
+	Long: `Manage layered KubeRocketAI CLI configuration.
+
+Configuration values are resolved in the following order (later layers win):
+  1. Built-in defaults
+  2. User configuration     ($HOME/.krci-ai.yaml, --config or KRCI_AI_CONFIG)
+  3. Project configuration  (.krci-ai/config.yaml)
+  4. Environment variables  (KRCI_AI_<KEY>, e.g. KRCI_AI_INSTALL_IDE)
+  5. Command line flags
+
+Examples:
+  krci-ai config list                          # Show effective configuration and its sources
+  krci-ai config get install.ide               # Show a single value and where it came from
+  krci-ai config set install.ide claude        # Set a value in the project configuration
+  krci-ai config set tokens.budget 20000 --user  # Set a value in the user configuration`,
+}
+
+// configListCmd represents the config list command
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List effective configuration values",
+	Args:  cobra.NoArgs,
+	RunE:  runConfigList,
+}
+
+// configGetCmd represents the config get command
+var configGetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Show an effective configuration value and its source",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runConfigGet,
+}
+
+// configSetCmd represents the config set command
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Set a configuration value in the project or user configuration file",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runConfigSet,
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configListCmd)
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configSetCmd)
+
+	configListCmd.Flags().Bool("json", false, "Output configuration in JSON format")
+	configSetCmd.Flags().Bool("user", false, "Write to the user configuration file instead of the project configuration")
+}
+
+// loadConfig loads layered configuration for the current project
+func loadConfig(cmd *cobra.Command) (*config.Config, error) {
+	projectRoot, err := discovery.GetProjectRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	userFile, err := getUserConfigFile(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	return config.Load(config.LoadOptions{
+		UserFile:    userFile,
+		ProjectFile: config.GetProjectConfigPath(assets.GetKrciPath(projectRoot)),
+	})
+}
+
+// getUserConfigFile returns the user configuration file from the --config flag or the default location
+func getUserConfigFile(cmd *cobra.Command) (string, error) {
+	if flag := cmd.Flags().Lookup("config"); flag != nil && flag.Value.String() != "" {
+		return flag.Value.String(), nil
+	}
+
+	return config.GetUserConfigPath()
+}
+
+func runConfigList(cmd *cobra.Command, args []string) error {
+	jsonOutput, err := cmd.Flags().GetBool("json")
+	if err != nil {
+		return fmt.Errorf("failed to read json flag: %w", err)
+	}
+
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		data, err := json.MarshalIndent(cfg.Values(), "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON output: %w", err)
+		}
+
+		fmt.Println(string(data))
+		return nil
+	}
+
+	rows := make([][]string, 0, len(config.Keys))
+	for _, value := range cfg.Values() {
+		rows = append(rows, []string{value.Key, displayConfigValue(value.Value), formatConfigSource(value)})
+	}
+
+	t := cli.CreateStyledTable().
+		Headers("KEY", "VALUE", "SOURCE").
+		Rows(rows...)
+	fmt.Println(t.String())
+
+	return nil
+}
+
+func runConfigGet(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	value, err := cfg.Get(args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s = %s (%s)\n", value.Key, displayConfigValue(value.Value), formatConfigSource(value))
+	return nil
+}
+
+func runConfigSet(cmd *cobra.Command, args []string) error {
+	outputHandler := cli.NewOutputHandler()
+
+	userFlag, err := cmd.Flags().GetBool("user")
+	if err != nil {
+		return fmt.Errorf("failed to read user flag: %w", err)
+	}
+
+	var path string
+	if userFlag {
+		path, err = getUserConfigFile(cmd)
+		if err != nil {
+			return err
+		}
+	} else {
+		projectRoot, rootErr := discovery.GetProjectRoot()
+		if rootErr != nil {
+			return rootErr
+		}
+		path = config.GetProjectConfigPath(assets.GetKrciPath(projectRoot))
+	}
+
+	if err := config.SetInFile(path, args[0], args[1]); err != nil {
+		return err
+	}
+
+	outputHandler.PrintSuccess(fmt.Sprintf("Set %s in %s", args[0], path))
+	return nil
+}
+
+// resolveProjectPath resolves a configured path relative to the project root
+func resolveProjectPath(projectRoot, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+
+	return filepath.Join(projectRoot, path)
+}
+
+// displayConfigValue renders empty values in a readable way
+func displayConfigValue(value string) string {
+	if value == "" {
+		return `""`
+	}
+
+	return value
+}
+
+// formatConfigSource describes where a configuration value came from
+func formatConfigSource(value config.Value) string {
+	if value.Origin == "" {
+		return string(value.Source)
+	}
+
+	return fmt.Sprintf("%s: %s", value.Source, value.Origin)
+}
@@ -0,0 +1,290 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/KubeRocketCI/kuberocketai/internal/assets"
+	"github.com/KubeRocketCI/kuberocketai/internal/cli"
+	"github.com/KubeRocketCI/kuberocketai/internal/diff"
+	"github.com/KubeRocketCI/kuberocketai/internal/discovery"
+	"github.com/KubeRocketCI/kuberocketai/internal/pack"
+	"github.com/KubeRocketCI/kuberocketai/internal/resolver"
+	"github.com/KubeRocketCI/kuberocketai/internal/source"
+	"github.com/KubeRocketCI/kuberocketai/internal/version"
+)
+
+const diffAgainstEmbedded = "embedded"
+
+// diffCmd represents the diff command
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Show how the installed framework differs from the embedded or another version",
+	Long: `Compare the agents, tasks, templates and data installed in .krci-ai/ with
+another version of the framework and print unified diffs per file followed by a
+summary of added, removed and modified files. Only agents and the files they
+depend on are compared.
+
+The installed files are compared against:
+- embedded           the framework shipped with this CLI (default)
+- <pack>@<version>   a pack version from .krci-ai/packs or the configured registries
+- <git-ref>          the .krci-ai directory of the project at a git commit, branch or tag
+
+Examples:
+  krci-ai diff                                  # Compare with the embedded framework
+  krci-ai diff --agent pm                       # Only the files of the pm agent
+  krci-ai diff --against golden-agents@1.2.0    # Compare with a pack version
+  krci-ai diff --against HEAD~1 --stat          # Changed files since the previous commit
+  krci-ai diff --json                           # Machine readable output`,
+	RunE: runDiff,
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+
+	diffCmd.Flags().String("agent", "", "Limit the comparison to the files of the given agents (comma or space separated)")
+	diffCmd.Flags().String("against", diffAgainstEmbedded, "Version to compare with: embedded, <pack>@<version> or a git ref")
+	diffCmd.Flags().Bool("stat", false, "Show changed files with added and deleted line counts instead of diffs")
+	diffCmd.Flags().Bool("json", false, "Output the comparison in JSON format")
+	diffCmd.Flags().Bool("insecure", false, "Compare with registry packs that publish no sha256 checksum without verifying them")
+	addRegistryFlags(diffCmd)
+}
+
+// diffOutput is the JSON representation of a comparison
+type diffOutput struct {
+	*diff.Result
+	Summary map[string]map[diff.Status]int `json:"summary"`
+	Agents  map[diff.Status][]string       `json:"agents"`
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	agentFlag, _ := cmd.Flags().GetString("agent")
+	against, _ := cmd.Flags().GetString("against")
+	stat, _ := cmd.Flags().GetBool("stat")
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+
+	output := cli.NewOutputHandler()
+	if jsonOutput {
+		output = cli.NewQuietOutputHandler()
+	}
+
+	projectRoot, err := discovery.GetProjectRoot()
+	if err != nil {
+		return err
+	}
+
+	krciPath := assets.GetKrciPath(projectRoot)
+	installed := diff.Tree{FS: assets.OSFileSystem{}, Dir: krciPath, Label: "installed"}
+	installedDiscovery := assets.NewDiscovery(krciPath)
+
+	base, baseDiscovery, cleanup, err := diffBase(cmd, projectRoot, against, output)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	var agentNames []string
+	if agentFlag != "" {
+		if agentNames = ParseAgentList(agentFlag); len(agentNames) == 0 {
+			return fmt.Errorf("no valid agent names provided")
+		}
+	}
+
+	// Files that are not part of any agent are never installed and not compared
+	paths, err := diffAgentFiles(projectRoot, agentNames, []diffSide{
+		{tree: base, discovery: baseDiscovery},
+		{tree: installed, discovery: installedDiscovery},
+	})
+	if err != nil {
+		return err
+	}
+
+	result, err := diff.Compare(base, installed, paths)
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		data, err := json.MarshalIndent(diffOutput{
+			Result:  result,
+			Summary: result.Summary(),
+			Agents: map[diff.Status][]string{
+				diff.StatusAdded:    result.Agents(diff.StatusAdded),
+				diff.StatusRemoved:  result.Agents(diff.StatusRemoved),
+				diff.StatusModified: result.Agents(diff.StatusModified),
+			},
+		}, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON output: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	displayDiffResult(result, stat, output)
+
+	return nil
+}
+
+// diffSide is one side of a comparison with the discovery of its agents
+type diffSide struct {
+	tree      diff.Tree
+	discovery *assets.Discovery
+}
+
+// diffBase returns the framework tree to compare the installation with
+func diffBase(cmd *cobra.Command, projectRoot, against string, output *cli.OutputHandler) (diff.Tree, *assets.Discovery, func(), error) {
+	noop := func() {}
+
+	if against == "" || against == diffAgainstEmbedded {
+		return diff.Tree{
+			FS:    assets.NewEmbeddedFileSystem(GetEmbeddedAssets()),
+			Dir:   assets.EmbeddedPrefix,
+			Label: "embedded " + version.GetCurrentVersion(),
+		}, assets.NewEmbeddedDiscovery(GetEmbeddedAssets(), assets.EmbeddedPrefix), noop, nil
+	}
+
+	var (
+		fetched *source.Fetched
+		label   string
+	)
+	if name, _, ok := strings.Cut(against, "@"); ok && pack.IsValidName(name) {
+		req, err := resolver.ParseRequirement(against)
+		if err == nil {
+			catalog, err := packCatalog(cmd, projectRoot, nil, output)
+			if err != nil {
+				return diff.Tree{}, nil, noop, err
+			}
+
+			candidate, err := resolver.New(catalog, version.GetCurrentVersion()).Select(req)
+			if err != nil {
+				return diff.Tree{}, nil, noop, err
+			}
+
+			output.PrintProgress(fmt.Sprintf("Fetching pack %s from %s...", candidate.ID(), candidate.Location))
+			insecure, _ := cmd.Flags().GetBool("insecure")
+			if fetched, _, err = fetchPack(candidate, insecure); err != nil {
+				return diff.Tree{}, nil, noop, err
+			}
+			label = candidate.ID()
+		}
+	}
+
+	if fetched == nil {
+		var err error
+		fetched, err = source.NewFetcher().FetchRef(context.Background(), projectRoot, against, assets.KrciAIDir)
+		if err != nil {
+			return diff.Tree{}, nil, noop, fmt.Errorf("--against %q is neither embedded, a pack version nor a git ref: %w", against, err)
+		}
+		label = against
+	}
+
+	return diff.Tree{
+		FS:    assets.OSFileSystem{},
+		Dir:   fetched.FrameworkDir,
+		Label: label,
+	}, assets.NewDiscovery(fetched.FrameworkDir), fetched.Cleanup, nil
+}
+
+// diffAgentFiles returns the files of the given agents, all agents when empty,
+// and their dependencies on either side
+func diffAgentFiles(projectRoot string, agentNames []string, sides []diffSide) (map[string]struct{}, error) {
+	paths := make(map[string]struct{})
+	found := make(map[string]bool)
+	for _, side := range sides {
+		agents, err := side.discovery.GetAgents(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get agents of %s: %w", side.tree.Label, err)
+		}
+
+		var present []string
+		for _, agent := range agents {
+			if len(agentNames) == 0 || slices.Contains(agentNames, agent.ShortName) {
+				present = append(present, agent.ShortName)
+				found[agent.ShortName] = true
+			}
+		}
+		if len(present) == 0 {
+			continue
+		}
+
+		installer := assets.NewInstallerFromSource(projectRoot, side.tree.FS, side.tree.Dir, side.discovery)
+		files, err := installer.ResolveAgentFiles(present)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			paths[file] = struct{}{}
+		}
+	}
+
+	var missing []string
+	for _, name := range agentNames {
+		if !found[name] {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("agents not found: %s", strings.Join(missing, ", "))
+	}
+
+	return paths, nil
+}
+
+// displayDiffResult prints unified diffs or a diffstat followed by the summary
+func displayDiffResult(result *diff.Result, stat bool, output *cli.OutputHandler) {
+	if len(result.Files) == 0 {
+		output.PrintSuccess(fmt.Sprintf("Installed framework matches %s", result.Base))
+		return
+	}
+
+	if stat {
+		width := 0
+		for _, file := range result.Files {
+			width = max(width, len(file.Path))
+		}
+		for _, file := range result.Files {
+			output.Printf(" %-*s | %4d %s%s (%s)\n", width, file.Path, file.Additions+file.Deletions,
+				strings.Repeat("+", min(file.Additions, 40)), strings.Repeat("-", min(file.Deletions, 40)), file.Status)
+		}
+	} else {
+		for _, file := range result.Files {
+			output.Printf("%s", file.Unified)
+		}
+	}
+
+	output.Newline()
+	output.PrintBold(fmt.Sprintf("Installed framework compared with %s:", result.Base))
+	summary := result.Summary()
+	for _, component := range diff.Components {
+		counts := summary[component]
+		output.PrintInfo(fmt.Sprintf("%-10s %d added, %d removed, %d modified", component+":",
+			counts[diff.StatusAdded], counts[diff.StatusRemoved], counts[diff.StatusModified]))
+	}
+
+	for _, status := range []diff.Status{diff.StatusAdded, diff.StatusRemoved, diff.StatusModified} {
+		if agents := result.Agents(status); len(agents) > 0 {
+			output.PrintInfo(fmt.Sprintf("Agents %s: %s", status, strings.Join(agents, ", ")))
+		}
+	}
+}
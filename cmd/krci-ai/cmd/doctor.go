@@ -0,0 +1,117 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/KubeRocketCI/kuberocketai/internal/cli"
+	"github.com/KubeRocketCI/kuberocketai/internal/discovery"
+	"github.com/KubeRocketCI/kuberocketai/internal/doctor"
+	"github.com/KubeRocketCI/kuberocketai/internal/version"
+)
+
+// doctorCmd represents the doctor command
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose the krci-ai installation and IDE integrations",
+	Long: `Check the environment and the framework installation of the current project
+and print pass, warn or fail for each check with a hint on how to fix it.
+
+This command checks:
+- The project root, including KRCI_AI_PROJECT_DIR and installations in parent directories
+- That the framework is installed and its agents load
+- The lockfile, the CLI version the framework was installed with and local changes
+- That each IDE integration exists and is in sync with the installed agents
+- That MCP servers required by installed tasks are configured in the project or user settings
+
+The command fails when any check fails.
+
+Examples:
+  krci-ai doctor          # Diagnose the installation
+  krci-ai doctor --json   # Machine readable output`,
+	RunE: runDoctor,
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+
+	doctorCmd.Flags().Bool("json", false, "Output the diagnostics in JSON format")
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+
+	projectRoot, err := discovery.GetProjectRoot()
+	if err != nil {
+		return err
+	}
+
+	workingDir, _ := os.Getwd()
+	homeDir, _ := os.UserHomeDir()
+
+	report := doctor.Run(doctor.Options{
+		ProjectDir:    projectRoot,
+		ProjectDirEnv: os.Getenv("KRCI_AI_PROJECT_DIR"),
+		WorkingDir:    workingDir,
+		HomeDir:       homeDir,
+		CLIVersion:    version.GetCurrentVersion(),
+	})
+
+	if jsonOutput {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON output: %w", err)
+		}
+		fmt.Println(string(data))
+	} else {
+		displayDoctorReport(report, cli.NewOutputHandler())
+	}
+
+	if failed := report.Count(doctor.StatusFail); failed > 0 {
+		// Failed checks are not usage errors
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%d doctor checks failed", failed)
+	}
+
+	return nil
+}
+
+// displayDoctorReport prints each check with its hint followed by a summary
+func displayDoctorReport(report *doctor.Report, output *cli.OutputHandler) {
+	for _, check := range report.Checks {
+		message := fmt.Sprintf("%-14s %s", check.Name, check.Message)
+		switch check.Status {
+		case doctor.StatusPass:
+			output.PrintSuccess(message)
+		case doctor.StatusWarn:
+			output.PrintWarning(message)
+		default:
+			output.PrintError(message)
+		}
+		if check.Hint != "" {
+			output.Printf("   → %s\n", check.Hint)
+		}
+	}
+
+	output.Newline()
+	output.PrintInfo(fmt.Sprintf("Summary: %d passed, %d warnings, %d failed",
+		report.Count(doctor.StatusPass), report.Count(doctor.StatusWarn), report.Count(doctor.StatusFail)))
+}
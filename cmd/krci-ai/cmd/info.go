@@ -0,0 +1,133 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/KubeRocketCI/kuberocketai/internal/cli"
+	"github.com/KubeRocketCI/kuberocketai/internal/registry"
+)
+
+// infoCmd represents the info command
+var infoCmd = &cobra.Command{
+	Use:   "info <pack>",
+	Short: "Show details of an agent pack from the registries",
+	Long: `Show the versions, agents, checksums and archive URLs of an agent pack
+published in the configured pack registries.
+
+Examples:
+  krci-ai info golden-agents            # Show all versions of the pack
+  krci-ai info golden-agents --offline  # Use cached registry indexes only
+  krci-ai info golden-agents --json     # Machine readable output`,
+	Args: cobra.ExactArgs(1),
+	RunE: runInfo,
+}
+
+// packInfo is a pack with resolved archive URLs
+type packInfo struct {
+	Registry    string                 `json:"registry"`
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	Versions    []registry.PackVersion `json:"versions"`
+}
+
+func init() {
+	rootCmd.AddCommand(infoCmd)
+	addRegistryFlags(infoCmd)
+	infoCmd.Flags().Bool("json", false, "Output pack details in JSON format")
+}
+
+func runInfo(cmd *cobra.Command, args []string) error {
+	output := cli.NewOutputHandler()
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+
+	results, err := loadRegistries(cmd, output, jsonOutput)
+	if err != nil {
+		return err
+	}
+
+	var infos []packInfo
+	for _, result := range results {
+		pack, ok := result.Index.Find(args[0])
+		if !ok {
+			continue
+		}
+
+		versions := pack.SortedVersions()
+		for idx := range versions {
+			versions[idx].URL = result.Client.ResolveURL(versions[idx].URL)
+		}
+
+		infos = append(infos, packInfo{
+			Registry:    result.Client.BaseURL,
+			Name:        pack.Name,
+			Description: pack.Description,
+			Versions:    versions,
+		})
+	}
+
+	if len(infos) == 0 {
+		return fmt.Errorf("pack %s not found in configured registries, run 'krci-ai search' to list available packs", args[0])
+	}
+
+	if jsonOutput {
+		data, err := json.MarshalIndent(infos, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON output: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	for _, info := range infos {
+		displayPackInfo(info, output)
+	}
+
+	return nil
+}
+
+// displayPackInfo prints a pack and its versions
+func displayPackInfo(info packInfo, output *cli.OutputHandler) {
+	output.Printf("\n%s\n", output.Bold("📦 "+info.Name))
+	output.Printf("%s %s\n", output.PrintCyan("Registry:"), info.Registry)
+	if info.Description != "" {
+		output.Printf("%s %s\n", output.PrintCyan("Description:"), info.Description)
+	}
+	output.Newline()
+
+	for _, v := range info.Versions {
+		output.Printf("  %s %s\n", output.PrintGreenBold("•"), output.Bold(v.Version))
+		if v.Description != "" {
+			output.Printf("    Description: %s\n", v.Description)
+		}
+		output.Printf("    Agents:      %s\n", strings.Join(v.Agents, ", "))
+		if v.MinCLIVersion != "" {
+			output.Printf("    Requires:    krci-ai >= %s\n", v.MinCLIVersion)
+		}
+		output.Printf("    Archive:     %s\n", v.URL)
+		output.Printf("    SHA-256:     %s\n", v.SHA256)
+	}
+
+	if latest, ok := (registry.Pack{Versions: info.Versions}).Latest(); ok {
+		output.Newline()
+		output.PrintInfo(fmt.Sprintf("Install the latest version with: krci-ai install --from %s", latest.URL))
+	}
+}
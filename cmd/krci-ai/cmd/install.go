@@ -16,14 +16,30 @@ limitations under the License.
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"os"
+	"os/signal"
+	"path/filepath"
 	"slices"
+	"strings"
+	"syscall"
 
 	"github.com/spf13/cobra"
 
 	"github.com/KubeRocketCI/kuberocketai/internal/assets"
+	"github.com/KubeRocketCI/kuberocketai/internal/backup"
 	"github.com/KubeRocketCI/kuberocketai/internal/cli"
+	"github.com/KubeRocketCI/kuberocketai/internal/config"
 	"github.com/KubeRocketCI/kuberocketai/internal/discovery"
+	"github.com/KubeRocketCI/kuberocketai/internal/lockfile"
+	"github.com/KubeRocketCI/kuberocketai/internal/pack"
+	"github.com/KubeRocketCI/kuberocketai/internal/resolver"
+	"github.com/KubeRocketCI/kuberocketai/internal/source"
+	"github.com/KubeRocketCI/kuberocketai/internal/tokens"
+	"github.com/KubeRocketCI/kuberocketai/internal/version"
 )
 
 const (
@@ -32,6 +48,12 @@ const (
 	ideClaude   = "claude"
 	ideVSCode   = "vscode"
 	ideWindsurf = "windsurf"
+	ideCopilot  = "copilot"
+	ideGemini   = "gemini"
+	ideCline    = "cline"
+	ideRoo      = "roo"
+	ideKiro     = "kiro"
+	ideAgentsMD = "agentsmd"
 )
 
 // installCmd represents the install command
@@ -48,10 +70,55 @@ This command will set up the AI-as-Code framework by installing:
 
 Supported IDE Integrations:
 - cursor     → .cursor/rules/krci-ai/*.mdc
-- claude     → .claude/commands/krci-ai/*.md
+- claude     → .claude/commands/krci-ai/*.md; --claude-subagents also generates
+               subagents in .claude/agents/*.md that Claude Code can delegate to,
+               kept on sync until removed with --claude-subagents=false
 - vscode     → .github/chatmodes/*.chatmode.md
 - windsurf   → .windsurf/rules/*.md
-- all        → Install all IDE integrations above
+- copilot    → .github/prompts/*.prompt.md, one prompt per agent;
+               --copilot-instructions also lists the agents in .github/copilot-instructions.md
+- gemini     → .gemini/commands/krci-ai/*.toml, run as /krci-ai:<agent>
+- cline      → .clinerules/krci-ai/*.md
+- roo        → .roomodes, a krci-ai-<agent> custom mode per agent next to the modes of the team
+- kiro       → .kiro/steering/*.md, included in the chat as #<agent>
+- agentsmd   → managed section of AGENTS.md indexing the agents, read by Codex, Jules, Zed and others
+- all        → Install all registered IDE integrations
+
+With --task-commands the claude, cursor, copilot and gemini integrations also
+generate a file per agent task, <agent>-<task>, that activates the agent and
+immediately executes the task. Once generated, --sync-ide and 'krci-ai add agent'
+keep them in sync with the agent tasks, until --task-commands=false removes them.
+
+With --inline the IDE files embed the tasks of the agent with their templates and
+data files, deduplicated, for IDE tools that cannot read .krci-ai. Task files embed
+their task and the agent definition. The token count of every inlined file is shown,
+with a warning above install.inline_max_tokens (32000 by default). Once inlined,
+--sync-ide and 'krci-ai add agent' keep inlining until --inline=false. Claude Code
+subagents, Copilot task prompts and AGENTS.md keep referring to the framework files.
+
+--sync-ide regenerates the files of the IDE integrations recorded in the lockfile from
+the installed agents and removes the files generated for agents or tasks that no longer
+exist. Other
+files in the IDE directories are never touched, and removed files with local changes
+are backed up. Every integration reports its added, updated, removed and unchanged files.
+
+Custom IDE integrations are described in .krci-ai/ide/<name>.yaml with the target
+directory, a file name pattern, an extension and a Go text/template rendering the
+agent file, and are installed with --ide <name> like the built-in ones.
+Run 'krci-ai list ides' to see the registered IDE integrations.
+
+Every installation is recorded in .krci-ai/krci-ai.lock with the CLI version,
+selected agents and IDEs, and a SHA-256 hash of every installed file.
+
+Packs are resolved by name with --pack from local packs in .krci-ai/packs and
+the configured registries. Pack dependencies are resolved to the newest versions
+satisfying every semantic version constraint (e.g. shared-standards: ^1.2) and
+installed first, in the same installation: when a pack fails, none is installed.
+Packs and agents requiring a newer krci-ai version are refused.
+Registry packs are verified against the sha256 published in the index: the archive
+SHA-256, or the digest of the pack files for git repositories and directories.
+Packs without a published checksum are refused unless --insecure is set. The verified
+checksum is recorded in the lockfile.
 
 Examples:
   # Basic installation
@@ -60,6 +127,7 @@ Examples:
 
   # IDE integrations (see supported list above)
   krci-ai install --ide cursor                 # Install core + specific IDE integration
+  krci-ai install --ide my-tool                # Install core + custom IDE from .krci-ai/ide/my-tool.yaml
   krci-ai install --all                        # Install core + all IDE integrations
   krci-ai install -a                           # Same as --all (shorthand)
 
@@ -68,6 +136,16 @@ Examples:
   krci-ai install --agents pm,architect        # Install core structure + multiple agents (comma-separated)
   krci-ai install --agent "pm po qa"           # Install core structure + multiple agents (space-separated)
 
+  # Task-level installation (selected tasks + dependencies, agents list only these tasks)
+  krci-ai install --task pm/create-prd         # Install the PRD task of the pm agent
+  krci-ai install --task pm/create-prd,dev/implement-feature -i claude
+
+  # Per-task entry points, e.g. /pm-create-prd in Claude Code or /krci-ai:pm-create-prd in Gemini CLI
+  krci-ai install --ide claude --task-commands
+
+  # Self-contained IDE files for tools without access to .krci-ai
+  krci-ai install --ide windsurf --inline
+
   # Combined selective + IDE
   krci-ai install --agent dev -i cursor        # Install core + dev agent + IDE integration
   krci-ai install --agents pm,po --ide vscode  # Install core + multiple agents + IDE
@@ -76,19 +154,39 @@ Examples:
   krci-ai install --ide cursor --force         # Add IDE integration to existing install
   krci-ai install --all --force                # Force reinstall everything
 
+  # Install from an agent pack instead of the embedded framework
+  krci-ai install --from https://github.com/org/golden-agents.git@v1.2.0
+  krci-ai install --from https://example.com/packs/golden-agents.tar.gz --agent pm
+  krci-ai install --from ../golden-agents --ide claude
+
+  # Install packs and their dependencies from registries or local packs
+  krci-ai install --pack golden-agents         # Newest compatible version
+  krci-ai install --pack golden-agents@^1.2 --pack qa-pack@~0.3 -i cursor
+
   # Sync IDE files from installed agents (instead of embedded assets)
   krci-ai install --sync-ide                   # Sync all existing IDE integrations from installed agents
-  krci-ai install --agent dev --sync-ide       # Install dev agent + sync IDE files`,
+  krci-ai install --agent dev --sync-ide       # Install dev agent + sync IDE files
+
+  # Preview which files would be created, overwritten or left unchanged
+  krci-ai install --force --dry-run            # Impact of a forced reinstall
+  krci-ai install --agent dev -i cursor --plan json  # Machine readable plan for reviews`,
 	Run: func(cmd *cobra.Command, args []string) {
 		errorHandler := cli.NewErrorHandler()
 
-		// Validate IDE flag upfront (common to all installation paths)
-		ideFlag, err := cmd.Flags().GetString("ide")
+		// Load layered configuration, explicit flags take precedence
+		cfg, err := loadConfig(cmd)
 		if err != nil {
+			errorHandler.HandleError(err, "Failed to load configuration")
+			return
+		}
+		if err := cfg.BindFlag(config.KeyInstallIDE, cmd.Flags(), "ide"); err != nil {
 			errorHandler.HandleError(err, "Failed to read IDE flag")
 			return
 		}
 
+		// Validate IDE flag upfront (common to all installation paths)
+		ideFlag := cfg.String(config.KeyInstallIDE)
+
 		// Check --all flag and override IDE if necessary
 		allFlag, err := cmd.Flags().GetBool("all")
 		if err != nil {
@@ -106,6 +204,41 @@ Examples:
 			}
 		}
 
+		// Dry runs print a plan instead of installing
+		planFormat, err := installPlanFormat(cmd)
+		if err != nil {
+			errorHandler.HandleError(err, "Invalid plan format")
+			return
+		}
+
+		// Packs resolved from registries are installed as a whole
+		packSpecs, err := cmd.Flags().GetStringSlice("pack")
+		if err != nil {
+			errorHandler.HandleError(err, "Failed to read pack flag")
+			return
+		}
+		taskFlag, err := cmd.Flags().GetString("task")
+		if err != nil {
+			errorHandler.HandleError(err, "Failed to read task flag")
+			return
+		}
+		if len(packSpecs) > 0 {
+			if taskFlag != "" {
+				errorHandler.PrintError("--pack cannot be combined with --task")
+				return
+			}
+			if from, _ := cmd.Flags().GetString("from"); from != "" {
+				errorHandler.PrintError("--pack cannot be combined with --from")
+				return
+			}
+			if planFormat == planFormatJSON {
+				errorHandler.PrintError("--plan json is not supported with --pack, use --dry-run to preview the resolved packs")
+				return
+			}
+			runPackInstallation(cmd, packSpecs, ideFlag, planFormat != "", output, errorHandler)
+			return
+		}
+
 		// Check for selective installation first
 		agentFlag, err := cmd.Flags().GetString("agent")
 		if err != nil {
@@ -121,6 +254,24 @@ Examples:
 			}
 		}
 
+		// Task-level installation replaces agent selection
+		var tasks map[string][]string
+		if taskFlag != "" {
+			if agentFlag != "" {
+				errorHandler.PrintError("--task cannot be combined with --agent")
+				return
+			}
+			if tasks, err = parseTaskSelectors(taskFlag); err != nil {
+				errorHandler.HandleError(err, "Invalid task selection")
+				return
+			}
+		}
+
+		// Fall back to configured default agents
+		if agentFlag == "" && len(tasks) == 0 {
+			agentFlag = strings.Join(cfg.List(config.KeyInstallAgents), ",")
+		}
+
 		// Check sync-ide flag
 		syncIDEFlag, err := cmd.Flags().GetBool("sync-ide")
 		if err != nil {
@@ -128,6 +279,16 @@ Examples:
 			return
 		}
 
+		if planFormat != "" {
+			runInstallPlan(cmd, agentFlag, tasks, ideFlag, syncIDEFlag, planFormat, errorHandler)
+			return
+		}
+
+		if len(tasks) > 0 {
+			runTaskInstallation(cmd, tasks, ideFlag, syncIDEFlag, output, errorHandler)
+			return
+		}
+
 		if agentFlag != "" {
 			runSelectiveInstallation(cmd, agentFlag, ideFlag, syncIDEFlag, output, errorHandler)
 			return
@@ -139,7 +300,7 @@ Examples:
 }
 
 // runSelectiveInstallation handles installation of specific agents only
-func runSelectiveInstallation(_ *cobra.Command, agentFlag string, ideFlag string, syncIDEFlag bool, output *cli.OutputHandler, errorHandler *cli.ErrorHandler) {
+func runSelectiveInstallation(cmd *cobra.Command, agentFlag string, ideFlag string, syncIDEFlag bool, output *cli.OutputHandler, errorHandler *cli.ErrorHandler) {
 	// Parse agent list using existing bundle logic
 	agentNames := ParseAgentList(agentFlag)
 	if len(agentNames) == 0 {
@@ -157,11 +318,13 @@ func runSelectiveInstallation(_ *cobra.Command, agentFlag string, ideFlag string
 	}
 
 	// Create installer and run selective installation
-	installer := assets.NewInstaller(
-		projectRoot,
-		GetEmbeddedAssets(),
-		assets.NewEmbeddedDiscovery(GetEmbeddedAssets(), assets.EmbeddedPrefix),
-	)
+	installer, cleanup, err := newInstaller(cmd, projectRoot, ideFlag, output, errorHandler)
+	if err != nil {
+		errorHandler.HandleError(err, "Failed to prepare installation source")
+		return
+	}
+	defer cleanup()
+
 	if err := installer.InstallSelective(agentNames); err != nil {
 		errorHandler.HandleError(err, "Failed to install selected agents")
 		return
@@ -179,9 +342,132 @@ func runSelectiveInstallation(_ *cobra.Command, agentFlag string, ideFlag string
 		handleIDESync(installer, output, errorHandler)
 	}
 
+	ides := selectedIDEs(ideFlag)
+	if syncIDEFlag {
+		ides = append(ides, installedIDEs(installer)...)
+	}
+	if err := installer.UpdateLockfile(ides); err != nil {
+		errorHandler.HandleError(err, "Failed to write lockfile")
+		return
+	}
+	commitInstallation(installer, errorHandler)
+
+	showBackupNotice(installer.Backup(), output)
+	showInlinedFiles(cmd, installer, output)
 	output.PrintSuccess(fmt.Sprintf("Selected agents installed successfully: %v", agentNames))
 }
 
+// runTaskInstallation handles installation of selected tasks of agents only
+func runTaskInstallation(cmd *cobra.Command, tasks map[string][]string, ideFlag string, syncIDEFlag bool, output *cli.OutputHandler, errorHandler *cli.ErrorHandler) {
+	projectRoot, err := discovery.GetProjectRoot()
+	if err != nil {
+		errorHandler.HandleError(err, "Failed to get project root")
+		return
+	}
+
+	for _, note := range mergeInstalledTasks(projectRoot, tasks) {
+		output.PrintWarning(note)
+	}
+
+	output.PrintProgress(fmt.Sprintf("Installing selected tasks: %s", formatTaskSelection(tasks)))
+
+	installer, cleanup, err := newInstaller(cmd, projectRoot, ideFlag, output, errorHandler)
+	if err != nil {
+		errorHandler.HandleError(err, "Failed to prepare installation source")
+		return
+	}
+	defer cleanup()
+
+	if err := installer.InstallTasks(tasks); err != nil {
+		errorHandler.HandleError(err, "Failed to install selected tasks")
+		return
+	}
+
+	if ideFlag != "" {
+		output.PrintInfo(fmt.Sprintf("Setting up %s IDE integration for selected agents...", ideFlag))
+		handleIDEIntegration(installer, ideFlag, output, errorHandler)
+	}
+
+	if syncIDEFlag {
+		output.PrintInfo("Syncing IDE integration files from installed agents...")
+		handleIDESync(installer, output, errorHandler)
+	}
+
+	ides := selectedIDEs(ideFlag)
+	if syncIDEFlag {
+		ides = append(ides, installedIDEs(installer)...)
+	}
+	if err := installer.UpdateLockfile(ides); err != nil {
+		errorHandler.HandleError(err, "Failed to write lockfile")
+		return
+	}
+	commitInstallation(installer, errorHandler)
+
+	showBackupNotice(installer.Backup(), output)
+	showInlinedFiles(cmd, installer, output)
+	output.PrintSuccess(fmt.Sprintf("Selected tasks installed successfully: %s", formatTaskSelection(tasks)))
+}
+
+// parseTaskSelectors parses agent/task selectors, comma or space separated, into task names per agent
+func parseTaskSelectors(taskFlag string) (map[string][]string, error) {
+	selectors := ParseAgentList(taskFlag)
+	if len(selectors) == 0 {
+		return nil, fmt.Errorf("no valid tasks provided")
+	}
+
+	tasks := make(map[string][]string)
+	for _, selector := range selectors {
+		agent, task, ok := strings.Cut(selector, "/")
+		if !ok || agent == "" || task == "" || strings.Contains(task, "/") {
+			return nil, fmt.Errorf("invalid task %q, expected <agent>/<task> (e.g. pm/create-prd)", selector)
+		}
+		task = strings.TrimSuffix(task, ".md")
+		if !slices.Contains(tasks[agent], task) {
+			tasks[agent] = append(tasks[agent], task)
+		}
+	}
+
+	return tasks, nil
+}
+
+// mergeInstalledTasks adds the tasks installed before with --task to the selection so that
+// agent definitions keep listing them. It returns notes about agents installed with all tasks.
+func mergeInstalledTasks(projectRoot string, tasks map[string][]string) []string {
+	lock, err := lockfile.Load(assets.GetKrciPath(projectRoot))
+	if err != nil {
+		return nil
+	}
+
+	var notes []string
+	for _, agent := range slices.Sorted(maps.Keys(tasks)) {
+		installed, ok := lock.Tasks[agent]
+		switch {
+		case ok:
+			for _, task := range installed {
+				if !slices.Contains(tasks[agent], task) {
+					tasks[agent] = append(tasks[agent], task)
+				}
+			}
+		case slices.Contains(lock.Agents, agent):
+			notes = append(notes, fmt.Sprintf("Agent %s is installed with all tasks, its definition will list only the selected tasks; run 'krci-ai install --agent %s' to restore it", agent, agent))
+		}
+	}
+
+	return notes
+}
+
+// formatTaskSelection formats a task selection as agent/task selectors
+func formatTaskSelection(tasks map[string][]string) string {
+	var selectors []string
+	for _, agent := range slices.Sorted(maps.Keys(tasks)) {
+		for _, task := range tasks[agent] {
+			selectors = append(selectors, agent+"/"+task)
+		}
+	}
+
+	return strings.Join(selectors, ", ")
+}
+
 // runFullInstallation handles standard full framework installation
 func runFullInstallation(cmd *cobra.Command, ideFlag string, syncIDEFlag bool, output *cli.OutputHandler, errorHandler *cli.ErrorHandler) {
 	output.PrintProgress("Installing KubeRocketAI framework components...")
@@ -205,11 +491,12 @@ func runFullInstallation(cmd *cobra.Command, ideFlag string, syncIDEFlag bool, o
 	}
 
 	// Create installer
-	installer := assets.NewInstaller(
-		projectRoot,
-		GetEmbeddedAssets(),
-		assets.NewEmbeddedDiscovery(GetEmbeddedAssets(), assets.EmbeddedPrefix),
-	)
+	installer, cleanup, err := newInstaller(cmd, projectRoot, ideFlag, output, errorHandler)
+	if err != nil {
+		errorHandler.HandleError(err, "Failed to prepare installation source")
+		return
+	}
+	defer cleanup()
 
 	// Check installation status
 	isAlreadyInstalled := installer.IsInstalled()
@@ -218,6 +505,13 @@ func runFullInstallation(cmd *cobra.Command, ideFlag string, syncIDEFlag bool, o
 	if isAlreadyInstalled && syncIDEFlag && !forceFlag {
 		output.PrintInfo("Framework already installed. Syncing IDE integration files from installed agents...")
 		handleIDESync(installer, output, errorHandler)
+		if err := installer.UpdateLockfile(installedIDEs(installer)); err != nil {
+			errorHandler.HandleError(err, "Failed to write lockfile")
+			return
+		}
+		commitInstallation(installer, errorHandler)
+		showBackupNotice(installer.Backup(), output)
+		showInlinedFiles(cmd, installer, output)
 		output.PrintSuccess("IDE integration files synced successfully!")
 		return
 	}
@@ -241,14 +535,25 @@ func runFullInstallation(cmd *cobra.Command, ideFlag string, syncIDEFlag bool, o
 
 	// Handle IDE integration
 	// If sync-ide is set, use installed agents; otherwise use embedded assets
+	ides := selectedIDEs(ideFlag)
 	if syncIDEFlag {
 		output.PrintInfo("Setting up IDE integration from installed agents...")
 		handleIDESync(installer, output, errorHandler)
+		ides = installedIDEs(installer)
 	} else if ideFlag != "" {
 		handleIDEIntegration(installer, ideFlag, output, errorHandler)
 	}
 
+	// Record what was installed so that local modifications can be detected later
+	if err := installer.UpdateLockfile(ides); err != nil {
+		errorHandler.HandleError(err, "Failed to write lockfile")
+		return
+	}
+	commitInstallation(installer, errorHandler)
+
 	// Show success and next steps
+	showBackupNotice(installer.Backup(), output)
+	showInlinedFiles(cmd, installer, output)
 	showInstallationSuccess(installer, ideFlag, output)
 }
 
@@ -275,23 +580,19 @@ func showInstallationSuccess(installer *assets.Installer, ideFlag string, output
 	// Success
 	output.PrintSuccess("Framework installation completed successfully!")
 	output.PrintInfo("Framework components installed to: " + installer.GetFrameworkPath())
+	output.PrintInfo("Installation recorded in: " + installer.GetLockfilePath())
 
 	// Show next steps
 	output.PrintInfo("\nNext steps:")
 	output.PrintInfo("  • Run 'krci-ai list agents' to see available agents")
 	output.PrintInfo("  • Run 'krci-ai validate' to verify installation")
 
-	if ideFlag == ideCursor || ideFlag == ideAll {
-		output.PrintInfo("  • Cursor IDE rules installed to: " + installer.GetCursorRulesPath() + "/")
-	}
-	if ideFlag == ideClaude || ideFlag == ideAll {
-		output.PrintInfo("  • Claude Code commands installed to: " + installer.GetClaudeCommandsPath() + "/")
-	}
-	if ideFlag == ideVSCode || ideFlag == ideAll {
-		output.PrintInfo("  • VS Code chat modes installed to: " + installer.GetVSCodeChatmodesPath() + "/")
-	}
-	if ideFlag == ideWindsurf || ideFlag == ideAll {
-		output.PrintInfo("  • Windsurf IDE rules installed to: " + installer.GetWindsurfRulesPath() + "/")
+	for _, ide := range selectedIDEs(ideFlag) {
+		descriptor, err := installer.IDEDescriptor(ide)
+		if err != nil {
+			continue
+		}
+		output.PrintInfo(fmt.Sprintf("  • %s files installed to: %s", descriptor.Title(), installer.IDEPath(ide)))
 	}
 }
 
@@ -299,108 +600,594 @@ func init() {
 	rootCmd.AddCommand(installCmd)
 
 	// Add IDE integration flag
-	installCmd.Flags().StringP("ide", "i", "", "IDE integration: cursor, claude, vscode, windsurf, or 'all' for everything")
+	installCmd.Flags().StringP("ide", "i", "", "IDE integration: cursor, claude, vscode, windsurf, copilot, gemini, cline, roo, kiro, agentsmd, a custom IDE from .krci-ai/ide, or 'all' for everything")
 
 	// Add force flag
 	installCmd.Flags().BoolP("force", "f", false, "Force installation even if framework is already installed")
 
 	// Add all flag
-	installCmd.Flags().BoolP("all", "a", false, "Install core framework with all IDE integrations (equivalent to --ide=all)")
+	installCmd.Flags().BoolP("all", "a", false, "Install core framework with all registered IDE integrations (equivalent to --ide=all)")
 
 	// Add selective installation flags (following bundle command patterns)
 	installCmd.Flags().String("agent", "", "Install specific agents (comma or space separated: 'pm,architect' or 'pm architect')")
 	installCmd.Flags().String("agents", "", "Alias for --agent flag")
+	installCmd.Flags().String("task", "", "Install specific tasks with their dependencies: agent/task (comma or space separated: 'pm/create-prd,dev/implement-feature')")
+
+	// Add Claude Code subagents flag
+	installCmd.Flags().Bool("claude-subagents", false, "Also generate a Claude Code subagent per agent in .claude/agents/ (claude IDE integration)")
+
+	// Add per-task commands flag
+	installCmd.Flags().Bool("task-commands", false, "Also generate a command, rule or prompt per agent task that activates the agent and runs the task (claude, cursor, copilot, gemini)")
+
+	// Add inline flag
+	installCmd.Flags().Bool("inline", false, "Embed the tasks of each agent with their templates and data files in the IDE files, for tools that cannot read .krci-ai")
+
+	// Add GitHub Copilot repository instructions flag
+	installCmd.Flags().Bool("copilot-instructions", false, "List the installed agents in a managed section of .github/copilot-instructions.md (copilot IDE integration)")
 
 	// Add sync-ide flag
 	installCmd.Flags().Bool("sync-ide", false, "Sync IDE integration files from installed agents")
+
+	// Add remote source flag
+	installCmd.Flags().String("from", "", "Install agents from a pack: <git-url|https-tarball|local-dir>[@ref] instead of the embedded framework")
+
+	// Add pack resolution flags
+	installCmd.Flags().StringSlice("pack", nil, "Install packs with dependencies from registries or local packs: name[@constraint] (repeatable)")
+	installCmd.Flags().Bool("insecure", false, "Install registry packs that publish no sha256 checksum without verifying them")
+	addRegistryFlags(installCmd)
+
+	// Add plan flags
+	installCmd.Flags().Bool("dry-run", false, "Show which files would be created, overwritten or left unchanged without writing anything")
+	installCmd.Flags().String("plan", "", "Print the installation plan without writing anything: text or json")
 }
 
-// validateIDEFlag validates the IDE flag value and returns error if invalid
+// validateIDEFlag validates the IDE flag value against the registered IDE integrations and returns error if invalid
 func validateIDEFlag(ideFlag string, errorHandler *cli.ErrorHandler) error {
-	validIDEs := []string{ideCursor, ideClaude, ideVSCode, ideWindsurf, ideAll}
-	if slices.Contains(validIDEs, ideFlag) {
+	registry, err := projectIDERegistry()
+	if err != nil {
+		if errorHandler != nil {
+			errorHandler.PrintError(err.Error())
+		}
+		return err
+	}
+
+	if ideFlag == ideAll {
+		return nil
+	}
+	if _, ok := registry.Get(ideFlag); ok {
 		return nil
 	}
 
-	errorHandler.PrintError(fmt.Sprintf("Invalid IDE '%s'. Valid options: cursor, claude, vscode, windsurf, all", ideFlag))
+	if errorHandler != nil {
+		errorHandler.PrintError(fmt.Sprintf("Invalid IDE '%s'. Valid options: %s, all", ideFlag, strings.Join(registry.Names(), ", ")))
+	}
 	return fmt.Errorf("invalid IDE flag")
 }
 
-// handleIDEIntegration handles IDE integration setup for any installation type
-func handleIDEIntegration(installer *assets.Installer, ideFlag string, output *cli.OutputHandler, errorHandler *cli.ErrorHandler) {
-	if ideFlag == ideCursor || ideFlag == ideAll {
-		output.PrintInfo("Setting up Cursor IDE integration...")
-		if err := installer.InstallCursorIntegration(); err != nil {
-			errorHandler.HandleError(err, "Failed to install Cursor IDE integration")
-			return
+// projectIDERegistry returns the IDE integrations of the current project: the built-in ones and
+// the custom descriptors in .krci-ai/ide, only the built-in ones outside of a project
+func projectIDERegistry() (*assets.IDERegistry, error) {
+	projectRoot, err := discovery.GetProjectRoot()
+	if err != nil {
+		return assets.BuiltinIDERegistry(), nil
+	}
+
+	return assets.LoadIDERegistry(assets.GetKrciPath(projectRoot))
+}
+
+// newInstaller creates an installer for the embedded framework or, with --from, for a fetched pack,
+// and begins its installation unless only a plan is requested. Dependencies declared by a fetched
+// pack are resolved and staged in the same installation first. The returned cleanup function rolls
+// back the installation unless it was committed and removes temporary files of fetched packs.
+func newInstaller(cmd *cobra.Command, projectRoot, ideFlag string, output *cli.OutputHandler, errorHandler *cli.ErrorHandler) (*assets.Installer, func(), error) {
+	from, err := cmd.Flags().GetString("from")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read from flag: %w", err)
+	}
+	format, _ := installPlanFormat(cmd)
+
+	if from == "" {
+		installer := assets.NewInstaller(
+			projectRoot,
+			GetEmbeddedAssets(),
+			assets.NewEmbeddedDiscovery(GetEmbeddedAssets(), assets.EmbeddedPrefix),
+		)
+		applyIDEOptions(cmd, installer)
+		if format != "" {
+			return installer, func() {}, nil
 		}
-		output.PrintSuccess("Cursor IDE integration installed successfully!")
+		return installer, beginInstallation(installer, errorHandler), nil
 	}
 
-	if ideFlag == ideClaude || ideFlag == ideAll {
-		output.PrintInfo("Setting up Claude Code integration...")
-		if err := installer.InstallClaudeIntegration(); err != nil {
-			errorHandler.HandleError(err, "Failed to install Claude Code integration")
-			return
+	spec, err := source.ParseSpec(from)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	output.PrintProgress(fmt.Sprintf("Fetching %s source %s...", spec.Type, spec))
+	fetched, err := source.NewFetcher().Fetch(context.Background(), spec)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	installer, manifest, err := newPackInstaller(projectRoot, fetched)
+	if err != nil {
+		fetched.Cleanup()
+		return nil, nil, err
+	}
+	applyIDEOptions(cmd, installer)
+	if format != "" {
+		return installer, fetched.Cleanup, nil
+	}
+
+	end := beginInstallation(installer, errorHandler)
+	cleanup := func() {
+		end()
+		fetched.Cleanup()
+	}
+	if manifest != nil && len(manifest.Dependencies) > 0 {
+		if err := installPackDependencies(cmd, installer, projectRoot, spec, manifest, ideFlag, output); err != nil {
+			cleanup()
+			return nil, nil, err
 		}
-		output.PrintSuccess("Claude Code integration installed successfully!")
 	}
 
-	if ideFlag == ideVSCode || ideFlag == ideAll {
-		output.PrintInfo("Setting up VS Code integration...")
-		if err := installer.InstallVSCodeIntegration(); err != nil {
-			errorHandler.HandleError(err, "Failed to install VS Code integration")
+	return installer, cleanup, nil
+}
+
+// applyIDEOptions sets the optional outputs of IDE integrations selected by install flags.
+// The flags are only defined by install, other commands keep the options of the installation.
+// An option set to false explicitly is turned off and the files it generated are removed.
+func applyIDEOptions(cmd *cobra.Command, installer *assets.Installer) {
+	flags := cmd.Flags()
+	if flags.Changed("claude-subagents") {
+		enabled, _ := flags.GetBool("claude-subagents")
+		installer.WithClaudeSubagents(enabled)
+	}
+	if flags.Changed("task-commands") {
+		enabled, _ := flags.GetBool("task-commands")
+		installer.WithTaskCommands(enabled)
+	}
+	if copilotInstructions, _ := flags.GetBool("copilot-instructions"); copilotInstructions {
+		installer.WithCopilotInstructions()
+	}
+	if flags.Changed("inline") {
+		enabled, _ := flags.GetBool("inline")
+		installer.WithInline(enabled)
+	}
+}
+
+// showInlinedFiles prints the token count of the IDE files written with inlined dependencies and
+// warns about files exceeding the configured limit
+func showInlinedFiles(cmd *cobra.Command, installer *assets.Installer, output *cli.OutputHandler) {
+	files := installer.InlinedFiles()
+	if len(files) == 0 {
+		return
+	}
+
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		output.PrintWarning(fmt.Sprintf("Token analysis of inlined IDE files failed: %v", err))
+		return
+	}
+
+	engine, err := tokens.NewEngineForModel(cfg.String(config.KeyTokensModel))
+	if err != nil {
+		output.PrintWarning(fmt.Sprintf("Token analysis of inlined IDE files failed: %v", err))
+		return
+	}
+
+	projectRoot := filepath.Dir(installer.GetFrameworkPath())
+	limit := cfg.Int(config.KeyInlineMaxTokens)
+	total := 0
+	var oversized []string
+
+	output.PrintInfo("IDE files with inlined dependencies:")
+	for _, file := range files {
+		count, err := engine.CalculateTokens(cmd.Context(), string(file.Content))
+		if err != nil {
+			output.PrintWarning(fmt.Sprintf("Token analysis of inlined IDE files failed: %v", err))
 			return
 		}
-		output.PrintSuccess("VS Code integration installed successfully!")
+
+		path, err := filepath.Rel(projectRoot, file.Path)
+		if err != nil {
+			path = file.Path
+		}
+		output.PrintInfo(fmt.Sprintf("  • %s: %d tokens", path, count))
+
+		total += count
+		if limit > 0 && count > limit {
+			oversized = append(oversized, fmt.Sprintf("%s (%d tokens)", path, count))
+		}
+	}
+	output.PrintInfo(fmt.Sprintf("  Total: %d tokens in %d files", total, len(files)))
+
+	if len(oversized) > 0 {
+		output.PrintWarning(fmt.Sprintf("%d inlined IDE files exceed the configured limit of %d tokens (%s): %s",
+			len(oversized), limit, config.KeyInlineMaxTokens, strings.Join(oversized, ", ")))
+		output.PrintInfo("  Install fewer agents or tasks, or raise the limit with 'krci-ai config set " + config.KeyInlineMaxTokens + " <tokens>'")
+	}
+}
+
+// newPackInstaller creates an installer for fetched framework files.
+// When the files are an agent pack, its manifest is returned and the pack is refused
+// if it requires a newer CLI version.
+func newPackInstaller(projectRoot string, fetched *source.Fetched) (*assets.Installer, *pack.Manifest, error) {
+	installer := assets.NewInstallerFromSource(
+		projectRoot,
+		assets.OSFileSystem{},
+		fetched.FrameworkDir,
+		assets.NewDiscovery(fetched.FrameworkDir),
+	).WithSource(fetched.Source)
+
+	manifest, err := pack.LoadManifest(fetched.FrameworkDir)
+	if errors.Is(err, os.ErrNotExist) {
+		return installer, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := version.CheckMinimumVersion(version.GetCurrentVersion(), manifest.MinCLIVersion); err != nil {
+		return nil, nil, fmt.Errorf("pack %s@%s %w", manifest.Name, manifest.Version, err)
+	}
+
+	return installer.WithPack(manifest.Name, manifest.Version), manifest, nil
+}
+
+// installPackDependencies resolves the dependencies of a pack installed with --from and stages them
+// in the installation of the pack
+func installPackDependencies(cmd *cobra.Command, installer *assets.Installer, projectRoot string, spec source.Spec, manifest *pack.Manifest, ideFlag string, output *cli.OutputHandler) error {
+	output.PrintProgress(fmt.Sprintf("Resolving dependencies of pack %s@%s...", manifest.Name, manifest.Version))
+
+	// The fetched pack takes part in the resolution so that constraints on it are checked as well
+	root := resolver.Candidate{
+		Name:          manifest.Name,
+		Version:       manifest.Version,
+		Dependencies:  manifest.Dependencies,
+		MinCLIVersion: manifest.MinCLIVersion,
+		Location:      spec.String(),
+		Origin:        spec.String(),
+	}
+
+	resolved, err := resolvePacks(cmd, projectRoot, []resolver.Requirement{{Name: manifest.Name, Constraint: "=" + manifest.Version}}, &root, output)
+	if err != nil {
+		return err
+	}
+
+	dependencies := slices.DeleteFunc(resolved, func(c resolver.Candidate) bool {
+		return strings.EqualFold(c.Name, manifest.Name)
+	})
+
+	if format, _ := installPlanFormat(cmd); format != "" {
+		output.PrintInfo("Dry run: pack dependencies are not installed")
+		return nil
 	}
 
-	if ideFlag == ideWindsurf || ideFlag == ideAll {
-		output.PrintInfo("Setting up Windsurf IDE integration...")
-		if err := installer.InstallWindsurfIntegration(); err != nil {
-			errorHandler.HandleError(err, "Failed to install Windsurf IDE integration")
+	insecure, _ := cmd.Flags().GetBool("insecure")
+	return installPacks(installer, projectRoot, dependencies, ideFlag, insecure, output)
+}
+
+// runPackInstallation resolves the requested packs with their dependencies and installs them in dependency order
+func runPackInstallation(cmd *cobra.Command, packSpecs []string, ideFlag string, dryRun bool, output *cli.OutputHandler, errorHandler *cli.ErrorHandler) {
+	requests := make([]resolver.Requirement, 0, len(packSpecs))
+	for _, spec := range packSpecs {
+		req, err := resolver.ParseRequirement(spec)
+		if err != nil {
+			errorHandler.HandleError(err, "Invalid pack request")
 			return
 		}
-		output.PrintSuccess("Windsurf IDE integration installed successfully!")
+		requests = append(requests, req)
+	}
+
+	projectRoot, err := discovery.GetProjectRoot()
+	if err != nil {
+		errorHandler.HandleError(err, "Failed to get project root")
+		return
+	}
+
+	output.PrintProgress(fmt.Sprintf("Resolving packs: %s", strings.Join(packSpecs, ", ")))
+	resolved, err := resolvePacks(cmd, projectRoot, requests, nil, output)
+	if err != nil {
+		errorHandler.HandleError(err, "Failed to resolve packs")
+		return
+	}
+
+	if dryRun {
+		output.PrintInfo("Dry run: no packs were installed")
+		return
 	}
+
+	// All packs are staged in one installation so that they are installed all or none
+	installation := assets.NewInstaller(
+		projectRoot,
+		GetEmbeddedAssets(),
+		assets.NewEmbeddedDiscovery(GetEmbeddedAssets(), assets.EmbeddedPrefix),
+	)
+	defer beginInstallation(installation, errorHandler)()
+
+	insecure, _ := cmd.Flags().GetBool("insecure")
+	if err := installPacks(installation, projectRoot, resolved, ideFlag, insecure, output); err != nil {
+		errorHandler.HandleError(err, "Failed to install packs")
+		return
+	}
+	commitInstallation(installation, errorHandler)
+
+	showBackupNotice(installation.Backup(), output)
+	output.PrintSuccess(fmt.Sprintf("Installed %d packs successfully", len(resolved)))
 }
 
-// handleIDESync syncs IDE integration files from installed agents
-func handleIDESync(installer *assets.Installer, output *cli.OutputHandler, errorHandler *cli.ErrorHandler) {
-	// Check which IDE directories exist and sync them
-	if installer.HasCursorIntegration() {
-		output.PrintInfo("Syncing Cursor IDE integration...")
-		if err := installer.SyncCursorIntegration(); err != nil {
-			errorHandler.HandleError(err, "Failed to sync Cursor IDE integration")
-			return
+// resolvePacks resolves the requests against local packs and the configured registries.
+// An optional root candidate is added to the catalog before all other sources.
+func resolvePacks(cmd *cobra.Command, projectRoot string, requests []resolver.Requirement, root *resolver.Candidate, output *cli.OutputHandler) ([]resolver.Candidate, error) {
+	catalog, err := packCatalog(cmd, projectRoot, root, output)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved, err := resolver.New(catalog, version.GetCurrentVersion()).Resolve(requests)
+	if err != nil {
+		return nil, err
+	}
+
+	output.PrintInfo("Resolved packs:")
+	for _, candidate := range resolved {
+		output.Printf("  • %s (%s)\n", candidate.ID(), candidate.Origin)
+	}
+
+	return resolved, nil
+}
+
+// packCatalog collects the available packs from local packs and the configured registries.
+// An optional root candidate is added to the catalog before all other sources.
+func packCatalog(cmd *cobra.Command, projectRoot string, root *resolver.Candidate, output *cli.OutputHandler) (*resolver.Catalog, error) {
+	catalog := resolver.NewCatalog()
+	if root != nil {
+		if err := catalog.Add(*root); err != nil {
+			return nil, err
 		}
-		output.PrintSuccess("Cursor IDE integration synced successfully!")
 	}
 
-	if installer.HasClaudeIntegration() {
-		output.PrintInfo("Syncing Claude Code integration...")
-		if err := installer.SyncClaudeIntegration(); err != nil {
-			errorHandler.HandleError(err, "Failed to sync Claude Code integration")
-			return
+	if err := catalog.AddDirectory(resolveProjectPath(projectRoot, defaultPackOutputDir)); err != nil {
+		return nil, err
+	}
+
+	results, err := loadRegistries(cmd, output, false)
+	if err != nil {
+		if catalog.Len() == 0 {
+			return nil, err
 		}
-		output.PrintSuccess("Claude Code integration synced successfully!")
+		output.PrintWarning(fmt.Sprintf("Resolving from local packs only: %v", err))
+	}
+	for _, result := range results {
+		catalog.AddIndex(result.Client, result.Index)
 	}
 
-	if installer.HasVSCodeIntegration() {
-		output.PrintInfo("Syncing VS Code integration...")
-		if err := installer.SyncVSCodeIntegration(); err != nil {
-			errorHandler.HandleError(err, "Failed to sync VS Code integration")
-			return
+	return catalog, nil
+}
+
+// installPacks fetches and installs resolved packs in order, verifying their checksums. The packs
+// are staged in the installation in progress of owner and committed with it.
+func installPacks(owner *assets.Installer, projectRoot string, packs []resolver.Candidate, ideFlag string, insecure bool, output *cli.OutputHandler) error {
+	for _, candidate := range packs {
+		if err := installPack(owner, projectRoot, candidate, ideFlag, insecure, output); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// installPack fetches a resolved pack and stages all of its agents in the installation of owner
+func installPack(owner *assets.Installer, projectRoot string, candidate resolver.Candidate, ideFlag string, insecure bool, output *cli.OutputHandler) error {
+	output.PrintProgress(fmt.Sprintf("Installing pack %s from %s...", candidate.ID(), candidate.Location))
+	fetched, checksum, err := fetchPack(candidate, insecure)
+	if err != nil {
+		return err
+	}
+	defer fetched.Cleanup()
+	if checksum == "" && candidate.Origin != resolver.OriginLocal {
+		output.PrintWarning(fmt.Sprintf("Pack %s is installed without checksum verification", candidate.ID()))
+	}
+
+	installer, _, err := newPackInstaller(projectRoot, fetched)
+	if err != nil {
+		return err
+	}
+	installer.WithPack(candidate.Name, candidate.Version).WithPackChecksum(checksum)
+	if err := installer.Join(owner); err != nil {
+		return err
+	}
+
+	if err := installer.Install(); err != nil {
+		return fmt.Errorf("failed to install pack %s: %w", candidate.ID(), err)
+	}
+
+	if err := installIDEIntegrations(installer, ideFlag, output); err != nil {
+		return err
+	}
+
+	if err := installer.UpdateLockfile(selectedIDEs(ideFlag)); err != nil {
+		return err
+	}
+
+	return installer.Commit()
+}
+
+// beginInstallation stages the files written by the installer until commitInstallation moves
+// them into the project at once. The installation is rolled back when the command fails,
+// returns without committing or is interrupted. The returned function must be deferred.
+func beginInstallation(installer *assets.Installer, errorHandler *cli.ErrorHandler) func() {
+	if err := installer.Begin(); err != nil {
+		errorHandler.HandleError(err, "Failed to start installation")
+	}
+
+	unregister := cli.OnExit(func() { rollbackInstallation(installer, errorHandler) })
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-signals:
+			rollbackInstallation(installer, errorHandler)
+			os.Exit(130)
+		case <-done:
+		}
+	}()
+
+	return func() {
+		signal.Stop(signals)
+		close(done)
+		unregister()
+		_, _ = installer.Rollback()
+	}
+}
+
+// commitInstallation moves the staged files of the installation into the project
+func commitInstallation(installer *assets.Installer, errorHandler *cli.ErrorHandler) {
+	if err := installer.Commit(); err != nil {
+		errorHandler.HandleError(err, "Failed to complete installation")
+	}
+}
+
+// rollbackInstallation discards the staged files of an installation in progress
+func rollbackInstallation(installer *assets.Installer, errorHandler *cli.ErrorHandler) {
+	rolledBack, err := installer.Rollback()
+	switch {
+	case err != nil:
+		errorHandler.PrintError(fmt.Sprintf("Failed to roll back installation: %v", err))
+	case rolledBack:
+		errorHandler.PrintWarning("Installation rolled back, no files were changed")
+	}
+}
+
+// showBackupNotice tells where locally modified files were saved before being overwritten
+func showBackupNotice(session *backup.Session, output *cli.OutputHandler) {
+	files := session.Files()
+	if len(files) == 0 {
+		return
+	}
+
+	output.PrintWarning(fmt.Sprintf("%d locally modified files were overwritten or removed and backed up to %s", len(files), session.Path()))
+	for _, file := range files {
+		output.Printf("  • %s\n", file)
+	}
+	output.PrintInfo(fmt.Sprintf("Run 'krci-ai restore %s' to roll them back", session.ID()))
+}
+
+// fetchPack fetches a resolved pack and verifies the checksum published for it: the archive SHA-256
+// for archives, the digest of the pack files for git repositories and directories. Packs from
+// registries without a checksum are refused unless insecure is set. It returns the verified
+// checksum, empty when the pack was not verified.
+func fetchPack(candidate resolver.Candidate, insecure bool) (*source.Fetched, string, error) {
+	spec, err := source.ParseSpec(candidate.Location)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if candidate.SHA256 == "" && candidate.Origin != resolver.OriginLocal && !insecure {
+		return nil, "", fmt.Errorf("pack %s from %s has no published sha256 checksum, pass --insecure to install it unverified", candidate.ID(), candidate.Origin)
+	}
+
+	fetched, err := source.NewFetcher().Fetch(context.Background(), spec)
+	if err != nil {
+		return nil, "", err
+	}
+	if candidate.SHA256 == "" {
+		return fetched, "", nil
+	}
+
+	checksum := fetched.Source.Revision
+	if spec.Type != lockfile.SourceTarball {
+		if checksum, err = source.Digest(fetched.FrameworkDir); err != nil {
+			fetched.Cleanup()
+			return nil, "", err
+		}
+	}
+
+	if !strings.EqualFold(candidate.SHA256, checksum) {
+		fetched.Cleanup()
+		return nil, "", fmt.Errorf("checksum mismatch for pack %s: expected %s, got %s", candidate.ID(), candidate.SHA256, checksum)
+	}
+
+	return fetched, checksum, nil
+}
+
+// selectedIDEs expands the IDE flag into the list of IDE integrations it installs
+func selectedIDEs(ideFlag string) []string {
+	switch ideFlag {
+	case "":
+		return nil
+	case ideAll:
+		registry, err := projectIDERegistry()
+		if err != nil {
+			return nil
+		}
+		return registry.Names()
+	default:
+		return []string{ideFlag}
+	}
+}
+
+// installedIDEs returns the IDE integrations present in the project
+func installedIDEs(installer *assets.Installer) []string {
+	return installer.InstalledIDEs()
+}
+
+// handleIDEIntegration handles IDE integration setup for any installation type
+func handleIDEIntegration(installer *assets.Installer, ideFlag string, output *cli.OutputHandler, errorHandler *cli.ErrorHandler) {
+	if err := installIDEIntegrations(installer, ideFlag, output); err != nil {
+		errorHandler.HandleError(err, "Failed to install IDE integration")
+	}
+}
+
+// installIDEIntegrations installs the IDE integrations selected by the IDE flag
+func installIDEIntegrations(installer *assets.Installer, ideFlag string, output *cli.OutputHandler) error {
+	for _, ide := range selectedIDEs(ideFlag) {
+		descriptor, err := installer.IDEDescriptor(ide)
+		if err != nil {
+			return err
 		}
-		output.PrintSuccess("VS Code integration synced successfully!")
+
+		output.PrintInfo(fmt.Sprintf("Setting up %s integration...", descriptor.Title()))
+		if err := installer.InstallIDE(ide); err != nil {
+			return fmt.Errorf("failed to install %s integration: %w", descriptor.Title(), err)
+		}
+		output.PrintSuccess(fmt.Sprintf("%s integration installed successfully!", descriptor.Title()))
 	}
 
-	if installer.HasWindsurfIntegration() {
-		output.PrintInfo("Syncing Windsurf IDE integration...")
-		if err := installer.SyncWindsurfIntegration(); err != nil {
-			errorHandler.HandleError(err, "Failed to sync Windsurf IDE integration")
+	return nil
+}
+
+// handleIDESync syncs IDE integration files from installed agents
+func handleIDESync(installer *assets.Installer, output *cli.OutputHandler, errorHandler *cli.ErrorHandler) {
+	// Sync the IDE integrations present in the project
+	for _, ide := range installer.InstalledIDEs() {
+		descriptor, err := installer.IDEDescriptor(ide)
+		if err != nil {
+			errorHandler.HandleError(err, "Failed to sync IDE integration")
 			return
 		}
-		output.PrintSuccess("Windsurf IDE integration synced successfully!")
+
+		output.PrintInfo(fmt.Sprintf("Syncing %s integration...", descriptor.Title()))
+		result, err := installer.SyncIDE(ide)
+		if err != nil {
+			errorHandler.HandleError(err, fmt.Sprintf("Failed to sync %s integration", descriptor.Title()))
+			return
+		}
+		for _, change := range result.Changes {
+			if change.Action == assets.IDEFileRemoved {
+				output.PrintInfo(fmt.Sprintf("  Removed stale file %s", change.Path))
+			}
+		}
+		output.PrintSuccess(fmt.Sprintf("%s integration synced successfully! (%d added, %d updated, %d removed, %d unchanged)",
+			descriptor.Title(),
+			result.Count(assets.IDEFileAdded),
+			result.Count(assets.IDEFileUpdated),
+			result.Count(assets.IDEFileRemoved),
+			result.Count(assets.IDEFileUnchanged),
+		))
 	}
 }
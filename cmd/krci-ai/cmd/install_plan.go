@@ -0,0 +1,212 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	"github.com/KubeRocketCI/kuberocketai/internal/assets"
+	"github.com/KubeRocketCI/kuberocketai/internal/cli"
+	"github.com/KubeRocketCI/kuberocketai/internal/discovery"
+)
+
+const (
+	planFormatText = "text"
+	planFormatJSON = "json"
+)
+
+// installPlanOutput is the machine readable installation plan
+type installPlanOutput struct {
+	*assets.InstallPlan
+	Summary map[assets.PlanStatus]int `json:"summary"`
+	Notes   []string                  `json:"notes,omitempty"`
+}
+
+// installPlanFormat returns the requested plan format, empty when the installation should run
+func installPlanFormat(cmd *cobra.Command) (string, error) {
+	format, _ := cmd.Flags().GetString("plan")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	switch format {
+	case "":
+		if dryRun {
+			return planFormatText, nil
+		}
+		return "", nil
+	case planFormatText, planFormatJSON:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unsupported plan format %q, use %s or %s", format, planFormatText, planFormatJSON)
+	}
+}
+
+// runInstallPlan prints which files the installation with the given flags would write
+func runInstallPlan(cmd *cobra.Command, agentFlag string, tasks map[string][]string, ideFlag string, syncIDEFlag bool, format string, errorHandler *cli.ErrorHandler) {
+	planOutput := output
+	if format == planFormatJSON {
+		planOutput = cli.NewQuietOutputHandler()
+	}
+
+	forceFlag, err := cmd.Flags().GetBool("force")
+	if err != nil {
+		errorHandler.HandleError(err, "Failed to read force flag")
+		return
+	}
+
+	projectRoot, err := discovery.GetProjectRoot()
+	if err != nil {
+		errorHandler.HandleError(err, "Failed to get project root")
+		return
+	}
+
+	var taskNotes []string
+	if len(tasks) > 0 {
+		taskNotes = mergeInstalledTasks(projectRoot, tasks)
+	}
+
+	installer, cleanup, err := newInstaller(cmd, projectRoot, ideFlag, planOutput, errorHandler)
+	if err != nil {
+		errorHandler.HandleError(err, "Failed to prepare installation source")
+		return
+	}
+	defer cleanup()
+
+	opts, notes := installPlanOptions(installer, agentFlag, tasks, ideFlag, syncIDEFlag, forceFlag)
+	notes = append(taskNotes, notes...)
+
+	plan, err := installer.PlanInstall(opts)
+	if err != nil {
+		errorHandler.HandleError(err, "Failed to compute installation plan")
+		return
+	}
+
+	if format == planFormatJSON {
+		result := installPlanOutput{
+			InstallPlan: plan,
+			Summary: map[assets.PlanStatus]int{
+				assets.PlanCreate:    plan.Count(assets.PlanCreate),
+				assets.PlanOverwrite: plan.Count(assets.PlanOverwrite),
+				assets.PlanUnchanged: plan.Count(assets.PlanUnchanged),
+				assets.PlanRemove:    plan.Count(assets.PlanRemove),
+			},
+			Notes: notes,
+		}
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			errorHandler.HandleError(err, "Failed to marshal JSON output")
+			return
+		}
+		fmt.Println(string(data))
+		return
+	}
+
+	displayInstallPlan(plan, notes, planOutput)
+}
+
+// installPlanOptions mirrors the decisions of the installation paths for the given flags
+func installPlanOptions(installer *assets.Installer, agentFlag string, tasks map[string][]string, ideFlag string, syncIDEFlag, forceFlag bool) (assets.PlanOptions, []string) {
+	var notes []string
+
+	// Selective installation: agents or tasks, then the requested IDE, then sync of existing integrations
+	if agentFlag != "" || len(tasks) > 0 {
+		ides := selectedIDEs(ideFlag)
+		if syncIDEFlag {
+			ides = append(ides, installedIDEs(installer)...)
+		}
+		if len(tasks) > 0 {
+			return assets.PlanOptions{Tasks: tasks, IDEs: uniqueIDEs(ides)}, notes
+		}
+		return assets.PlanOptions{Agents: ParseAgentList(agentFlag), IDEs: uniqueIDEs(ides)}, notes
+	}
+
+	// Full installation of an existing framework only syncs IDE files or does nothing without --force
+	if installer.IsInstalled() && !forceFlag {
+		if syncIDEFlag {
+			notes = append(notes, "Framework already installed: only IDE integration files would be synced")
+			return assets.PlanOptions{SkipFramework: true, IDEs: installedIDEs(installer)}, notes
+		}
+		notes = append(notes, "Framework already installed: nothing would be installed without --force")
+		return assets.PlanOptions{SkipFramework: true}, notes
+	}
+
+	if syncIDEFlag {
+		return assets.PlanOptions{IDEs: installedIDEs(installer)}, notes
+	}
+
+	return assets.PlanOptions{IDEs: selectedIDEs(ideFlag)}, notes
+}
+
+// uniqueIDEs removes duplicate IDE names keeping the first occurrence
+func uniqueIDEs(ides []string) []string {
+	var result []string
+	for _, ide := range ides {
+		if !slices.Contains(result, ide) {
+			result = append(result, ide)
+		}
+	}
+
+	return result
+}
+
+// displayInstallPlan prints the files that would be created or overwritten and a summary
+func displayInstallPlan(plan *assets.InstallPlan, notes []string, output *cli.OutputHandler) {
+	output.PrintBold("Installation Plan (Dry Run):")
+	if len(plan.Agents) > 0 {
+		output.PrintInfo(fmt.Sprintf("Agents: %v", plan.Agents))
+	}
+	for _, note := range notes {
+		output.PrintWarning(note)
+	}
+
+	var rows [][]string
+	for _, file := range plan.Files {
+		if file.Status == assets.PlanUnchanged {
+			continue
+		}
+
+		status := string(file.Status)
+		if file.LocalChanges {
+			status += " (local changes)"
+		}
+		rows = append(rows, []string{status, file.Path, file.Component})
+	}
+
+	if len(rows) > 0 {
+		t := cli.CreateStyledTable().
+			Headers("STATUS", "FILE", "COMPONENT").
+			Rows(rows...)
+		fmt.Println(t.String())
+	}
+
+	output.PrintInfo(fmt.Sprintf("Summary: %d to create, %d to overwrite, %d to remove, %d unchanged",
+		plan.Count(assets.PlanCreate), plan.Count(assets.PlanOverwrite), plan.Count(assets.PlanRemove), plan.Count(assets.PlanUnchanged)))
+
+	localChanges := 0
+	for _, file := range plan.Files {
+		if file.LocalChanges {
+			localChanges++
+		}
+	}
+	if localChanges > 0 {
+		output.PrintWarning(fmt.Sprintf("%d files with local changes would be overwritten or removed, consider 'krci-ai upgrade' to merge them", localChanges))
+	}
+
+	output.PrintInfo("Run without --dry-run to install")
+}
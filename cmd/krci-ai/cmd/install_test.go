@@ -16,11 +16,19 @@ limitations under the License.
 package cmd
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/KubeRocketCI/kuberocketai/internal/assets"
+	"github.com/KubeRocketCI/kuberocketai/internal/cli"
+	"github.com/KubeRocketCI/kuberocketai/internal/pack"
+	"github.com/KubeRocketCI/kuberocketai/internal/resolver"
+	"github.com/KubeRocketCI/kuberocketai/internal/source"
 )
 
 // TestInstallCommandExists verifies that the install command is properly defined
@@ -101,6 +109,36 @@ func TestIDEValidation(t *testing.T) {
 			constant: ideWindsurf,
 			expected: "windsurf",
 		},
+		{
+			name:     "GitHub Copilot",
+			constant: ideCopilot,
+			expected: "copilot",
+		},
+		{
+			name:     "Gemini CLI",
+			constant: ideGemini,
+			expected: "gemini",
+		},
+		{
+			name:     "Cline",
+			constant: ideCline,
+			expected: "cline",
+		},
+		{
+			name:     "Roo Code",
+			constant: ideRoo,
+			expected: "roo",
+		},
+		{
+			name:     "Kiro",
+			constant: ideKiro,
+			expected: "kiro",
+		},
+		{
+			name:     "AGENTS.md",
+			constant: ideAgentsMD,
+			expected: "agentsmd",
+		},
 		{
 			name:     "All IDEs",
 			constant: ideAll,
@@ -115,8 +153,8 @@ func TestIDEValidation(t *testing.T) {
 	}
 
 	// Test that all IDEs are included in valid set
-	validIDEs := []string{ideCursor, ideClaude, ideVSCode, ideWindsurf, ideAll}
-	expectedIDEs := []string{"cursor", "claude", "vscode", "windsurf", "all"}
+	validIDEs := []string{ideCursor, ideClaude, ideVSCode, ideWindsurf, ideCopilot, ideGemini, ideCline, ideRoo, ideKiro, ideAgentsMD, ideAll}
+	expectedIDEs := []string{"cursor", "claude", "vscode", "windsurf", "copilot", "gemini", "cline", "roo", "kiro", "agentsmd", "all"}
 
 	assert.ElementsMatch(t, expectedIDEs, validIDEs, "Valid IDEs should contain all expected values")
 }
@@ -193,6 +231,21 @@ func TestValidateIDEFlag(t *testing.T) {
 			ideFlag:     ideWindsurf,
 			expectError: false,
 		},
+		{
+			name:        "valid copilot IDE",
+			ideFlag:     ideCopilot,
+			expectError: false,
+		},
+		{
+			name:        "valid gemini IDE",
+			ideFlag:     ideGemini,
+			expectError: false,
+		},
+		{
+			name:        "valid roo IDE",
+			ideFlag:     ideRoo,
+			expectError: false,
+		},
 		{
 			name:        "valid all IDEs",
 			ideFlag:     ideAll,
@@ -330,3 +383,154 @@ func TestInstallSelectiveFlags(t *testing.T) {
 		})
 	}
 }
+
+// TestParseTaskSelectors tests parsing of --task selectors
+func TestParseTaskSelectors(t *testing.T) {
+	tests := []struct {
+		name        string
+		taskFlag    string
+		expected    map[string][]string
+		expectError bool
+	}{
+		{
+			name:     "comma-separated",
+			taskFlag: "pm/create-prd,dev/implement-feature",
+			expected: map[string][]string{"pm": {"create-prd"}, "dev": {"implement-feature"}},
+		},
+		{
+			name:     "space-separated with duplicates and extension",
+			taskFlag: "pm/create-prd pm/update-prd pm/create-prd.md",
+			expected: map[string][]string{"pm": {"create-prd", "update-prd"}},
+		},
+		{
+			name:        "missing agent",
+			taskFlag:    "create-prd",
+			expectError: true,
+		},
+		{
+			name:        "empty task",
+			taskFlag:    "pm/",
+			expectError: true,
+		},
+		{
+			name:        "nested task path",
+			taskFlag:    "pm/pm/create-prd",
+			expectError: true,
+		},
+		{
+			name:        "empty",
+			taskFlag:    " , ",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasks, err := parseTaskSelectors(tt.taskFlag)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, tasks)
+		})
+	}
+}
+
+// TestFetchPack verifies that packs from registries are only installed with a matching checksum
+func TestFetchPack(t *testing.T) {
+	packDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(packDir, "agents"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(packDir, "agents", "pm.yaml"), []byte("agent: {}\n"), 0644))
+	digest, err := source.Digest(packDir)
+	require.NoError(t, err)
+
+	archive, archiveSHA, err := pack.WriteFile(t.TempDir(), &pack.Manifest{Name: "golden", Version: "1.0.0", Agents: []string{"pm"}},
+		map[string][]byte{"agents/pm.yaml": []byte("agent: {}\n")})
+	require.NoError(t, err)
+
+	const registryURL = "https://packs.example.com"
+	tests := []struct {
+		name      string
+		candidate resolver.Candidate
+		insecure  bool
+		want      string
+		wantErr   string
+	}{
+		{name: "registry directory with digest", candidate: resolver.Candidate{Location: packDir, SHA256: digest, Origin: registryURL}, want: digest},
+		{name: "registry archive with checksum", candidate: resolver.Candidate{Location: archive, SHA256: archiveSHA, Origin: registryURL}, want: archiveSHA},
+		{name: "registry directory with wrong digest", candidate: resolver.Candidate{Location: packDir, SHA256: archiveSHA, Origin: registryURL}, wantErr: "checksum mismatch"},
+		{name: "registry archive with wrong checksum", candidate: resolver.Candidate{Location: archive, SHA256: digest, Origin: registryURL}, wantErr: "checksum mismatch"},
+		{name: "registry pack without checksum", candidate: resolver.Candidate{Location: packDir, Origin: registryURL}, wantErr: "--insecure"},
+		{name: "registry pack without checksum, insecure", candidate: resolver.Candidate{Location: packDir, Origin: registryURL}, insecure: true},
+		{name: "local pack without checksum", candidate: resolver.Candidate{Location: archive, Origin: resolver.OriginLocal}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.candidate.Name, tt.candidate.Version = "golden", "1.0.0"
+
+			fetched, checksum, err := fetchPack(tt.candidate, tt.insecure)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			defer fetched.Cleanup()
+			assert.Equal(t, tt.want, checksum)
+		})
+	}
+}
+
+func TestInstallPacks(t *testing.T) {
+	packs := make([]resolver.Candidate, 0, 2)
+	for _, name := range []string{"alpha", "beta"} {
+		packDir := t.TempDir()
+		writeFiles(t, packDir, map[string]string{
+			"agents/" + name + ".yaml":     testAgent(name),
+			"tasks/review-" + name + ".md": "# Review " + name + "\n",
+		})
+		packs = append(packs, resolver.Candidate{Name: name, Version: "1.0.0", Location: packDir, Origin: resolver.OriginLocal})
+	}
+	output := cli.NewQuietOutputHandler()
+
+	t.Run("all packs", func(t *testing.T) {
+		projectDir := t.TempDir()
+		installation := assets.NewInstaller(projectDir, GetEmbeddedAssets(), assets.NewEmbeddedDiscovery(GetEmbeddedAssets(), assets.EmbeddedPrefix))
+		require.NoError(t, installation.Begin())
+		require.NoError(t, installPacks(installation, projectDir, packs, "claude", false, output))
+		// Nothing is written before the installation is committed
+		assert.NoDirExists(t, filepath.Join(projectDir, ".krci-ai"))
+		require.NoError(t, installation.Commit())
+
+		for _, name := range []string{"alpha", "beta"} {
+			assert.FileExists(t, filepath.Join(projectDir, ".krci-ai", "agents", name+".yaml"))
+			assert.FileExists(t, filepath.Join(projectDir, ".claude", "commands", "krci-ai", name+".md"))
+		}
+		lock := loadProjectLockfile(t, projectDir)
+		assert.Equal(t, []string{"alpha", "beta"}, lock.Agents)
+		assert.Equal(t, []string{"claude"}, lock.IDEs)
+		require.Len(t, lock.Packs, 2)
+		assert.Equal(t, "alpha", lock.Packs[0].Name)
+		assert.Equal(t, "beta", lock.Packs[1].Name)
+	})
+
+	t.Run("failing pack", func(t *testing.T) {
+		projectDir := t.TempDir()
+		failing := packs[1]
+		failing.Location = filepath.Join(t.TempDir(), "missing")
+
+		installation := assets.NewInstaller(projectDir, GetEmbeddedAssets(), assets.NewEmbeddedDiscovery(GetEmbeddedAssets(), assets.EmbeddedPrefix))
+		require.NoError(t, installation.Begin())
+		require.Error(t, installPacks(installation, projectDir, []resolver.Candidate{packs[0], failing}, "claude", false, output))
+		rolledBack, err := installation.Rollback()
+		require.NoError(t, err)
+		assert.True(t, rolledBack)
+
+		// The packs installed before the failing one are rolled back as well
+		entries, err := os.ReadDir(projectDir)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+}
@@ -18,6 +18,7 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -39,13 +40,15 @@ components are available in your local framework installation.
 
 Available subcommands:
   agents     List all installed agents with their roles and descriptions
+  ides       List the built-in and custom IDE integrations
   tasks      List all installed tasks (coming soon)
   templates  List all installed templates (coming soon)
   data       List all installed data components (coming soon)
 
 Examples:
   krci-ai list agents          # List all available agents
-  krci-ai list agents -v       # List agents with dependency table showing tasks, templates, and data`,
+  krci-ai list agents -v       # List agents with dependency table showing tasks, templates, and data
+  krci-ai list ides            # List IDE integrations available to --ide`,
 }
 
 // listAgentsCmd represents the list agents command
@@ -181,9 +184,95 @@ func formatAgentDependencyTable(agents []assets.Agent) string {
 	return t.String()
 }
 
+// listIDEsCmd represents the list ides command
+var listIDEsCmd = &cobra.Command{
+	Use:   "ides",
+	Short: "List the available IDE integrations",
+	Long: `List the IDE integrations accepted by 'krci-ai install --ide'.
+
+Built-in IDE integrations ship with krci-ai. Custom ones are described in
+.krci-ai/ide/<name>.yaml of the project:
+
+  name: my-tool                      # value of --ide
+  display_name: My Tool              # shown in messages, optional
+  description: My Tool agent prompts # shown by this command, optional
+  directory: .my-tool/agents         # relative to the project root
+  file_name: "{{.Agent}}"            # file name pattern without extension, optional
+  extension: .md
+  template: |
+    # {{.Role}}
+    {{.Definition}}
+  task_template: |                   # file per agent task with --task-commands, optional
+    Activate {{.AgentPath}} and execute {{.TaskPath}}
+
+Templates get .Agent, .Name, .Role, .Description, .Goal, .Icon, .AgentPath,
+.Definition, the agent YAML with project values substituted, and .Dependencies,
+the files inlined with 'krci-ai install --inline'. Task templates also
+get .Task, .TaskTitle and .TaskPath, task files are named {{.Agent}}-{{.Task}} unless
+task_file_name is set.
+
+Examples:
+  krci-ai list ides            # List built-in and custom IDE integrations`,
+	Run: func(cmd *cobra.Command, args []string) {
+		errorHandler := cli.NewErrorHandler()
+
+		registry, err := projectIDERegistry()
+		if err != nil {
+			errorHandler.HandleError(err, "Failed to load IDE integrations")
+			return
+		}
+
+		installed := make(map[string]bool)
+		projectRoot, err := discovery.GetProjectRoot()
+		if err == nil {
+			installer := assets.NewInstaller(
+				projectRoot,
+				GetEmbeddedAssets(),
+				assets.NewEmbeddedDiscovery(GetEmbeddedAssets(), assets.EmbeddedPrefix),
+			)
+			for _, ide := range installer.InstalledIDEs() {
+				installed[ide] = true
+			}
+		}
+
+		fmt.Print(formatIDESummary(registry.Descriptors(), installed, projectRoot))
+		fmt.Println()
+	},
+}
+
+// formatIDESummary formats IDE integrations for table display with their source relative to the
+// project root and installation state
+func formatIDESummary(descriptors []assets.IDEDescriptor, installed map[string]bool, projectRoot string) string {
+	rows := make([][]string, 0, len(descriptors))
+
+	for _, descriptor := range descriptors {
+		source := "built-in"
+		if !descriptor.Builtin() {
+			source = descriptor.Source
+			if rel, err := filepath.Rel(projectRoot, source); err == nil && projectRoot != "" {
+				source = filepath.ToSlash(rel)
+			}
+		}
+
+		state := "no"
+		if installed[descriptor.Name] {
+			state = "yes"
+		}
+
+		rows = append(rows, []string{descriptor.Name, cli.TruncateDescription(descriptor.Summary()), source, state})
+	}
+
+	t := cli.CreateStyledTable().
+		Headers("NAME", "DESCRIPTION", "SOURCE", "INSTALLED").
+		Rows(rows...)
+
+	return t.String()
+}
+
 func init() {
 	rootCmd.AddCommand(listCmd)
 	listCmd.AddCommand(listAgentsCmd)
+	listCmd.AddCommand(listIDEsCmd)
 
 	// Add verbose flag to list agents command
 	listAgentsCmd.Flags().BoolP("verbose", "v", false, "Show detailed agent information")
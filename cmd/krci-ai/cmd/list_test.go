@@ -21,6 +21,8 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/KubeRocketCI/kuberocketai/internal/assets"
 )
 
 // TestListCommandExists verifies that the list command is properly defined
@@ -140,3 +142,27 @@ func TestListAgentsCommandHelp(t *testing.T) {
 		assert.Contains(t, helpText, section, "Help text should contain section: %s", section)
 	}
 }
+
+// TestListIDEsCommandExists verifies that the list ides command is properly defined
+func TestListIDEsCommandExists(t *testing.T) {
+	require.NotNil(t, listIDEsCmd, "listIDEsCmd should not be nil")
+
+	assert.Equal(t, "ides", listIDEsCmd.Use, "Command name should be 'ides'")
+	assert.Contains(t, listCmd.Commands(), listIDEsCmd, "List command should have 'ides' subcommand")
+	assert.Contains(t, listIDEsCmd.Long, ".krci-ai/ide/<name>.yaml", "Help text should describe custom descriptors")
+}
+
+// TestFormatIDESummary tests the table of IDE integrations
+func TestFormatIDESummary(t *testing.T) {
+	descriptors := []assets.IDEDescriptor{
+		{Name: "cursor", Description: "Cursor rules", Directory: ".cursor/rules/krci-ai", Extension: ".mdc"},
+		{Name: "acme", Directory: ".acme/agents", Extension: ".md", Source: "/project/.krci-ai/ide/acme.yaml"},
+	}
+
+	table := formatIDESummary(descriptors, map[string]bool{"acme": true}, "/project")
+
+	for _, expected := range []string{"NAME", "SOURCE", "INSTALLED", "cursor", "Cursor rules", "built-in", "acme", "acme.yaml", "yes", "no"} {
+		assert.Contains(t, table, expected)
+	}
+	assert.NotContains(t, table, "/project/")
+}
@@ -0,0 +1,189 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/KubeRocketCI/kuberocketai/internal/assets"
+	"github.com/KubeRocketCI/kuberocketai/internal/cli"
+	"github.com/KubeRocketCI/kuberocketai/internal/discovery"
+	"github.com/KubeRocketCI/kuberocketai/internal/pack"
+	"github.com/KubeRocketCI/kuberocketai/internal/resolver"
+	"github.com/KubeRocketCI/kuberocketai/internal/version"
+)
+
+// defaultPackOutputDir is where packs are written relative to the project root
+const defaultPackOutputDir = ".krci-ai/packs"
+
+// packCmd represents the pack command
+var packCmd = &cobra.Command{
+	Use:   "pack",
+	Short: "Build a versioned, distributable agent pack",
+	Long: `Build a versioned agent pack from the agents installed in .krci-ai.
+
+The pack is a deterministic .tar.gz archive containing pack.yaml (name, version,
+description, minimum CLI version, agents and pack dependencies) and every agent with its full
+dependency closure (tasks, templates and data) laid out as under .krci-ai.
+A sha256sum compatible checksum file is written next to the archive.
+
+Packs can be installed in other projects with 'krci-ai install --from <archive-url>'.
+
+Examples:
+  krci-ai pack --agent pm,architect --version 1.2.0
+  krci-ai pack --agent dev --version 0.1.0 --name golden-dev --description "Golden developer agent"
+  krci-ai pack --agent pm --version 1.0.0 --output dist
+  krci-ai pack --agent qa --version 0.3.0 --dependency shared-standards@^1.2`,
+	RunE: runPack,
+}
+
+func init() {
+	rootCmd.AddCommand(packCmd)
+
+	packCmd.Flags().String("agent", "", "Agents to include (comma or space separated: 'pm,architect' or 'pm architect')")
+	packCmd.Flags().String("version", "", "Pack version (semantic version, e.g. 1.2.0)")
+	packCmd.Flags().String("name", "", "Pack name (defaults to the project directory name)")
+	packCmd.Flags().String("description", "", "Pack description")
+	packCmd.Flags().String("min-cli-version", "", "Minimum krci-ai version required to install the pack (defaults to the current version)")
+	packCmd.Flags().String("output", defaultPackOutputDir, "Output directory for the archive and checksum file")
+	packCmd.Flags().StringArray("dependency", nil, "Pack dependency with a version constraint: name@constraint (repeatable)")
+
+	_ = packCmd.MarkFlagRequired("agent")
+	_ = packCmd.MarkFlagRequired("version")
+}
+
+func runPack(cmd *cobra.Command, args []string) error {
+	output := cli.NewOutputHandler()
+
+	agentFlag, _ := cmd.Flags().GetString("agent")
+	packVersion, _ := cmd.Flags().GetString("version")
+	name, _ := cmd.Flags().GetString("name")
+	description, _ := cmd.Flags().GetString("description")
+	minCLIVersion, _ := cmd.Flags().GetString("min-cli-version")
+	outputDir, _ := cmd.Flags().GetString("output")
+	dependencySpecs, _ := cmd.Flags().GetStringArray("dependency")
+
+	agentNames := ParseAgentList(agentFlag)
+	if len(agentNames) == 0 {
+		return fmt.Errorf("no valid agent names provided")
+	}
+
+	projectRoot, err := discovery.GetProjectRoot()
+	if err != nil {
+		return err
+	}
+
+	krciPath := assets.GetKrciPath(projectRoot)
+	if name == "" {
+		name = strings.ToLower(filepath.Base(projectRoot))
+	}
+	if minCLIVersion == "" {
+		minCLIVersion = defaultMinCLIVersion()
+	}
+
+	dependencies, err := parsePackDependencies(dependencySpecs)
+	if err != nil {
+		return err
+	}
+
+	manifest := &pack.Manifest{
+		Name:          name,
+		Version:       strings.TrimPrefix(packVersion, "v"),
+		Description:   description,
+		MinCLIVersion: minCLIVersion,
+		Agents:        agentNames,
+		Dependencies:  dependencies,
+	}
+	if err := manifest.Validate(); err != nil {
+		return err
+	}
+
+	output.PrintProgress(fmt.Sprintf("Packing agents %v from %s...", agentNames, krciPath))
+
+	files, err := collectPackFiles(projectRoot, krciPath, agentNames)
+	if err != nil {
+		return err
+	}
+
+	archivePath, checksum, err := pack.WriteFile(resolveProjectPath(projectRoot, outputDir), manifest, files)
+	if err != nil {
+		return err
+	}
+
+	output.PrintSuccess(fmt.Sprintf("Pack %s %s created with %d files", manifest.Name, manifest.Version, len(files)))
+	output.PrintInfo("Archive: " + archivePath)
+	output.PrintInfo("Checksum: " + archivePath + pack.ChecksumExtension)
+	output.PrintInfo("SHA-256: " + checksum)
+
+	return nil
+}
+
+// collectPackFiles reads the agents and their dependency closure from the installed framework
+func collectPackFiles(projectRoot, krciPath string, agentNames []string) (map[string][]byte, error) {
+	installer := assets.NewInstallerFromSource(projectRoot, assets.OSFileSystem{}, krciPath, assets.NewDiscovery(krciPath))
+
+	paths, err := installer.ResolveAgentFiles(agentNames)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string][]byte, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(filepath.Join(krciPath, filepath.FromSlash(path)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		files[path] = data
+	}
+
+	return files, nil
+}
+
+// parsePackDependencies converts name@constraint flags into manifest dependencies
+func parsePackDependencies(specs []string) (map[string]string, error) {
+	if len(specs) == 0 {
+		return nil, nil
+	}
+
+	dependencies := make(map[string]string, len(specs))
+	for _, spec := range specs {
+		req, err := resolver.ParseRequirement(spec)
+		if err != nil {
+			return nil, err
+		}
+		if req.Constraint == "" {
+			return nil, fmt.Errorf("dependency %s must declare a version constraint, e.g. %s@^1.0", req.Name, req.Name)
+		}
+		dependencies[req.Name] = req.Constraint
+	}
+
+	return dependencies, nil
+}
+
+// defaultMinCLIVersion returns the running CLI version when it is a release version
+func defaultMinCLIVersion() string {
+	current := version.GetCurrentVersion()
+	if _, err := version.ParseVersion(current); err != nil {
+		return ""
+	}
+
+	return strings.TrimPrefix(current, "v")
+}
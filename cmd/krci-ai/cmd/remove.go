@@ -0,0 +1,136 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/KubeRocketCI/kuberocketai/internal/assets"
+	"github.com/KubeRocketCI/kuberocketai/internal/cli"
+	"github.com/KubeRocketCI/kuberocketai/internal/discovery"
+	"github.com/KubeRocketCI/kuberocketai/internal/uninstall"
+)
+
+// removeCmd represents the remove command
+var removeCmd = &cobra.Command{
+	Use:   "remove",
+	Short: "Remove components from an existing installation",
+	Long: `Remove components from a framework installation made with 'krci-ai install'.
+
+Examples:
+  krci-ai remove agent dev         # Remove the dev agent and its unshared files`,
+}
+
+// removeAgentCmd represents the remove agent command
+var removeAgentCmd = &cobra.Command{
+	Use:   "agent <name>...",
+	Short: "Remove agents from an existing installation",
+	Long: `Remove agents with their IDE integration files and the tasks, templates and data
+no other installed agent needs. Files shared with other agents are kept and
+recorded for the remaining agents only.
+
+Only files recorded in .krci-ai/krci-ai.lock are removed. Files with local changes
+are backed up to .krci-ai/.backup/ before removal, or kept with --keep-local.
+
+Examples:
+  krci-ai remove agent dev              # Remove the dev agent
+  krci-ai remove agent pm architect     # Remove several agents
+  krci-ai remove agent qa --dry-run     # Show which files would be removed`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runRemoveAgent,
+}
+
+func init() {
+	rootCmd.AddCommand(removeCmd)
+	removeCmd.AddCommand(removeAgentCmd)
+
+	removeAgentCmd.Flags().Bool("keep-local", false, "Keep files with local changes instead of backing them up and removing them")
+	removeAgentCmd.Flags().Bool("dry-run", false, "Show which files would be removed without removing them")
+}
+
+func runRemoveAgent(cmd *cobra.Command, args []string) error {
+	keepLocal, _ := cmd.Flags().GetBool("keep-local")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	agentNames := ParseAgentList(strings.Join(args, " "))
+	if len(agentNames) == 0 {
+		return fmt.Errorf("no valid agent names provided")
+	}
+
+	// Failures past argument parsing are not usage errors
+	cmd.SilenceUsage = true
+
+	projectRoot, err := discovery.GetProjectRoot()
+	if err != nil {
+		return err
+	}
+
+	lock, err := loadInstalledLockfile(projectRoot)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range agentNames {
+		if !slices.Contains(lock.Agents, name) {
+			return fmt.Errorf("agent %s is not installed (installed: %s)", name, strings.Join(lock.Agents, ", "))
+		}
+	}
+
+	result, err := uninstall.Apply(uninstall.Options{
+		ProjectDir:   projectRoot,
+		FrameworkDir: assets.GetKrciPath(projectRoot),
+		Lock:         lock,
+		Agents:       agentNames,
+		KeepLocal:    keepLocal,
+		DryRun:       dryRun,
+	})
+	if err != nil {
+		return err
+	}
+
+	if !dryRun && !result.Complete {
+		if err := refreshIDESections(projectRoot, lock.IDEs); err != nil {
+			return err
+		}
+	}
+
+	displayUninstallResult(result, dryRun, cli.NewOutputHandler())
+
+	return nil
+}
+
+// refreshIDESections updates the agents listed by IDE integrations in managed sections and aggregate files
+func refreshIDESections(projectRoot string, ides []string) error {
+	installer := assets.NewInstaller(
+		projectRoot,
+		GetEmbeddedAssets(),
+		assets.NewEmbeddedDiscovery(GetEmbeddedAssets(), assets.EmbeddedPrefix),
+	)
+	defer beginInstallation(installer, cli.NewErrorHandler())()
+
+	if err := installer.SyncIDESections(ides); err != nil {
+		return err
+	}
+	if err := installer.UpdateLockfile(nil); err != nil {
+		return err
+	}
+
+	return installer.Commit()
+}
@@ -0,0 +1,87 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoveAgent(t *testing.T) {
+	_, projectDir := prepareAgentProject(t, "alpha", "beta")
+
+	require.NoError(t, runRemoveAgent(removeAgentCmd, []string{"alpha"}))
+
+	// Files of the removed agent only are removed, shared files are kept
+	assert.NoFileExists(t, filepath.Join(projectDir, ".krci-ai", "agents", "alpha.yaml"))
+	assert.NoFileExists(t, filepath.Join(projectDir, ".krci-ai", "tasks", "review-alpha.md"))
+	assert.NoFileExists(t, filepath.Join(projectDir, ".claude", "commands", "krci-ai", "alpha.md"))
+	assert.FileExists(t, filepath.Join(projectDir, ".krci-ai", "templates", "review.md"))
+	assert.FileExists(t, filepath.Join(projectDir, ".krci-ai", "agents", "beta.yaml"))
+	assert.FileExists(t, filepath.Join(projectDir, ".claude", "commands", "krci-ai", "beta.md"))
+
+	// The IDE section lists the remaining agents
+	agentsMD, err := os.ReadFile(filepath.Join(projectDir, "AGENTS.md"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(agentsMD), "(`alpha`)")
+	assert.Contains(t, string(agentsMD), "(`beta`)")
+
+	lock := loadProjectLockfile(t, projectDir)
+	assert.Equal(t, []string{"beta"}, lock.Agents)
+	_, ok := lock.GetFile(".krci-ai/agents/alpha.yaml")
+	assert.False(t, ok)
+
+	template, ok := lock.GetFile(".krci-ai/templates/review.md")
+	require.True(t, ok)
+	assert.Equal(t, []string{"beta"}, template.Agents)
+
+	section, ok := lock.GetFile("AGENTS.md")
+	require.True(t, ok)
+	assert.Equal(t, []string{"beta"}, section.Agents)
+
+	checks, err := lock.Modified(projectDir)
+	require.NoError(t, err)
+	assert.Empty(t, checks)
+
+	t.Run("not installed", func(t *testing.T) {
+		err := runRemoveAgent(removeAgentCmd, []string{"alpha"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "agent alpha is not installed")
+	})
+
+	t.Run("last agent", func(t *testing.T) {
+		require.NoError(t, runRemoveAgent(removeAgentCmd, []string{"beta"}))
+		assert.NoFileExists(t, filepath.Join(projectDir, ".krci-ai", "templates", "review.md"))
+		assert.NoFileExists(t, filepath.Join(projectDir, ".claude", "commands", "krci-ai", "beta.md"))
+		assert.NoFileExists(t, filepath.Join(projectDir, "AGENTS.md"))
+	})
+}
+
+func TestRemoveAgentDryRun(t *testing.T) {
+	_, projectDir := prepareAgentProject(t, "alpha", "beta")
+	setFlags(t, removeAgentCmd, map[string]string{"dry-run": "true"})
+	before := loadProjectLockfile(t, projectDir)
+
+	require.NoError(t, runRemoveAgent(removeAgentCmd, []string{"alpha"}))
+
+	assert.FileExists(t, filepath.Join(projectDir, ".krci-ai", "agents", "alpha.yaml"))
+	assert.FileExists(t, filepath.Join(projectDir, ".claude", "commands", "krci-ai", "alpha.md"))
+	assert.Equal(t, before, loadProjectLockfile(t, projectDir))
+}
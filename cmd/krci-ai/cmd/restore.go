@@ -0,0 +1,104 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/KubeRocketCI/kuberocketai/internal/assets"
+	"github.com/KubeRocketCI/kuberocketai/internal/backup"
+	"github.com/KubeRocketCI/kuberocketai/internal/cli"
+	"github.com/KubeRocketCI/kuberocketai/internal/discovery"
+)
+
+// restoreCmd represents the restore command
+var restoreCmd = &cobra.Command{
+	Use:   "restore [timestamp]",
+	Short: "Restore locally modified files from a backup",
+	Long: `Restore files that were modified locally and then overwritten by
+'krci-ai install' or 'krci-ai unbundle'.
+
+Before overwriting a file that differs from the version recorded in
+.krci-ai/krci-ai.lock, the previous content is saved to
+.krci-ai/.backup/<timestamp>/. Without arguments the available backups are listed.
+
+Examples:
+  krci-ai restore                    # List available backups
+  krci-ai restore 20250304-050607    # Restore the files of a backup`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runRestore,
+}
+
+func init() {
+	rootCmd.AddCommand(restoreCmd)
+}
+
+func runRestore(cmd *cobra.Command, args []string) error {
+	output := cli.NewOutputHandler()
+
+	projectRoot, err := discovery.GetProjectRoot()
+	if err != nil {
+		return err
+	}
+	frameworkDir := assets.GetKrciPath(projectRoot)
+
+	if len(args) == 0 {
+		return listBackups(frameworkDir, output)
+	}
+
+	files, err := backup.Restore(projectRoot, frameworkDir, args[0])
+	if err != nil {
+		return err
+	}
+
+	output.PrintSuccess(fmt.Sprintf("Restored %d files from backup %s", len(files), args[0]))
+	for _, file := range files {
+		output.Printf("  • %s\n", file)
+	}
+	output.PrintInfo("Run 'krci-ai install --sync-ide' if restored agents should be reflected in IDE integration files")
+
+	return nil
+}
+
+// listBackups prints the available backups, newest first
+func listBackups(frameworkDir string, output *cli.OutputHandler) error {
+	backups, err := backup.List(frameworkDir)
+	if err != nil {
+		return err
+	}
+
+	if len(backups) == 0 {
+		output.PrintInfo("No backups found")
+		return nil
+	}
+
+	rows := make([][]string, 0, len(backups))
+	for _, b := range backups {
+		rows = append(rows, []string{b.ID, b.CreatedAt.Local().Format(time.DateTime), b.Reason, fmt.Sprintf("%d", len(b.Files))})
+	}
+
+	t := cli.CreateStyledTable().
+		Headers("TIMESTAMP", "CREATED", "COMMAND", "FILES").
+		Rows(rows...)
+	fmt.Println(t.String())
+
+	output.PrintInfo("Run 'krci-ai restore <timestamp>' to restore a backup")
+
+	return nil
+}
@@ -77,11 +77,8 @@ func GetEmbeddedAssets() embed.FS {
 }
 
 func init() {
-	// Here you will define your flags and configuration settings.
-	// Cobra supports persistent flags, which, if defined here,
-	// will be global for your application.
-
-	// rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.krci-ai.yaml)")
+	// Persistent flags are global for the application
+	rootCmd.PersistentFlags().String("config", "", "config file (default is $HOME/.krci-ai.yaml)")
 
 	// Add version command
 	rootCmd.AddCommand(versionCmd)
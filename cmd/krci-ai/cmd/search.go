@@ -0,0 +1,189 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/KubeRocketCI/kuberocketai/internal/cli"
+	"github.com/KubeRocketCI/kuberocketai/internal/config"
+	"github.com/KubeRocketCI/kuberocketai/internal/registry"
+)
+
+// searchCmd represents the search command
+var searchCmd = &cobra.Command{
+	Use:   "search [query]",
+	Short: "Search pack registries for agent packs",
+	Long: `Search the configured pack registries for agent packs.
+
+The query is matched against pack names, descriptions and agent names.
+Without a query all packs are listed. Registry indexes are cached and
+refreshed after registry.cache_ttl; use --offline to search the cache only.
+
+Registries are configured with:
+  krci-ai config set registry.urls https://packs.example.com,file:///srv/packs
+
+Examples:
+  krci-ai search                      # List all packs
+  krci-ai search architect            # Find packs containing an architect agent
+  krci-ai search golden --offline     # Search cached indexes without network access
+  krci-ai search pm --json            # Machine readable output`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runSearch,
+}
+
+// searchResult is a pack found in a registry
+type searchResult struct {
+	Registry      string   `json:"registry"`
+	Name          string   `json:"name"`
+	LatestVersion string   `json:"latest_version"`
+	Description   string   `json:"description,omitempty"`
+	Agents        []string `json:"agents"`
+}
+
+func init() {
+	rootCmd.AddCommand(searchCmd)
+	addRegistryFlags(searchCmd)
+	searchCmd.Flags().Bool("json", false, "Output results in JSON format")
+}
+
+// addRegistryFlags adds the flags shared by registry commands
+func addRegistryFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSlice("registry", nil, "Registry URL to use instead of the configured registries (repeatable)")
+	cmd.Flags().Bool("offline", false, "Use cached registry indexes only")
+	cmd.Flags().Bool("refresh", false, "Refresh registry indexes even if the cache is fresh")
+}
+
+// loadRegistries loads the indexes of all configured registries.
+// Registries that fail to load are reported as warnings as long as at least one index is available.
+func loadRegistries(cmd *cobra.Command, output *cli.OutputHandler, quiet bool) ([]*registry.Result, error) {
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	urls := cfg.List(config.KeyRegistryURLs)
+	if flagURLs, _ := cmd.Flags().GetStringSlice("registry"); len(flagURLs) > 0 {
+		urls = flagURLs
+	}
+	if len(urls) == 0 {
+		return nil, fmt.Errorf("no pack registries configured, use 'krci-ai config set %s <url>' or --registry", config.KeyRegistryURLs)
+	}
+
+	offline, _ := cmd.Flags().GetBool("offline")
+	refresh, _ := cmd.Flags().GetBool("refresh")
+
+	cacheDir, err := registry.DefaultCacheDir()
+	if err != nil {
+		return nil, err
+	}
+
+	opts := registry.Options{
+		Cache:   &registry.Cache{Dir: cacheDir},
+		TTL:     cfg.Duration(config.KeyRegistryTTL),
+		Offline: offline,
+		Refresh: refresh,
+	}
+
+	var (
+		results []*registry.Result
+		errs    []string
+	)
+	for _, url := range urls {
+		result, err := registry.Load(context.Background(), registry.NewClient(url), opts)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		if result.Warning != "" && !quiet {
+			output.PrintWarning(result.Warning)
+		}
+		results = append(results, result)
+	}
+
+	if len(results) == 0 {
+		return nil, fmt.Errorf("failed to load pack registries: %s", strings.Join(errs, "; "))
+	}
+	if !quiet {
+		for _, msg := range errs {
+			output.PrintWarning(msg)
+		}
+	}
+
+	return results, nil
+}
+
+func runSearch(cmd *cobra.Command, args []string) error {
+	output := cli.NewOutputHandler()
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+
+	query := ""
+	if len(args) > 0 {
+		query = args[0]
+	}
+
+	results, err := loadRegistries(cmd, output, jsonOutput)
+	if err != nil {
+		return err
+	}
+
+	found := make([]searchResult, 0)
+	for _, result := range results {
+		for _, pack := range result.Index.Search(query) {
+			item := searchResult{Registry: result.Client.BaseURL, Name: pack.Name, Description: pack.Description}
+			if latest, ok := pack.Latest(); ok {
+				item.LatestVersion = latest.Version
+				item.Agents = latest.Agents
+				if item.Description == "" {
+					item.Description = latest.Description
+				}
+			}
+			found = append(found, item)
+		}
+	}
+
+	if jsonOutput {
+		data, err := json.MarshalIndent(found, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON output: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(found) == 0 {
+		output.PrintInfo(fmt.Sprintf("No packs found matching '%s'", query))
+		return nil
+	}
+
+	rows := make([][]string, 0, len(found))
+	for _, item := range found {
+		rows = append(rows, []string{item.Name, item.LatestVersion, strings.Join(item.Agents, ", "), item.Description})
+	}
+
+	t := cli.CreateStyledTable().
+		Headers("PACK", "LATEST", "AGENTS", "DESCRIPTION").
+		Rows(rows...)
+	fmt.Println(t.String())
+	output.PrintInfo("Run 'krci-ai info <pack>' for versions and installation details")
+
+	return nil
+}
@@ -26,6 +26,7 @@ import (
 
 	"github.com/KubeRocketCI/kuberocketai/internal/assets"
 	"github.com/KubeRocketCI/kuberocketai/internal/cli"
+	"github.com/KubeRocketCI/kuberocketai/internal/config"
 	"github.com/KubeRocketCI/kuberocketai/internal/discovery"
 	"github.com/KubeRocketCI/kuberocketai/internal/tokens"
 )
@@ -33,6 +34,9 @@ import (
 // output is initialized in init function for CLI formatting
 var output *cli.OutputHandler
 
+// tokenBudget is the configured per-agent token budget, 0 disables the check
+var tokenBudget int
+
 // tokensCmd represents the tokens command
 var tokensCmd = &cobra.Command{
 	Use:   "tokens",
@@ -58,7 +62,10 @@ Examples:
   krci-ai tokens --all --json --verbose
   
   # Set custom timeout for large projects
-  krci-ai tokens --all --timeout 10s`,
+  krci-ai tokens --all --timeout 10s
+
+The token model, budget and timeout defaults can be configured with
+'krci-ai config set tokens.model|tokens.budget|tokens.timeout <value>'.`,
 	RunE: runTokensCommand,
 }
 
@@ -117,11 +124,23 @@ func runTokensCommand(cmd *cobra.Command, args []string) error {
 		return handleTokenError(fmt.Errorf("failed to initialize token calculator: %w", err), tokenJSON)
 	}
 
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		return handleTokenError(err, tokenJSON)
+	}
+
+	if err := cfg.BindFlag(config.KeyTokensTimeout, cmd.Flags(), "timeout"); err != nil {
+		return handleTokenError(err, tokenJSON)
+	}
+	tokenTimeout = cfg.Duration(config.KeyTokensTimeout)
+	tokenBudget = cfg.Int(config.KeyTokensBudget)
+
 	// Create token calculator
-	calculator, err := tokens.NewCalculator(assets.GetKrciPath(projectRoot))
+	calculator, err := tokens.NewCalculatorForModel(assets.GetKrciPath(projectRoot), cfg.String(config.KeyTokensModel))
 	if err != nil {
 		return handleTokenError(fmt.Errorf("failed to initialize token calculator: %w", err), tokenJSON)
 	}
+	calculator.WithBundleDir(resolveProjectPath(projectRoot, cfg.String(config.KeyBundleOutputDir)))
 
 	// Create context with timeout
 	ctx, cancel := context.WithTimeout(context.Background(), tokenTimeout)
@@ -234,6 +253,7 @@ func outputAgentTokensTable(agentInfo *tokens.AgentTokenInfo) error {
 	output.Printf("%s %s\n", output.PrintCyan("Agent:"), agentInfo.AgentName)
 	output.Printf("%s %s\n", output.PrintCyan("File:"), agentInfo.AgentFile)
 	output.Printf("%s %d tokens\n", output.PrintCyan("Total:"), agentInfo.TotalTokens)
+	printTokenBudgetWarning(agentInfo.AgentName, agentInfo.TotalTokens)
 
 	// Agent file tokens
 	if len(agentInfo.Assets) > 0 {
@@ -313,6 +333,9 @@ func outputProjectTokensTable(projectInfo *tokens.ProjectTokenInfo) error {
 		output.Printf("  %s %s: %d tokens\n",
 			output.PrintGreenBold("✓"), agent.AgentName, agent.TotalTokens)
 	}
+	for _, agent := range projectInfo.Agents {
+		printTokenBudgetWarning(agent.AgentName, agent.TotalTokens)
+	}
 
 	// Add disclaimer about token approximation
 	output.Newline()
@@ -322,6 +345,16 @@ func outputProjectTokensTable(projectInfo *tokens.ProjectTokenInfo) error {
 	return nil
 }
 
+// printTokenBudgetWarning warns when an agent exceeds the configured token budget
+func printTokenBudgetWarning(agentName string, totalTokens int) {
+	if tokenBudget <= 0 || totalTokens <= tokenBudget {
+		return
+	}
+
+	output.PrintWarning(fmt.Sprintf("Agent '%s' uses %d tokens, exceeding the configured budget of %d tokens (tokens.budget)",
+		agentName, totalTokens, tokenBudget))
+}
+
 func handleTokenError(err error, jsonOutput bool) error {
 	if jsonOutput {
 		// For JSON output, return the raw error
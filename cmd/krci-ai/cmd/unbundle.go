@@ -21,6 +21,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/KubeRocketCI/kuberocketai/internal/assets"
 	"github.com/KubeRocketCI/kuberocketai/internal/bundle"
 	"github.com/KubeRocketCI/kuberocketai/internal/cli"
 	"github.com/KubeRocketCI/kuberocketai/internal/discovery"
@@ -39,7 +40,9 @@ contained files back into the appropriate directories:
 - Templates to .krci-ai/templates/
 - Data files to .krci-ai/data/
 
-By default, existing files will be overwritten.
+By default, existing files will be overwritten. Files modified locally since they were
+installed are backed up to .krci-ai/.backup/<timestamp>/ first and can be rolled back
+with 'krci-ai restore <timestamp>'.
 
 Examples:
   krci-ai unbundle ./.krci-ai/bundle/all.md                # Extract complete bundle
@@ -101,7 +104,7 @@ func runUnbundle(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create extractor
-	extractor := bundle.NewExtractor(projectRoot)
+	extractor := bundle.NewExtractor(projectRoot, assets.GetKrciPath(projectRoot))
 
 	// Get dry-run flag
 	dryRun, err := cmd.Flags().GetBool("dry-run")
@@ -154,6 +157,9 @@ func extractFiles(output *cli.OutputHandler, errorHandler *cli.ErrorHandler, ext
 	output.PrintInfo(fmt.Sprintf("  New files: %d", stats.FilesExtracted))
 	output.PrintInfo(fmt.Sprintf("  Overwritten files: %d", stats.FilesOverwritten))
 	output.PrintInfo(fmt.Sprintf("  Directories created: %d", stats.DirsCreated))
+	if stats.FilesBackedUp > 0 {
+		output.PrintWarning(fmt.Sprintf("  Modified files backed up: %d (run 'krci-ai restore %s' to roll back)", stats.FilesBackedUp, stats.BackupID))
+	}
 
 	output.Newline()
 	output.PrintInfo("Next steps:")
@@ -0,0 +1,174 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	"github.com/KubeRocketCI/kuberocketai/internal/assets"
+	"github.com/KubeRocketCI/kuberocketai/internal/cli"
+	"github.com/KubeRocketCI/kuberocketai/internal/discovery"
+	"github.com/KubeRocketCI/kuberocketai/internal/lockfile"
+	"github.com/KubeRocketCI/kuberocketai/internal/uninstall"
+)
+
+// uninstallCmd represents the uninstall command
+var uninstallCmd = &cobra.Command{
+	Use:   "uninstall",
+	Short: "Remove installed framework and IDE integration files",
+	Long: `Remove the files installed by krci-ai from the project.
+
+Only files recorded in .krci-ai/krci-ai.lock are removed, so user authored files
+next to generated ones (e.g. your own VS Code chat modes or Windsurf rules) are
+left untouched. Files with local changes are backed up to .krci-ai/.backup/ before
+removal, or kept with --keep-local.
+
+With --agent only the files of the given agents are removed; tasks, templates and
+data still needed by other installed agents are kept. With --ide only the files of
+the given IDE integration are removed.
+
+Examples:
+  krci-ai uninstall                      # Remove everything installed by krci-ai
+  krci-ai uninstall --agent pm,architect # Remove agents and their unshared files
+  krci-ai uninstall --ide cursor         # Remove the Cursor IDE integration only
+  krci-ai uninstall --keep-local         # Keep files with local changes
+  krci-ai uninstall --dry-run            # Show what would be removed`,
+	RunE: runUninstall,
+}
+
+func init() {
+	rootCmd.AddCommand(uninstallCmd)
+
+	uninstallCmd.Flags().String("agent", "", "Remove only the given agents (comma or space separated)")
+	uninstallCmd.Flags().String("ide", "", "Remove only the given IDE integration (cursor, claude, vscode, windsurf, copilot, gemini, cline, roo, kiro, agentsmd, a custom IDE, all)")
+	uninstallCmd.Flags().Bool("keep-local", false, "Keep files with local changes instead of backing them up and removing them")
+	uninstallCmd.Flags().Bool("dry-run", false, "Show which files would be removed without removing them")
+}
+
+func runUninstall(cmd *cobra.Command, args []string) error {
+	output := cli.NewOutputHandler()
+	errorHandler := cli.NewErrorHandler()
+
+	agentFlag, _ := cmd.Flags().GetString("agent")
+	ideFlag, _ := cmd.Flags().GetString("ide")
+	keepLocal, _ := cmd.Flags().GetBool("keep-local")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	agentNames := ParseAgentList(agentFlag)
+	if agentFlag != "" && len(agentNames) == 0 {
+		return fmt.Errorf("no valid agent names provided")
+	}
+
+	projectRoot, err := discovery.GetProjectRoot()
+	if err != nil {
+		return err
+	}
+	frameworkDir := assets.GetKrciPath(projectRoot)
+
+	lock, err := lockfile.Load(frameworkDir)
+	if errors.Is(err, lockfile.ErrNotFound) {
+		return fmt.Errorf("%w: nothing to uninstall, installed files are only tracked since krci-ai records a lockfile", err)
+	}
+	if err != nil {
+		return err
+	}
+
+	// IDEs recorded in the lockfile stay removable when their custom descriptor is gone
+	ides := selectedIDEs(ideFlag)
+	if ideFlag != "" && !slices.Contains(lock.IDEs, ideFlag) {
+		if err := validateIDEFlag(ideFlag, errorHandler); err != nil {
+			return err
+		}
+	}
+	if ideFlag == ideAll {
+		for _, ide := range lock.IDEs {
+			if !slices.Contains(ides, ide) {
+				ides = append(ides, ide)
+			}
+		}
+	}
+
+	result, err := uninstall.Apply(uninstall.Options{
+		ProjectDir:   projectRoot,
+		FrameworkDir: frameworkDir,
+		Lock:         lock,
+		Agents:       agentNames,
+		IDEs:         ides,
+		KeepLocal:    keepLocal,
+		DryRun:       dryRun,
+	})
+	if err != nil {
+		return err
+	}
+
+	if !dryRun && !result.Complete && len(agentNames) > 0 {
+		if err := refreshIDESections(projectRoot, lock.IDEs); err != nil {
+			return err
+		}
+	}
+
+	displayUninstallResult(result, dryRun, output)
+
+	return nil
+}
+
+// displayUninstallResult prints the removed files and a summary
+func displayUninstallResult(result *uninstall.Result, dryRun bool, output *cli.OutputHandler) {
+	if len(result.Changes) == 0 {
+		output.PrintInfo("No installed files match the selection")
+	} else {
+		rows := make([][]string, 0, len(result.Changes))
+		for _, change := range result.Changes {
+			rows = append(rows, []string{string(change.Action), change.Path, change.Reason})
+		}
+
+		t := cli.CreateStyledTable().
+			Headers("ACTION", "FILE", "DETAILS").
+			Rows(rows...)
+		fmt.Println(t.String())
+
+		output.PrintInfo(fmt.Sprintf("Summary: %d removed, %d kept, %d shared, %d missing",
+			result.Count(uninstall.ActionRemoved),
+			result.Count(uninstall.ActionKept),
+			result.Count(uninstall.ActionShared),
+			result.Count(uninstall.ActionMissing),
+		))
+	}
+
+	if result.Untracked > 0 {
+		output.PrintWarning(fmt.Sprintf("%d files were installed by an older krci-ai without agent records and were not removed; run 'krci-ai install --force' to track them", result.Untracked))
+	}
+
+	if dryRun {
+		output.PrintInfo("Dry run: no files were removed")
+		return
+	}
+
+	if result.BackupID != "" {
+		output.PrintWarning(fmt.Sprintf("Files with local changes were backed up, run 'krci-ai restore %s' to bring them back", result.BackupID))
+	}
+
+	if result.Complete {
+		output.PrintSuccess("krci-ai was uninstalled from the project")
+		return
+	}
+
+	output.PrintSuccess("Selected components were uninstalled")
+}
@@ -0,0 +1,53 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUninstallAgent(t *testing.T) {
+	_, projectDir := prepareAgentProject(t, "alpha", "beta")
+	setFlags(t, uninstallCmd, map[string]string{"agent": "alpha"})
+
+	require.NoError(t, runUninstall(uninstallCmd, nil))
+
+	assert.NoFileExists(t, filepath.Join(projectDir, ".krci-ai", "agents", "alpha.yaml"))
+	assert.NoFileExists(t, filepath.Join(projectDir, ".claude", "commands", "krci-ai", "alpha.md"))
+	assert.FileExists(t, filepath.Join(projectDir, ".krci-ai", "agents", "beta.yaml"))
+
+	// The IDE section lists the remaining agents as remove agent leaves it
+	agentsMD, err := os.ReadFile(filepath.Join(projectDir, "AGENTS.md"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(agentsMD), "(`alpha`)")
+	assert.NotContains(t, string(agentsMD), "alpha.yaml")
+	assert.Contains(t, string(agentsMD), "(`beta`)")
+
+	lock := loadProjectLockfile(t, projectDir)
+	assert.Equal(t, []string{"beta"}, lock.Agents)
+	section, ok := lock.GetFile("AGENTS.md")
+	require.True(t, ok)
+	assert.Equal(t, []string{"beta"}, section.Agents)
+
+	checks, err := lock.Modified(projectDir)
+	require.NoError(t, err)
+	assert.Empty(t, checks)
+}
@@ -0,0 +1,303 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/KubeRocketCI/kuberocketai/internal/assets"
+	"github.com/KubeRocketCI/kuberocketai/internal/cli"
+	"github.com/KubeRocketCI/kuberocketai/internal/discovery"
+	"github.com/KubeRocketCI/kuberocketai/internal/lockfile"
+	"github.com/KubeRocketCI/kuberocketai/internal/source"
+	"github.com/KubeRocketCI/kuberocketai/internal/upgrade"
+	"github.com/KubeRocketCI/kuberocketai/internal/version"
+)
+
+// upgradeCmd represents the upgrade command
+var upgradeCmd = &cobra.Command{
+	Use:   "upgrade",
+	Short: "Upgrade installed framework files while keeping local changes",
+	Long: `Upgrade the installed agents, tasks, templates and data to the versions
+embedded in this CLI without losing local changes.
+
+Every file is merged three ways: the originally installed version recorded in
+.krci-ai/krci-ai.lock, the current local file and the new embedded version.
+- Files without local changes are replaced with the new version
+- Local and upstream changes to different lines are merged automatically
+- Overlapping changes are written with git style conflict markers and listed
+- Files with local changes that did not change upstream are kept
+
+Only the agents recorded in the lockfile are upgraded. Files deleted locally are
+not restored. Agents added from packs or other sources with --from, and the
+files they share, are left untouched: reinstall them from their source with
+'krci-ai add agent <name> --from <source> --force'. The upgraded files and the
+lockfile are written at once, an interrupted upgrade changes no file.
+
+Examples:
+  krci-ai upgrade              # Upgrade and merge local changes
+  krci-ai upgrade --dry-run    # Show what would change without writing files`,
+	RunE: runUpgrade,
+}
+
+func init() {
+	rootCmd.AddCommand(upgradeCmd)
+
+	upgradeCmd.Flags().Bool("dry-run", false, "Print a summary of the changes without writing any file")
+}
+
+func runUpgrade(cmd *cobra.Command, args []string) error {
+	output := cli.NewOutputHandler()
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	projectRoot, err := discovery.GetProjectRoot()
+	if err != nil {
+		return err
+	}
+
+	embedded := assets.NewEmbeddedDiscovery(GetEmbeddedAssets(), assets.EmbeddedPrefix)
+	installer := assets.NewInstaller(projectRoot, GetEmbeddedAssets(), embedded)
+
+	return upgradeFramework(installer, embedded, projectRoot, dryRun, output)
+}
+
+// upgradeFramework upgrades the installed files to the versions of the installer source,
+// whose agents are listed by the discovery
+func upgradeFramework(installer *assets.Installer, agents assets.IstallerDiscovery, projectRoot string, dryRun bool, output *cli.OutputHandler) error {
+	if !installer.IsInstalled() {
+		return fmt.Errorf("framework is not installed, run 'krci-ai install' first")
+	}
+
+	lock, err := lockfile.Load(installer.GetFrameworkPath())
+	if errors.Is(err, lockfile.ErrNotFound) {
+		return fmt.Errorf("%w: reinstall with 'krci-ai install --force' to start tracking installed files", err)
+	}
+	if err != nil {
+		return err
+	}
+
+	files, err := embeddedUpgradeFiles(installer, agents, projectRoot, lock, output)
+	if err != nil {
+		return err
+	}
+
+	if dryRun {
+		output.PrintProgress(fmt.Sprintf("Planning upgrade of %d framework files to krci-ai %s...", len(files), version.GetCurrentVersion()))
+	} else {
+		output.PrintProgress(fmt.Sprintf("Upgrading %d framework files to krci-ai %s...", len(files), version.GetCurrentVersion()))
+		// The upgraded files, their originals and the lockfile are written at once
+		defer beginInstallation(installer, cli.NewErrorHandler())()
+	}
+
+	result, err := upgrade.Apply(upgrade.Options{
+		ProjectDir:   projectRoot,
+		FrameworkDir: installer.GetFrameworkPath(),
+		Lock:         lock,
+		RemoteLabel:  "krci-ai " + version.GetCurrentVersion(),
+		DryRun:       dryRun,
+		Stage:        installer.Stage,
+	}, files)
+	if err != nil {
+		return err
+	}
+
+	if !dryRun {
+		lock.CLI = lockfile.New().CLI
+		if err := installer.SaveLockfile(lock); err != nil {
+			return err
+		}
+		if err := installer.Commit(); err != nil {
+			return fmt.Errorf("failed to complete upgrade: %w", err)
+		}
+	}
+
+	displayUpgradeResult(result, dryRun, output)
+
+	return nil
+}
+
+// embeddedUpgradeFiles returns the new embedded versions of the files of the agents recorded in the lockfile.
+// Agents installed from packs or other sources and the files they share are left to their source.
+func embeddedUpgradeFiles(installer *assets.Installer, agents assets.IstallerDiscovery, projectRoot string, lock *lockfile.Lockfile, output *cli.OutputHandler) ([]upgrade.File, error) {
+	// Lockfiles written before agent sources were recorded do not tell which agents came from the source
+	if lock.Source != nil && lock.Source.Type != lockfile.SourceEmbedded && len(lock.AgentSources) == 0 {
+		return nil, fmt.Errorf("framework files were installed from %s source %s, upgrade only updates files embedded in this CLI: reinstall them with 'krci-ai install --from %s --force'",
+			lock.Source.Type, lock.Source.URL, sourceLocation(*lock.Source))
+	}
+
+	embedded, err := agents.GetAgents(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get embedded agents: %w", err)
+	}
+
+	var agentNames, skipped, sourced []string
+	for _, agent := range embedded {
+		if _, ok := lock.AgentSources[agent.ShortName]; ok {
+			continue
+		}
+		if len(lock.Agents) == 0 || slices.Contains(lock.Agents, agent.ShortName) {
+			agentNames = append(agentNames, agent.ShortName)
+		}
+	}
+	for _, name := range lock.Agents {
+		if _, ok := lock.AgentSources[name]; ok {
+			sourced = append(sourced, name)
+		} else if !slices.Contains(agentNames, name) {
+			skipped = append(skipped, name)
+		}
+	}
+	if len(sourced) > 0 {
+		output.PrintInfo("Skipping agents installed from packs or other sources, reinstall them from their source to update them:")
+		for _, name := range sourced {
+			output.Printf("  • krci-ai add agent %s --from %s --force\n", name, sourceLocation(lock.AgentSources[name]))
+		}
+	}
+	if len(skipped) > 0 {
+		output.PrintInfo(fmt.Sprintf("Skipping agents not shipped with this CLI (installed from packs): %v", skipped))
+	}
+	if len(agentNames) == 0 {
+		return nil, nil
+	}
+
+	// Agents installed with --task keep only their selected tasks
+	var fullAgents []string
+	selection := make(map[string][]string)
+	for _, name := range agentNames {
+		if tasks, ok := lock.Tasks[name]; ok {
+			selection[name] = tasks
+		} else {
+			fullAgents = append(fullAgents, name)
+		}
+	}
+
+	var paths []string
+	if len(fullAgents) > 0 {
+		agentPaths, err := installer.ResolveAgentFiles(fullAgents)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, agentPaths...)
+	}
+
+	trimmed := make(map[string][]byte)
+	if len(selection) > 0 {
+		taskPaths, agentFiles, err := installer.ResolveTaskFiles(selection)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, taskPaths...)
+		trimmed = agentFiles
+	}
+	slices.Sort(paths)
+	paths = slices.Compact(paths)
+
+	files := make([]upgrade.File, 0, len(paths))
+	var shared []string
+	for _, path := range paths {
+		rel, err := filepath.Rel(projectRoot, filepath.Join(installer.GetFrameworkPath(), path))
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve path of %s: %w", path, err)
+		}
+		if entry, ok := lock.GetFile(rel); ok && slices.ContainsFunc(entry.Agents, func(agent string) bool {
+			return slices.Contains(sourced, agent)
+		}) {
+			shared = append(shared, filepath.ToSlash(rel))
+			continue
+		}
+
+		data, ok := trimmed[path]
+		if !ok {
+			var err error
+			if data, err = installer.ReadSourceFile(path); err != nil {
+				return nil, err
+			}
+		}
+		files = append(files, upgrade.File{Path: path, Content: data})
+	}
+	if len(shared) > 0 {
+		output.PrintInfo(fmt.Sprintf("Skipping files shared with agents installed from other sources: %s", strings.Join(shared, ", ")))
+	}
+
+	return files, nil
+}
+
+// sourceLocation returns the recorded source in the form accepted by --from
+func sourceLocation(src lockfile.Source) string {
+	return source.Spec{Type: src.Type, Location: src.URL, Ref: src.Ref}.String()
+}
+
+// displayUpgradeResult prints the changed files and a summary
+func displayUpgradeResult(result *upgrade.Result, dryRun bool, output *cli.OutputHandler) {
+	var rows [][]string
+	for _, change := range result.Changes {
+		if change.Action == upgrade.ActionUnchanged {
+			continue
+		}
+
+		details := change.Reason
+		if change.Conflicts > 0 {
+			details = fmt.Sprintf("%d conflicts", change.Conflicts)
+			if change.Reason != "" {
+				details += ", " + change.Reason
+			}
+		}
+		rows = append(rows, []string{string(change.Action), change.Path, details})
+	}
+
+	if len(rows) == 0 {
+		output.PrintSuccess("Framework files are up to date")
+		return
+	}
+
+	t := cli.CreateStyledTable().
+		Headers("ACTION", "FILE", "DETAILS").
+		Rows(rows...)
+	fmt.Println(t.String())
+
+	output.PrintInfo(fmt.Sprintf("Summary: %d added, %d updated, %d merged, %d conflicts, %d kept, %d skipped, %d unchanged",
+		result.Count(upgrade.ActionAdded),
+		result.Count(upgrade.ActionUpdated),
+		result.Count(upgrade.ActionMerged),
+		result.Count(upgrade.ActionConflict),
+		result.Count(upgrade.ActionKept),
+		result.Count(upgrade.ActionSkipped),
+		result.Count(upgrade.ActionUnchanged),
+	))
+
+	if dryRun {
+		output.PrintInfo("Dry run: no files were changed, run 'krci-ai upgrade' to apply")
+		return
+	}
+
+	if conflicts := result.Conflicts(); len(conflicts) > 0 {
+		output.PrintWarning(fmt.Sprintf("%d files contain conflict markers and need manual resolution:", len(conflicts)))
+		for _, change := range conflicts {
+			output.Printf("  • %s\n", change.Path)
+		}
+		output.PrintInfo("Resolve the conflicts, then run 'krci-ai validate'")
+	} else {
+		output.PrintSuccess("Upgrade completed successfully!")
+	}
+
+	output.PrintInfo("Run 'krci-ai install --sync-ide' to refresh IDE integration files")
+}
@@ -0,0 +1,94 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KubeRocketCI/kuberocketai/internal/assets"
+	"github.com/KubeRocketCI/kuberocketai/internal/cli"
+	"github.com/KubeRocketCI/kuberocketai/internal/lockfile"
+)
+
+// newUpgradeInstaller creates an installer upgrading a project to the framework files of sourceDir,
+// which stand in for the framework embedded in the CLI
+func newUpgradeInstaller(projectDir, sourceDir string) (*assets.Installer, assets.IstallerDiscovery) {
+	agents := assets.NewDiscovery(sourceDir)
+	return assets.NewInstallerFromSource(projectDir, assets.OSFileSystem{}, sourceDir, agents), agents
+}
+
+func TestUpgradeSkipsAgentsFromPacks(t *testing.T) {
+	sourceDir, projectDir := prepareAgentProject(t, "alpha")
+
+	// The pack shadows the beta agent of the framework and ships its own version of a template of alpha
+	packDir := t.TempDir()
+	writeFiles(t, packDir, map[string]string{
+		"agents/beta.yaml":     testAgent("beta"),
+		"tasks/review-beta.md": "---\ndependencies:\n  templates:\n    - review.md\n---\n# Pack review\n",
+		"templates/review.md":  "# Pack review template\n",
+	})
+	setFlags(t, addAgentCmd, map[string]string{"from": packDir})
+	require.NoError(t, runAddAgent(addAgentCmd, []string{"beta"}))
+
+	// The new framework version changes every file
+	writeFiles(t, sourceDir, map[string]string{
+		"agents/alpha.yaml":    testAgent("alpha") + "# upgraded\n",
+		"agents/beta.yaml":     testAgent("beta") + "# upgraded\n",
+		"tasks/review-beta.md": "# Upgraded review\n",
+		"templates/review.md":  "# Upgraded review template\n",
+	})
+
+	installer, agents := newUpgradeInstaller(projectDir, sourceDir)
+	require.NoError(t, upgradeFramework(installer, agents, projectDir, false, cli.NewQuietOutputHandler()))
+
+	frameworkDir := assets.GetKrciPath(projectDir)
+	for path, want := range map[string]string{
+		"agents/alpha.yaml":    testAgent("alpha") + "# upgraded\n",
+		"agents/beta.yaml":     testAgent("beta"),
+		"tasks/review-beta.md": "---\ndependencies:\n  templates:\n    - review.md\n---\n# Pack review\n",
+		"templates/review.md":  "# Pack review template\n",
+	} {
+		data, err := os.ReadFile(filepath.Join(frameworkDir, filepath.FromSlash(path)))
+		require.NoError(t, err)
+		assert.Equal(t, want, string(data), path)
+	}
+
+	lock := loadProjectLockfile(t, projectDir)
+	assert.Equal(t, []string{"alpha", "beta"}, lock.Agents)
+	assert.Equal(t, map[string]lockfile.Source{"beta": {Type: lockfile.SourceLocal, URL: packDir}}, lock.AgentSources)
+	checks, err := lock.Modified(projectDir)
+	require.NoError(t, err)
+	assert.Empty(t, checks)
+}
+
+func TestUpgradeRefusesUntrackedSource(t *testing.T) {
+	sourceDir, projectDir := prepareAgentProject(t, "alpha")
+
+	// Lockfiles written before agent sources were recorded only have the source of the installation
+	lock := loadProjectLockfile(t, projectDir)
+	lock.Source = &lockfile.Source{Type: lockfile.SourceGit, URL: "https://example.com/packs.git", Ref: "v1.0.0"}
+	require.NoError(t, lock.Save(assets.GetKrciPath(projectDir)))
+
+	installer, agents := newUpgradeInstaller(projectDir, sourceDir)
+	err := upgradeFramework(installer, agents, projectDir, false, cli.NewQuietOutputHandler())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "krci-ai install --from https://example.com/packs.git@v1.0.0 --force")
+}
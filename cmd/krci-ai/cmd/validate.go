@@ -25,6 +25,7 @@ import (
 	"github.com/KubeRocketCI/kuberocketai/internal/cli"
 	"github.com/KubeRocketCI/kuberocketai/internal/discovery"
 	"github.com/KubeRocketCI/kuberocketai/internal/validation"
+	"github.com/KubeRocketCI/kuberocketai/internal/values"
 )
 
 // validateCmd represents the validate command
@@ -40,6 +41,7 @@ This command validates:
 - Markdown links to framework files ([text](./.krci-ai/path/file.md))
 - Markdown format validation for task files
 - Cross-platform file accessibility
+- Project variables ({{ .project.name }}) defined in .krci-ai/values.yaml
 
 The validation runs on the current directory framework structure and provides
 detailed error reporting for any issues found.
@@ -79,8 +81,14 @@ func runValidate(cmd *cobra.Command, args []string) error {
 	frameworkDir := assets.GetKrciPath(projectRoot)
 	discoveryService := assets.NewDiscovery(frameworkDir)
 
+	// Load project values to detect undefined variables
+	vals, err := values.Load(frameworkDir)
+	if err != nil {
+		return err
+	}
+
 	// Create analyzer with discovery
-	analyzer := validation.NewFrameworkAnalyzer(discoveryService)
+	analyzer := validation.NewFrameworkAnalyzer(discoveryService).WithValues(vals)
 
 	// Run optimized framework analysis with caching
 	issues, insights, err := analyzer.AnalyzeFramework()
@@ -0,0 +1,94 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package assets
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/KubeRocketCI/kuberocketai/internal/bundle"
+)
+
+// AgentContent holds the content of the tasks, templates and data files used by agents, keyed by path.
+// Files shared by several agents or tasks are collected once.
+type AgentContent struct {
+	Tasks     map[string]string
+	Templates map[string]string
+	DataFiles map[string]string
+}
+
+// NewAgentContent returns an empty AgentContent
+func NewAgentContent() *AgentContent {
+	return &AgentContent{
+		Tasks:     make(map[string]string),
+		Templates: make(map[string]string),
+		DataFiles: make(map[string]string),
+	}
+}
+
+// Collect adds the tasks of an agent, the tasks they reference and their templates and data files,
+// reading the files not collected yet with read
+func (c *AgentContent) Collect(agent Agent, read func(path string) ([]byte, error)) error {
+	if err := collectFiles(agent.GetAllTasksPaths(), c.Tasks, read); err != nil {
+		return err
+	}
+
+	if err := collectFiles(agent.GetAllReferencedTasksPaths(), c.Tasks, read); err != nil {
+		return err
+	}
+
+	if err := collectFiles(agent.GetAllTemplatesPaths(), c.Templates, read); err != nil {
+		return err
+	}
+
+	return collectFiles(agent.GetAllDataFilesPaths(), c.DataFiles, read)
+}
+
+// Empty reports whether no file was collected
+func (c *AgentContent) Empty() bool {
+	return len(c.Tasks) == 0 && len(c.Templates) == 0 && len(c.DataFiles) == 0
+}
+
+// WriteFiles writes the collected files in the bundle file format, tasks first, then templates and
+// data files, each sorted by path. name returns the path written for a collected file.
+func (c *AgentContent) WriteFiles(b *strings.Builder, name func(path string) string) {
+	for _, files := range []map[string]string{c.Tasks, c.Templates, c.DataFiles} {
+		for _, path := range slices.Sorted(maps.Keys(files)) {
+			fmt.Fprintf(b, "%s%s ====\n", bundle.FileStartDelimiter, name(path))
+			b.WriteString(files[path])
+			fmt.Fprintf(b, "\n%s\n\n", bundle.FileEndDelimiter)
+		}
+	}
+}
+
+// collectFiles reads the files of paths not in target yet into target
+func collectFiles(paths []string, target map[string]string, read func(path string) ([]byte, error)) error {
+	for _, path := range paths {
+		if _, exists := target[path]; exists {
+			continue
+		}
+
+		data, err := read(path)
+		if err != nil {
+			return fmt.Errorf("failed to read file %s: %w", path, err)
+		}
+		target[path] = string(data)
+	}
+
+	return nil
+}
@@ -22,6 +22,7 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"golang.org/x/sync/errgroup"
@@ -52,6 +53,11 @@ type EmbeddedFileSystem struct {
 	fs embed.FS
 }
 
+// NewEmbeddedFileSystem wraps embedded assets as a FileSystem
+func NewEmbeddedFileSystem(embeddedFS embed.FS) EmbeddedFileSystem {
+	return EmbeddedFileSystem{fs: embeddedFS}
+}
+
 func (efs EmbeddedFileSystem) WalkDir(root string, fn fs.WalkDirFunc) error {
 	return fs.WalkDir(efs.fs, filepath.ToSlash(root), fn)
 }
@@ -70,6 +76,8 @@ type Agent struct {
 	Tasks       []Task
 	FilePath    string
 	ShortName   string
+	// MinCLIVersion is the minimum krci-ai version required by the agent, empty when unrestricted
+	MinCLIVersion string
 }
 
 func (a *Agent) GetAllTasksPaths() []string {
@@ -115,8 +123,10 @@ func (a *Agent) GetAllReferencedTasksPaths() []string {
 }
 
 type Task struct {
-	Path         string
-	Name         string
+	Path string
+	Name string
+	// Title is the title of the task file heading, empty when it has none
+	Title        string
 	Dependencies TaskDependencies
 }
 
@@ -235,6 +245,29 @@ func (d *Discovery) GetAgents(ctx context.Context) ([]Agent, error) {
 	return agents, nil
 }
 
+// LoadAgent returns the agent of a single agent file with its tasks in the order the agent lists them
+func (d *Discovery) LoadAgent(ctx context.Context, agentPath string) (Agent, error) {
+	rawAgent, err := processor.UnmarshalAgentFileFromFS(d.fs, agentPath)
+	if err != nil {
+		return Agent{}, fmt.Errorf("failed to unmarshal agent file: %w", err)
+	}
+
+	tasks, err := d.getAgentTasks(ctx, rawAgent)
+	if err != nil {
+		return Agent{}, err
+	}
+
+	order := make(map[string]int, len(rawAgent.Agent.Tasks))
+	for idx, ref := range rawAgent.Agent.Tasks {
+		order[strings.TrimSuffix(filepath.Base(ref), filepath.Ext(ref))] = idx
+	}
+	slices.SortFunc(tasks, func(a, b Task) int {
+		return order[a.Name] - order[b.Name]
+	})
+
+	return MakeAgent(agentPath, rawAgent, tasks), nil
+}
+
 // GetAgent returns an agent by short name.
 // TODO: Method can be optimized by reading only one agent instead of all agents.
 func (d *Discovery) GetAgent(ctx context.Context, shortName string) (*Agent, error) {
@@ -339,12 +372,20 @@ func (d *Discovery) getAgentTasks(ctx context.Context, rawAgent *processor.Agent
 func (d *Discovery) getAgentTask(taskRef string) (Task, error) {
 	taskName := strings.TrimPrefix(taskRef, "./"+KrciAIDir+"/tasks/")
 	taskPath := filepath.Join(GetTasksPath(d.frameworkDir), taskName)
-	taskDependencies, err := processor.UnmarshalTaskDependenciesFileFromFS(d.fs, taskPath)
+	data, err := d.fs.ReadFile(taskPath)
+	if err != nil {
+		return Task{}, fmt.Errorf("failed to read file %q: %w", taskPath, err)
+	}
+
+	taskDependencies, err := processor.UnmarshalTaskDependencies(data, taskPath)
 	if err != nil {
 		return Task{}, err
 	}
 
-	return MakeTask(d.frameworkDir, taskPath, *taskDependencies), nil
+	task := MakeTask(d.frameworkDir, taskPath, *taskDependencies)
+	task.Title = processor.TaskTitle(data)
+
+	return task, nil
 }
 
 func GetAgentsPath(frameworkDir string) string {
@@ -369,14 +410,15 @@ func GetKrciPath(projectDir string) string {
 
 func MakeAgent(path string, representation *processor.AgentYamlRepresentation, tasks []Task) Agent {
 	return Agent{
-		Name:        representation.Agent.Identity.Name,
-		Description: representation.Agent.Identity.Description,
-		Role:        representation.Agent.Identity.Role,
-		Goal:        representation.Agent.Identity.Goal,
-		Icon:        representation.Agent.Identity.Icon,
-		FilePath:    path,
-		ShortName:   strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)),
-		Tasks:       tasks,
+		Name:          representation.Agent.Identity.Name,
+		Description:   representation.Agent.Identity.Description,
+		Role:          representation.Agent.Identity.Role,
+		Goal:          representation.Agent.Identity.Goal,
+		Icon:          representation.Agent.Identity.Icon,
+		FilePath:      path,
+		ShortName:     strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)),
+		Tasks:         tasks,
+		MinCLIVersion: representation.Agent.Identity.MinCLIVersion,
 	}
 }
 
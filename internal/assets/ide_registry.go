@@ -0,0 +1,366 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package assets
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// IDEDir holds the custom IDE descriptors of a project inside the framework directory
+const IDEDir = "ide"
+
+// AllIDEs selects every registered IDE integration
+const AllIDEs = "all"
+
+//go:embed ide/*.yaml
+var builtinIDEFiles embed.FS
+
+// ideNamePattern restricts IDE names to what works as --ide value and lockfile entry
+var ideNamePattern = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// IDEDescriptor describes an IDE integration generating a file per agent from a Go text/template.
+// Built-in IDEs are descriptors too, some of them with behavior beyond the template, e.g. the
+// Claude Code subagents.
+type IDEDescriptor struct {
+	// Name selects the integration with --ide and records it in the lockfile
+	Name string `yaml:"name"`
+	// DisplayName is shown in messages, the name when empty
+	DisplayName string `yaml:"display_name,omitempty"`
+	// Description tells what the integration generates
+	Description string `yaml:"description,omitempty"`
+	// Directory is the directory of the agent files relative to the project root,
+	// empty for built-in integrations generating no file per agent
+	Directory string `yaml:"directory"`
+	// FileName is a template for the agent file names without extension, {{.Agent}} when empty
+	FileName string `yaml:"file_name,omitempty"`
+	// Extension is appended to the agent file names, e.g. .md
+	Extension string `yaml:"extension,omitempty"`
+	// Template renders the agent file with IDETemplateData
+	Template string `yaml:"template"`
+	// TaskFileName is a template for the task file names without extension, {{.Agent}}-{{.Task}} when empty
+	TaskFileName string `yaml:"task_file_name,omitempty"`
+	// TaskTemplate renders a file per agent task activating the agent and executing the task,
+	// generated when task commands are enabled
+	TaskTemplate string `yaml:"task_template,omitempty"`
+
+	// Source is the descriptor file of custom IDEs, empty for built-in ones
+	Source string `yaml:"-"`
+}
+
+// Builtin reports whether the IDE integration ships with krci-ai
+func (d IDEDescriptor) Builtin() bool {
+	return d.Source == ""
+}
+
+// Title returns the name of the integration shown in messages
+func (d IDEDescriptor) Title() string {
+	if d.DisplayName != "" {
+		return d.DisplayName
+	}
+	return d.Name
+}
+
+// Summary returns the description of the integration, or the agent files it generates
+func (d IDEDescriptor) Summary() string {
+	if d.Description != "" {
+		return d.Description
+	}
+
+	fileName := d.FileName
+	if templates, err := d.parseTemplates(); err == nil {
+		var name bytes.Buffer
+		if err := templates.fileName.Execute(&name, IDETemplateData{Agent: "<agent>"}); err == nil {
+			fileName = name.String()
+		}
+	}
+	return path.Join(d.Directory, fileName+d.Extension)
+}
+
+// IDETemplateData is the data of IDE descriptor templates. File name templates only get Agent and Task.
+type IDETemplateData struct {
+	// Agent is the agent name, e.g. dev
+	Agent       string
+	Name        string
+	Role        string
+	Description string
+	Goal        string
+	Icon        string
+	// Definition is the agent YAML with project values substituted
+	Definition string
+	// AgentPath is the slash separated agent definition relative to the project root
+	AgentPath string
+	// Dependencies holds the inlined tasks, templates and data files of the agent or task,
+	// empty unless installed with --inline
+	Dependencies string
+
+	// Task, TaskTitle and TaskPath describe the task of task files, e.g. create-prd
+	Task      string
+	TaskTitle string
+	TaskPath  string
+}
+
+// IDERegistry holds the IDE integrations available to a project by name
+type IDERegistry struct {
+	descriptors []IDEDescriptor
+}
+
+// BuiltinIDERegistry returns the registry of the IDE integrations shipped with krci-ai
+func BuiltinIDERegistry() *IDERegistry {
+	registry, err := loadIDEDescriptors(builtinIDEFiles, "ide", "")
+	if err != nil {
+		// Built-in descriptors are covered by tests
+		panic(err)
+	}
+	return registry
+}
+
+// LoadIDERegistry returns the built-in IDE integrations and the custom ones described
+// in <frameworkDir>/ide/*.yaml
+func LoadIDERegistry(frameworkDir string) (*IDERegistry, error) {
+	registry := BuiltinIDERegistry()
+
+	dir := filepath.Join(frameworkDir, IDEDir)
+	custom, err := loadIDEDescriptors(os.DirFS(dir), ".", dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, descriptor := range custom.descriptors {
+		if _, ok := registry.Get(descriptor.Name); ok {
+			return nil, fmt.Errorf("invalid IDE descriptor %s: IDE %s is already registered", descriptor.Source, descriptor.Name)
+		}
+		registry.descriptors = append(registry.descriptors, descriptor)
+	}
+
+	return registry, nil
+}
+
+// loadIDEDescriptors reads and validates the descriptors in dir of fsys, sorted by file name.
+// A missing directory has no descriptors.
+func loadIDEDescriptors(fsys fs.FS, dir, sourceDir string) (*IDERegistry, error) {
+	// I/O errors, e.g. of a missing directory, are ignored by Glob
+	files, err := fs.Glob(fsys, path.Join(dir, "*.yaml"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find IDE descriptors: %w", err)
+	}
+
+	registry := &IDERegistry{}
+	for _, file := range files {
+		source := file
+		if sourceDir != "" {
+			source = filepath.Join(sourceDir, path.Base(file))
+		}
+
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read IDE descriptor %s: %w", source, err)
+		}
+
+		descriptor, err := decodeIDEDescriptor(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse IDE descriptor %s: %w", source, err)
+		}
+		if sourceDir != "" {
+			descriptor.Source = source
+		}
+
+		if err := descriptor.validate(); err != nil {
+			return nil, fmt.Errorf("invalid IDE descriptor %s: %w", source, err)
+		}
+		if _, ok := registry.Get(descriptor.Name); ok {
+			return nil, fmt.Errorf("invalid IDE descriptor %s: IDE %s is already registered", source, descriptor.Name)
+		}
+		registry.descriptors = append(registry.descriptors, descriptor)
+	}
+
+	return registry, nil
+}
+
+// Get returns the descriptor of an IDE integration
+func (r *IDERegistry) Get(name string) (IDEDescriptor, bool) {
+	idx := slices.IndexFunc(r.descriptors, func(descriptor IDEDescriptor) bool {
+		return descriptor.Name == name
+	})
+	if idx < 0 {
+		return IDEDescriptor{}, false
+	}
+	return r.descriptors[idx], true
+}
+
+// Names returns the names of the registered IDE integrations, built-in ones first
+func (r *IDERegistry) Names() []string {
+	names := make([]string, 0, len(r.descriptors))
+	for _, descriptor := range r.descriptors {
+		names = append(names, descriptor.Name)
+	}
+	return names
+}
+
+// Descriptors returns the registered IDE integrations, built-in ones first
+func (r *IDERegistry) Descriptors() []IDEDescriptor {
+	return slices.Clone(r.descriptors)
+}
+
+// validate checks a descriptor and its templates
+func (d IDEDescriptor) validate() error {
+	if !ideNamePattern.MatchString(d.Name) {
+		return fmt.Errorf("name %q must start with a lowercase letter followed by lowercase letters, digits or dashes", d.Name)
+	}
+	if d.Name == AllIDEs {
+		return fmt.Errorf("name %q is reserved", d.Name)
+	}
+
+	switch {
+	case d.Directory == "" && d.Builtin():
+		// Built-in integrations without agent files are implemented in Go
+	case d.Directory == "":
+		return errors.New("directory is required")
+	case filepath.IsAbs(d.Directory) || !filepath.IsLocal(filepath.FromSlash(d.Directory)):
+		return fmt.Errorf("directory %s must be relative to the project root and stay inside it", d.Directory)
+	case strings.Split(path.Clean(d.Directory), "/")[0] == KrciAIDir:
+		return fmt.Errorf("directory %s must not be inside %s", d.Directory, KrciAIDir)
+	case strings.TrimSpace(d.Template) == "":
+		return errors.New("template is required")
+	}
+
+	if strings.ContainsAny(d.FileName+d.TaskFileName+d.Extension, `/\`) {
+		return fmt.Errorf("file_name, task_file_name and extension must not contain path separators")
+	}
+	if d.Directory == "" && d.TaskTemplate != "" {
+		return errors.New("task_template requires a directory")
+	}
+
+	_, err := d.parseTemplates()
+	return err
+}
+
+// ideTemplates are the parsed templates of a descriptor, task templates are nil without task_template
+type ideTemplates struct {
+	fileName     *template.Template
+	content      *template.Template
+	taskFileName *template.Template
+	task         *template.Template
+}
+
+// parseTemplates parses the file name and content templates of a descriptor
+func (d IDEDescriptor) parseTemplates() (ideTemplates, error) {
+	fileName := d.FileName
+	if fileName == "" {
+		fileName = "{{.Agent}}"
+	}
+
+	var templates ideTemplates
+	var err error
+	if templates.fileName, err = template.New("file_name").Option("missingkey=error").Parse(fileName); err != nil {
+		return ideTemplates{}, fmt.Errorf("failed to parse file_name: %w", err)
+	}
+
+	if templates.content, err = parseIDETemplate("template", d.Template); err != nil {
+		return ideTemplates{}, err
+	}
+
+	if d.TaskTemplate == "" {
+		return templates, nil
+	}
+
+	taskFileName := d.TaskFileName
+	if taskFileName == "" {
+		taskFileName = "{{.Agent}}-{{.Task}}"
+	}
+	if templates.taskFileName, err = template.New("task_file_name").Option("missingkey=error").Parse(taskFileName); err != nil {
+		return ideTemplates{}, fmt.Errorf("failed to parse task_file_name: %w", err)
+	}
+	if templates.task, err = parseIDETemplate("task_template", d.TaskTemplate); err != nil {
+		return ideTemplates{}, err
+	}
+
+	return templates, nil
+}
+
+// parseIDETemplate parses a content template of a descriptor with the template functions
+func parseIDETemplate(name, text string) (*template.Template, error) {
+	tmpl := template.New(name).Option("missingkey=error")
+	tmpl.Funcs(ideTemplateFuncs(tmpl))
+	if _, err := tmpl.Parse(text); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+
+	return tmpl, nil
+}
+
+// yamlString encodes s as a YAML string on a single line, quoted when needed, e.g. for frontmatter values
+func yamlString(s string) (string, error) {
+	node := yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: s}
+	if strings.ContainsAny(s, "\r\n") {
+		node.Style = yaml.DoubleQuotedStyle
+	}
+
+	data, err := yaml.Marshal(&node)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode YAML string: %w", err)
+	}
+
+	return strings.TrimSuffix(string(data), "\n"), nil
+}
+
+// ideTemplateFuncs returns the functions available to descriptor templates.
+// include renders a template defined with {{define}} so its output can be piped, e.g. to toml.
+func ideTemplateFuncs(tmpl *template.Template) template.FuncMap {
+	return template.FuncMap{
+		"title": func(s string) string {
+			if s == "" {
+				return s
+			}
+			return strings.ToUpper(s[:1]) + s[1:]
+		},
+		"lower":       strings.ToLower,
+		"upper":       strings.ToUpper,
+		"toml":        tomlString,
+		"yaml":        yamlString,
+		"githubTools": func() string { return GitHubToolsList },
+		"include": func(name string, data any) (string, error) {
+			var out bytes.Buffer
+			if err := tmpl.ExecuteTemplate(&out, name, data); err != nil {
+				return "", err
+			}
+			return out.String(), nil
+		},
+	}
+}
+
+// decodeIDEDescriptor parses a descriptor, rejecting unknown fields to catch typos in custom descriptors
+func decodeIDEDescriptor(data []byte) (IDEDescriptor, error) {
+	var descriptor IDEDescriptor
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&descriptor); err != nil {
+		return IDEDescriptor{}, err
+	}
+	return descriptor, nil
+}
@@ -0,0 +1,236 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package assets
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/KubeRocketCI/kuberocketai/internal/lockfile"
+)
+
+func TestBuiltinIDERegistry(t *testing.T) {
+	registry := BuiltinIDERegistry()
+
+	assert.Equal(t, []string{"agentsmd", "claude", "cline", "copilot", "cursor", "gemini", "kiro", "roo", "vscode", "windsurf"}, registry.Names())
+	for _, descriptor := range registry.Descriptors() {
+		assert.True(t, descriptor.Builtin(), descriptor.Name)
+		assert.NotEmpty(t, descriptor.Description, descriptor.Name)
+		assert.NotEqual(t, descriptor.Name, descriptor.Title(), descriptor.Name)
+	}
+
+	cursor, ok := registry.Get("cursor")
+	require.True(t, ok)
+	assert.Equal(t, ".cursor/rules/krci-ai", cursor.Directory)
+	assert.Equal(t, ".mdc", cursor.Extension)
+
+	_, ok = registry.Get("unknown")
+	assert.False(t, ok)
+}
+
+func TestLoadIDERegistry(t *testing.T) {
+	frameworkDir := t.TempDir()
+	writeTree(t, frameworkDir, map[string]string{
+		"ide/acme.yaml": "name: acme\ndirectory: .acme/agents\nfile_name: krci-{{.Agent}}\nextension: .md\ntemplate: \"# {{.Role}}\\n\"\n",
+	})
+
+	registry, err := LoadIDERegistry(frameworkDir)
+	require.NoError(t, err)
+
+	names := registry.Names()
+	assert.Equal(t, "acme", names[len(names)-1], "custom IDEs follow the built-in ones")
+
+	acme, ok := registry.Get("acme")
+	require.True(t, ok)
+	assert.False(t, acme.Builtin())
+	assert.Equal(t, filepath.Join(frameworkDir, "ide", "acme.yaml"), acme.Source)
+	assert.Equal(t, "acme", acme.Title())
+	assert.Equal(t, ".acme/agents/krci-<agent>.md", acme.Summary())
+}
+
+func TestLoadIDERegistryMissingDirectory(t *testing.T) {
+	registry, err := LoadIDERegistry(t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, BuiltinIDERegistry().Names(), registry.Names())
+}
+
+func TestLoadIDERegistryInvalid(t *testing.T) {
+	tests := []struct {
+		name       string
+		descriptor string
+		expected   string
+	}{
+		{
+			name:       "built-in name",
+			descriptor: "name: cursor\ndirectory: .acme\ntemplate: x\n",
+			expected:   "IDE cursor is already registered",
+		},
+		{
+			name:       "reserved name",
+			descriptor: "name: all\ndirectory: .acme\ntemplate: x\n",
+			expected:   "is reserved",
+		},
+		{
+			name:       "invalid name",
+			descriptor: "name: Acme Tool\ndirectory: .acme\ntemplate: x\n",
+			expected:   "must start with a lowercase letter",
+		},
+		{
+			name:       "missing directory",
+			descriptor: "name: acme\ntemplate: x\n",
+			expected:   "directory is required",
+		},
+		{
+			name:       "directory outside of the project",
+			descriptor: "name: acme\ndirectory: ../acme\ntemplate: x\n",
+			expected:   "must be relative to the project root",
+		},
+		{
+			name:       "absolute directory",
+			descriptor: "name: acme\ndirectory: /tmp/acme\ntemplate: x\n",
+			expected:   "must be relative to the project root",
+		},
+		{
+			name:       "framework directory",
+			descriptor: "name: acme\ndirectory: .krci-ai/agents\ntemplate: x\n",
+			expected:   "must not be inside .krci-ai",
+		},
+		{
+			name:       "missing template",
+			descriptor: "name: acme\ndirectory: .acme\n",
+			expected:   "template is required",
+		},
+		{
+			name:       "file name with path",
+			descriptor: "name: acme\ndirectory: .acme\nfile_name: sub/{{.Agent}}\ntemplate: x\n",
+			expected:   "must not contain path separators",
+		},
+		{
+			name:       "task file name with path",
+			descriptor: "name: acme\ndirectory: .acme\ntask_file_name: tasks/{{.Task}}\ntemplate: x\ntask_template: y\n",
+			expected:   "must not contain path separators",
+		},
+		{
+			name:       "broken task template",
+			descriptor: "name: acme\ndirectory: .acme\ntemplate: x\ntask_template: \"{{.Task\"\n",
+			expected:   "failed to parse task_template",
+		},
+		{
+			name:       "broken template",
+			descriptor: "name: acme\ndirectory: .acme\ntemplate: \"{{.Role\"\n",
+			expected:   "failed to parse template",
+		},
+		{
+			name:       "unknown field",
+			descriptor: "name: acme\ndirectory: .acme\ntemplates: x\n",
+			expected:   "failed to parse IDE descriptor",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frameworkDir := t.TempDir()
+			writeTree(t, frameworkDir, map[string]string{"ide/acme.yaml": tt.descriptor})
+
+			_, err := LoadIDERegistry(frameworkDir)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expected)
+			assert.Contains(t, err.Error(), filepath.Join(frameworkDir, "ide", "acme.yaml"))
+		})
+	}
+}
+
+func TestCustomIDEIntegration(t *testing.T) {
+	sourceDir, projectDir := prepareInstall(t)
+	writeTree(t, projectDir, map[string]string{
+		".krci-ai/ide/acme.yaml": `name: acme
+display_name: Acme Assistant
+directory: .acme/agents
+file_name: "krci-{{ .Agent }}"
+extension: .md
+template: |
+  # {{ .Icon }} {{ .Role }} ({{ .Name }})
+
+  {{ .Description }} Goal: {{ .Goal }}.
+
+  ` + "```yaml\n  {{ .Definition }}```\n",
+	})
+
+	installer := NewInstallerFromSource(projectDir, OSFileSystem{}, sourceDir, NewDiscovery(sourceDir))
+	assert.False(t, installer.HasIDE("acme"))
+
+	require.NoError(t, installer.Begin())
+	require.NoError(t, installer.Install())
+	require.NoError(t, installer.InstallIDE("acme"))
+	require.NoError(t, installer.UpdateLockfile([]string{"acme"}))
+	require.NoError(t, installer.Commit())
+
+	assert.True(t, installer.HasIDE("acme"))
+	assert.Contains(t, installer.InstalledIDEs(), "acme")
+	assert.Equal(t, filepath.Join(projectDir, ".acme", "agents"), installer.IDEPath("acme"))
+
+	data, err := os.ReadFile(filepath.Join(projectDir, ".acme", "agents", "krci-tester.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "# 🧪 Tester (Tester)\n\nAgent for installation tests Goal: Test installations.\n\n```yaml\n# new\n"+testerAgent+"```\n", string(data))
+
+	lock, err := lockfile.Load(installer.GetFrameworkPath())
+	require.NoError(t, err)
+	file, ok := lock.GetFile(".acme/agents/krci-tester.md")
+	require.True(t, ok)
+	assert.Equal(t, "acme", file.IDE)
+	assert.Equal(t, []string{"tester"}, file.Agents)
+
+	assert.EqualError(t, installer.InstallIDE("unknown"), "unsupported IDE unknown (available: agentsmd, claude, cline, copilot, cursor, gemini, kiro, roo, vscode, windsurf, acme)")
+}
+
+func TestYAMLString(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{name: "plain", value: "Plan tests as the Tester agent", want: "Plan tests as the Tester agent"},
+		{name: "colon", value: "Review: PRD as the PM agent", want: "'Review: PRD as the PM agent'"},
+		{name: "comment", value: "Fix #12 as the Dev agent"},
+		{name: "quotes", value: `Write "release notes" as the Writer's agent`},
+		{name: "leading indicator", value: "- [x] done"},
+		{name: "boolean", value: "true", want: `"true"`},
+		{name: "lines", value: "line one\nline two"},
+		{name: "empty", value: "", want: `""`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := yamlString(tt.value)
+			require.NoError(t, err)
+			assert.NotContains(t, value, "\n")
+			if tt.want != "" {
+				assert.Equal(t, tt.want, value)
+			}
+
+			var parsed struct {
+				Description string `yaml:"description"`
+			}
+			require.NoError(t, yaml.Unmarshal([]byte("description: "+value+"\n"), &parsed), value)
+			assert.Equal(t, tt.value, parsed.Description)
+		})
+	}
+}
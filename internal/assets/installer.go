@@ -23,10 +23,15 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"golang.org/x/sync/errgroup"
 
+	"github.com/KubeRocketCI/kuberocketai/internal/backup"
+	"github.com/KubeRocketCI/kuberocketai/internal/lockfile"
+	"github.com/KubeRocketCI/kuberocketai/internal/transaction"
 	"github.com/KubeRocketCI/kuberocketai/internal/utils"
+	"github.com/KubeRocketCI/kuberocketai/internal/version"
 )
 
 const (
@@ -61,20 +66,136 @@ type IstallerDiscovery interface {
 
 // Installer handles installation of framework assets
 type Installer struct {
-	projectDir     string
-	krciPath       string
-	embeddedAssets EmbeddedFileSystem
-	discovery      IstallerDiscovery
+	projectDir string
+	krciPath   string
+	// source and sourceDir point to the framework files to install, the embedded assets by default
+	source    FileSystem
+	sourceDir string
+	sourceRef *lockfile.Source
+	packRef   *lockfile.Pack
+	discovery IstallerDiscovery
+	// backup saves locally modified files before they are overwritten
+	backup *backup.Session
+
+	// mu guards the installation record used to write the lockfile
+	mu             sync.Mutex
+	installedFiles map[string]string
+	agentNames     []string
+	// complete records an installation of all agents of the source, which replaces the source of the lockfile
+	complete bool
+	// fileAgents and fileIDEs record which agents and IDE integration each written file belongs to
+	fileAgents map[string][]string
+	fileIDEs   map[string]string
+	// agentTasks records the selected tasks of agents installed with InstallTasks
+	agentTasks map[string][]string
+	// sectionFiles records the files where only a managed section was written
+	sectionFiles map[string]bool
+	// aggregateFiles records the files generated for all installed agents by aggregate IDE integrations
+	aggregateFiles map[string]bool
+
+	// copilotInstructions lists the agents in the GitHub Copilot repository instructions
+	copilotInstructions bool
+	// claudeSubagents generates a Claude Code subagent per agent next to the slash commands,
+	// nil keeps the subagents of the installation
+	claudeSubagents *bool
+	// taskCommands generates a file per agent task for IDE integrations with a task template,
+	// nil keeps the task files of the installation
+	taskCommands *bool
+	// inline embeds the tasks of the agents with their templates and data files in the IDE files,
+	// nil keeps inlining in installations with inlined files
+	inline *bool
+	// inlinedFiles records the IDE files written with inlined dependencies
+	inlinedFiles map[string][]byte
+	// ideChanges records what syncing each IDE integration did with its files
+	ideChanges map[string][]IDEFileChange
+	// ideRegistry holds the built-in and custom IDE integrations, loaded on first use
+	ideRegistry *IDERegistry
+
+	// txnMu guards the transaction staging the written files between Begin and Commit
+	txnMu      sync.RWMutex
+	txn        *transaction.Transaction
+	rolledBack bool
+	// joined is set when the transaction belongs to the installation of another installer
+	joined bool
 }
 
 // NewInstaller creates a new asset installer
 func NewInstaller(projectDir string, embeddedAssets embed.FS, discovery IstallerDiscovery) *Installer {
+	return NewInstallerFromSource(projectDir, EmbeddedFileSystem{fs: embeddedAssets}, EmbeddedPrefix, discovery)
+}
+
+// NewInstallerFromSource creates an asset installer that copies framework files
+// from sourceDir of the given filesystem (e.g. a fetched agent pack)
+func NewInstallerFromSource(projectDir string, source FileSystem, sourceDir string, discovery IstallerDiscovery) *Installer {
 	return &Installer{
 		projectDir:     projectDir,
 		krciPath:       GetKrciPath(projectDir),
-		embeddedAssets: EmbeddedFileSystem{fs: embeddedAssets},
+		source:         source,
+		sourceDir:      sourceDir,
 		discovery:      discovery,
+		backup:         backup.NewSession(projectDir, GetKrciPath(projectDir), "install"),
+		installedFiles: make(map[string]string),
+		fileAgents:     make(map[string][]string),
+		fileIDEs:       make(map[string]string),
+		agentTasks:     make(map[string][]string),
+		sectionFiles:   make(map[string]bool),
+		aggregateFiles: make(map[string]bool),
+		inlinedFiles:   make(map[string][]byte),
+		ideChanges:     make(map[string][]IDEFileChange),
+	}
+}
+
+// Backup returns the backup of locally modified files overwritten by this installer
+func (i *Installer) Backup() *backup.Session {
+	return i.backup
+}
+
+// WithSource records where the installed framework files came from
+func (i *Installer) WithSource(source lockfile.Source) *Installer {
+	i.sourceRef = &source
+	return i
+}
+
+// WithCopilotInstructions lists the installed agents in the GitHub Copilot repository instructions
+func (i *Installer) WithCopilotInstructions() *Installer {
+	i.copilotInstructions = true
+	return i
+}
+
+// WithClaudeSubagents turns the generation of a Claude Code subagent per agent next to the slash commands
+// on or off. Subagents generated before are removed when it is turned off.
+func (i *Installer) WithClaudeSubagents(enabled bool) *Installer {
+	i.claudeSubagents = &enabled
+	return i
+}
+
+// WithTaskCommands turns the generation of a command, rule or prompt per agent task activating the agent
+// and executing the task on or off, for IDE integrations with a task template. Task files generated
+// before are removed when it is turned off.
+func (i *Installer) WithTaskCommands(enabled bool) *Installer {
+	i.taskCommands = &enabled
+	return i
+}
+
+// WithInline turns embedding the tasks of the agents with their templates and data files in the IDE files
+// on or off, for IDE tools that cannot read the framework files
+func (i *Installer) WithInline(enabled bool) *Installer {
+	i.inline = &enabled
+	return i
+}
+
+// WithPack records that the installed framework files are the given version of an agent pack
+func (i *Installer) WithPack(name, version string) *Installer {
+	i.packRef = &lockfile.Pack{Name: name, Version: version}
+	return i
+}
+
+// WithPackChecksum records the published checksum the pack set with WithPack was verified against
+func (i *Installer) WithPackChecksum(sha256 string) *Installer {
+	if i.packRef != nil {
+		i.packRef.SHA256 = sha256
 	}
+	return i
 }
 
 // Install installs framework assets to the target directory
@@ -84,13 +205,26 @@ func (i *Installer) Install() error {
 		return fmt.Errorf("failed to get agents: %w", err)
 	}
 
-	prefix := filepath.Clean(EmbeddedPrefix + "/")
+	if err := checkCLICompatibility(agents); err != nil {
+		return err
+	}
+
+	prefix := filepath.Clean(i.sourceDir + "/")
 	filesFilter := make(map[string]struct{})
 	for _, agent := range agents {
-		i.addAgentDependencies(agent, filesFilter, prefix)
+		agentFiles := make(map[string]struct{})
+		i.addAgentDependencies(agent, agentFiles, prefix)
+		if err := i.addReferencedTaskDependencies(agent, agentFiles, prefix); err != nil {
+			return err
+		}
+		maps.Copy(filesFilter, agentFiles)
+		i.recordAgent(agent.ShortName, agentFiles)
 	}
+	i.mu.Lock()
+	i.complete = true
+	i.mu.Unlock()
 
-	return i.copyEmbeddedFiles(context.TODO(), filesFilter)
+	return i.copySourceFiles(context.TODO(), filesFilter)
 }
 
 // InstallSelective installs only specified agents and their dependencies using existing bundle logic
@@ -99,22 +233,53 @@ func (i *Installer) InstallSelective(agentNames []string) error {
 		return fmt.Errorf("no agents specified")
 	}
 
+	filesFilter, err := i.resolveAgentFiles(agentNames)
+	if err != nil {
+		return err
+	}
+
+	return i.copySourceFiles(context.TODO(), filesFilter)
+}
+
+// ResolveAgentFiles returns the source files of the given agents and their full dependency closure.
+// Paths are slash separated and relative to the framework directory, sorted for reproducible output.
+func (i *Installer) ResolveAgentFiles(agentNames []string) ([]string, error) {
+	filesFilter, err := i.resolveAgentFiles(agentNames)
+	if err != nil {
+		return nil, err
+	}
+
+	return filterPaths(filesFilter), nil
+}
+
+// resolveAgentFiles collects the files filter for the given agents
+func (i *Installer) resolveAgentFiles(agentNames []string) (map[string]struct{}, error) {
 	agents, err := i.discovery.GetAgentsByNames(context.Background(), agentNames)
 	if err != nil {
-		return fmt.Errorf("failed to get agents: %w", err)
+		return nil, fmt.Errorf("failed to get agents: %w", err)
 	}
 
 	if err := i.validateAgentsFound(agents, agentNames); err != nil {
-		return err
+		return nil, err
+	}
+
+	if err := checkCLICompatibility(agents); err != nil {
+		return nil, err
 	}
 
-	prefix := filepath.Clean(EmbeddedPrefix + "/")
+	prefix := filepath.Clean(i.sourceDir + "/")
 	filesFilter := make(map[string]struct{})
 	for _, agent := range agents {
-		i.addAgentDependencies(agent, filesFilter, prefix)
+		agentFiles := make(map[string]struct{})
+		i.addAgentDependencies(agent, agentFiles, prefix)
+		if err := i.addReferencedTaskDependencies(agent, agentFiles, prefix); err != nil {
+			return nil, err
+		}
+		maps.Copy(filesFilter, agentFiles)
+		i.recordAgent(agent.ShortName, agentFiles)
 	}
 
-	return i.copyEmbeddedFiles(context.TODO(), filesFilter)
+	return filesFilter, nil
 }
 
 // validateAgentsFound checks if all requested agents were found
@@ -137,6 +302,24 @@ func (i *Installer) validateAgentsFound(agents []Agent, agentNames []string) err
 	return fmt.Errorf("agents not found: %s", strings.Join(notFound, ", "))
 }
 
+// checkCLICompatibility refuses agents that require a newer CLI than the running one
+func checkCLICompatibility(agents []Agent) error {
+	current := version.GetCurrentVersion()
+
+	var incompatible []string
+	for _, agent := range agents {
+		if err := version.CheckMinimumVersion(current, agent.MinCLIVersion); err != nil {
+			incompatible = append(incompatible, fmt.Sprintf("%s: %v", agent.ShortName, err))
+		}
+	}
+
+	if len(incompatible) > 0 {
+		return fmt.Errorf("agents require a newer krci-ai version:\n  %s", strings.Join(incompatible, "\n  "))
+	}
+
+	return nil
+}
+
 // addAgentDependencies adds agent and its dependencies to the files filter
 func (i *Installer) addAgentDependencies(agent Agent, filesFilter map[string]struct{}, prefix string) {
 	trimPrefix := func(path string) string {
@@ -150,8 +333,8 @@ func (i *Installer) addAgentDependencies(agent Agent, filesFilter map[string]str
 	maps.Insert(filesFilter, maps.All(utils.MapSliceToSet(agent.GetAllReferencedTasksPaths(), trimPrefix)))
 }
 
-// copyEmbeddedFiles copies embedded files to the target directory.
-func (i *Installer) copyEmbeddedFiles(ctx context.Context, filepaths map[string]struct{}) error {
+// copySourceFiles copies framework files from the installer source to the target directory.
+func (i *Installer) copySourceFiles(ctx context.Context, filepaths map[string]struct{}) error {
 	g, ctx := errgroup.WithContext(ctx)
 	paths := make(chan string)
 
@@ -180,20 +363,14 @@ func (i *Installer) copyEmbeddedFiles(ctx context.Context, filepaths map[string]
 				default:
 				}
 
-				targetPath := filepath.Join(i.krciPath, path)
-
-				if err := os.MkdirAll(filepath.Dir(targetPath), DirectoryPermissions); err != nil {
-					return fmt.Errorf("failed to create parent directory for %s: %w", targetPath, err)
-				}
-
-				embeddedPath := filepath.Join(EmbeddedPrefix, path)
-				data, err := i.embeddedAssets.ReadFile(embeddedPath)
+				sourcePath := filepath.Join(i.sourceDir, path)
+				data, err := i.source.ReadFile(sourcePath)
 				if err != nil {
-					return fmt.Errorf("failed to read embedded file %s (target: %s): %w", embeddedPath, targetPath, err)
+					return fmt.Errorf("failed to read source file %s (target: %s): %w", sourcePath, filepath.Join(i.krciPath, path), err)
 				}
 
-				if err := os.WriteFile(targetPath, data, FilePermissions); err != nil {
-					return fmt.Errorf("failed to write file to %s (source: %s): %w", targetPath, embeddedPath, err)
+				if err := i.writeFrameworkFile(path, data); err != nil {
+					return err
 				}
 			}
 
@@ -202,24 +379,64 @@ func (i *Installer) copyEmbeddedFiles(ctx context.Context, filepaths map[string]
 	}
 
 	if err := g.Wait(); err != nil {
-		return fmt.Errorf("failed to copy embedded files: %w", err)
+		return fmt.Errorf("failed to copy framework files: %w", err)
 	}
 
 	return nil
 }
 
-// createDirectory creates a directory if it doesn't exist
-func (i *Installer) createDirectory(path string) error {
-	if _, err := os.Stat(path); os.IsNotExist(err) {
-		return os.MkdirAll(path, DirectoryPermissions)
+// writeFrameworkFile writes a file of the files filter to the framework directory and records it
+func (i *Installer) writeFrameworkFile(path string, data []byte) error {
+	targetPath := filepath.Join(i.krciPath, path)
+
+	if _, err := i.backup.Protect(targetPath, data); err != nil {
+		return err
 	}
+
+	if err := i.writeFile(targetPath, data); err != nil {
+		return err
+	}
+
+	// Keep the original content as the merge base for later upgrades
+	err := i.stage(i.krciPath, func(frameworkDir string) error {
+		_, err := lockfile.StoreObject(frameworkDir, data)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	i.recordFile(targetPath, data)
+
 	return nil
 }
 
+// ReadSourceFile reads a framework file from the installer source.
+// The path is slash separated and relative to the framework directory.
+func (i *Installer) ReadSourceFile(path string) ([]byte, error) {
+	sourcePath := filepath.Join(i.sourceDir, filepath.FromSlash(path))
+	data, err := i.source.ReadFile(sourcePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read source file %s: %w", sourcePath, err)
+	}
+
+	return data, nil
+}
+
+// createDirectory creates a directory if it doesn't exist
+func (i *Installer) createDirectory(path string) error {
+	return i.stage(path, func(path string) error {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			return os.MkdirAll(path, DirectoryPermissions)
+		}
+		return nil
+	})
+}
+
 // IsInstalled checks if the framework is properly installed in the target directory
 func (i *Installer) IsInstalled() bool {
 	// Check if main directory exists
-	if _, err := os.Stat(i.krciPath); os.IsNotExist(err) {
+	if !i.exists(i.krciPath) {
 		return false
 	}
 
@@ -227,7 +444,7 @@ func (i *Installer) IsInstalled() bool {
 	requiredDirs := []string{agentsDir, TasksDir}
 	for _, dir := range requiredDirs {
 		dirPath := filepath.Join(i.krciPath, dir)
-		if _, err := os.Stat(dirPath); os.IsNotExist(err) {
+		if !i.exists(dirPath) {
 			return false
 		}
 	}
@@ -243,7 +460,7 @@ func (i *Installer) ValidateInstallation() error {
 
 	// Check that agents directory has files
 	agentsPath := i.GetAgentsPath()
-	agentFiles, err := filepath.Glob(filepath.Join(agentsPath, "*.yaml"))
+	agentFiles, err := i.glob(filepath.Join(agentsPath, "*.yaml"))
 	if err != nil {
 		return fmt.Errorf("failed to check agent files: %w", err)
 	}
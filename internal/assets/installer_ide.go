@@ -1,326 +1,1290 @@
 package assets
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"maps"
 	"os"
+	"path"
 	"path/filepath"
+	"slices"
 	"strings"
 
+	"gopkg.in/yaml.v3"
+
+	"github.com/KubeRocketCI/kuberocketai/internal/bundle"
+	"github.com/KubeRocketCI/kuberocketai/internal/lockfile"
 	"github.com/KubeRocketCI/kuberocketai/internal/processor"
+	"github.com/KubeRocketCI/kuberocketai/internal/roomodes"
+	"github.com/KubeRocketCI/kuberocketai/internal/section"
+	"github.com/KubeRocketCI/kuberocketai/internal/values"
 )
 
 const (
-	// IDE integration directories
-	cursorRulesDir    = ".cursor/rules/krci-ai"
-	claudeCommandsDir = ".claude/commands/krci-ai"
-	vscodeModesDir    = ".github/chatmodes"
-	windsurfRulesDir  = ".windsurf/rules"
+	// claudeAgentsDir holds the Claude Code subagents
+	claudeAgentsDir = ".claude/agents"
+	// copilotInstructionsFile holds the repository instructions of GitHub Copilot
+	copilotInstructionsFile = ".github/copilot-instructions.md"
+	// agentsMDFile holds the instructions read by Codex, Jules, Zed and other coding agents
+	agentsMDFile = "AGENTS.md"
+	// inlineHeading starts the dependencies inlined in IDE files installed with --inline
+	inlineHeading = "## Inlined Dependencies"
 )
 
 // IDEIntegration defines the interface for IDE-specific integrations
 type IDEIntegration interface {
+	// Name returns the IDE name used by the --ide flag and the lockfile
+	Name() string
+	// GetDirectoryPath returns the directory of the agent files, empty for integrations generating
+	// no file per agent
 	GetDirectoryPath() string
 	GetFileExtension() string
-	GenerateContent(agentName, role string, yamlContent []byte) string
+	// AgentFileName returns the file name of the agent file inside the directory, with extension
+	AgentFileName(agentName string) (string, error)
+	// GenerateContent serializes the IDE file activating an agent in the format the IDE reads,
+	// e.g. markdown or TOML
+	GenerateContent(agentName, role string, yamlContent []byte) ([]byte, error)
 }
 
-// CursorIntegration implements IDEIntegration for Cursor IDE
-type CursorIntegration struct {
+// TemplateIntegration implements IDEIntegration with the templates of an IDE descriptor.
+// Built-in integrations with more files embed it.
+type TemplateIntegration struct {
 	projectDir string
+	descriptor IDEDescriptor
+	templates  ideTemplates
+	// taskFiles generates a file per agent task with the task template of the descriptor
+	taskFiles bool
+	// inline embeds the tasks of the agent with their templates and data files in the generated files
+	inline bool
+}
+
+// newTemplateIntegration returns the integration of a descriptor for a project
+func newTemplateIntegration(projectDir string, descriptor IDEDescriptor) (*TemplateIntegration, error) {
+	templates, err := descriptor.parseTemplates()
+	if err != nil {
+		return nil, fmt.Errorf("invalid IDE %s: %w", descriptor.Name, err)
+	}
+
+	return &TemplateIntegration{projectDir: projectDir, descriptor: descriptor, templates: templates}, nil
+}
+
+func (t *TemplateIntegration) Name() string {
+	return t.descriptor.Name
+}
+
+func (t *TemplateIntegration) GetDirectoryPath() string {
+	if t.descriptor.Directory == "" {
+		return ""
+	}
+	return filepath.Join(t.projectDir, filepath.FromSlash(t.descriptor.Directory))
+}
+
+func (t *TemplateIntegration) GetFileExtension() string {
+	return t.descriptor.Extension
+}
+
+// AgentFileName renders the file name template of the descriptor for an agent
+func (t *TemplateIntegration) AgentFileName(agentName string) (string, error) {
+	var name bytes.Buffer
+	if err := t.templates.fileName.Execute(&name, IDETemplateData{Agent: agentName}); err != nil {
+		return "", fmt.Errorf("failed to render file name: %w", err)
+	}
+
+	fileName := name.String() + t.descriptor.Extension
+	if strings.TrimSpace(name.String()) == "" || strings.ContainsAny(fileName, `/\`) || fileName == "." || fileName == ".." {
+		return "", fmt.Errorf("invalid file name %q for agent %s", fileName, agentName)
+	}
+
+	return fileName, nil
+}
+
+// GenerateContent renders the template of the descriptor with the agent identity and definition
+func (t *TemplateIntegration) GenerateContent(agentName, role string, yamlContent []byte) ([]byte, error) {
+	return t.generateContent(agentName, role, yamlContent, "")
+}
+
+// inlines reports whether the dependencies of the agent are embedded in the generated files
+func (t *TemplateIntegration) inlines() bool {
+	return t.inline
+}
+
+// generateAgentContent renders the agent file of an agent, with its dependencies when inlining is enabled
+func (t *TemplateIntegration) generateAgentContent(agent IDEAgent) ([]byte, error) {
+	dependencies, err := t.inlineDependencies(agent, agent.Tasks, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.generateContent(agent.ShortName, agent.Role, agent.Definition, dependencies)
+}
+
+// inlineDependencies returns the given tasks of an agent with their templates and data files in the
+// bundle file format, preceded by the agent definition when requested. It is empty when inlining is
+// disabled or there is nothing to inline.
+func (t *TemplateIntegration) inlineDependencies(agent IDEAgent, tasks []Task, definition bool) (string, error) {
+	if !t.inline {
+		return "", nil
+	}
+
+	content, err := agent.dependencies(tasks)
+	if err != nil {
+		return "", fmt.Errorf("failed to inline dependencies of %s: %w", agent.ShortName, err)
+	}
+	if content.Empty() && !definition {
+		return "", nil
+	}
+
+	var b strings.Builder
+	b.WriteString(inlineHeading + "\n\n")
+	b.WriteString("The files referenced by the agent are inlined below, each between `" + bundle.FileStartDelimiter + "<path> ====` and `" + bundle.FileEndDelimiter + "`. ")
+	b.WriteString("Use them instead of reading the files from the project.\n\n")
+	if definition {
+		fmt.Fprintf(&b, "%s%s ====\n%s\n%s\n\n", bundle.FileStartDelimiter, slashRel(t.projectDir, agent.FilePath), agent.Definition, bundle.FileEndDelimiter)
+	}
+	content.WriteFiles(&b, func(path string) string {
+		return slashRel(t.projectDir, path)
+	})
+
+	return strings.TrimRight(b.String(), "\n"), nil
+}
+
+// generateContent renders the template of the descriptor with the agent identity, definition and inlined dependencies
+func (t *TemplateIntegration) generateContent(agentName, role string, yamlContent []byte, dependencies string) ([]byte, error) {
+	if strings.TrimSpace(t.descriptor.Template) == "" {
+		return nil, fmt.Errorf("%s generates no file per agent", t.descriptor.Title())
+	}
+
+	definition, err := processor.UnmarshalAgent(yamlContent)
+	if err != nil {
+		return nil, err
+	}
+	identity := definition.Agent.Identity
+
+	var content bytes.Buffer
+	if err := t.templates.content.Execute(&content, IDETemplateData{
+		Agent:        agentName,
+		Name:         identity.Name,
+		Role:         role,
+		Description:  identity.Description,
+		Goal:         identity.Goal,
+		Icon:         identity.Icon,
+		Definition:   string(yamlContent),
+		AgentPath:    path.Join(KrciAIDir, agentsDir, agentName+".yaml"),
+		Dependencies: dependencies,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to render template: %w", err)
+	}
+
+	return content.Bytes(), nil
+}
+
+// GenerateAgentFiles generates a file per agent task activating the agent and executing the task
+// when task files are enabled and the descriptor has a task template
+func (t *TemplateIntegration) GenerateAgentFiles(agent IDEAgent) ([]GeneratedFile, error) {
+	if !t.taskFiles || t.templates.task == nil {
+		return nil, nil
+	}
+
+	files := make([]GeneratedFile, 0, len(agent.Tasks))
+	for _, task := range agent.Tasks {
+		// Task files refer to the agent definition, which is inlined with the task
+		dependencies, err := t.inlineDependencies(agent, []Task{task}, true)
+		if err != nil {
+			return nil, err
+		}
+
+		data := IDETemplateData{
+			Agent:        agent.ShortName,
+			Name:         agent.Name,
+			Role:         agent.Role,
+			Description:  agent.Description,
+			Goal:         agent.Goal,
+			Icon:         agent.Icon,
+			Definition:   string(agent.Definition),
+			AgentPath:    slashRel(t.projectDir, agent.FilePath),
+			Task:         task.Name,
+			TaskTitle:    taskTitle(task),
+			TaskPath:     slashRel(t.projectDir, task.Path),
+			Dependencies: dependencies,
+		}
+
+		var name bytes.Buffer
+		if err := t.templates.taskFileName.Execute(&name, IDETemplateData{Agent: agent.ShortName, Task: task.Name}); err != nil {
+			return nil, fmt.Errorf("failed to render task file name: %w", err)
+		}
+		fileName := name.String() + t.descriptor.Extension
+		if strings.TrimSpace(name.String()) == "" || strings.ContainsAny(fileName, `/\`) {
+			return nil, fmt.Errorf("invalid task file name %q for task %s/%s", fileName, agent.ShortName, task.Name)
+		}
+
+		var content bytes.Buffer
+		if err := t.templates.task.Execute(&content, data); err != nil {
+			return nil, fmt.Errorf("failed to render task template for %s: %w", task.Name, err)
+		}
+
+		files = append(files, GeneratedFile{
+			Path:    filepath.Join(t.GetDirectoryPath(), fileName),
+			Content: content.Bytes(),
+		})
+	}
+
+	return files, nil
 }
 
-func (c *CursorIntegration) GetDirectoryPath() string {
-	return filepath.Join(c.projectDir, cursorRulesDir)
+// IDEAgent is an installed agent with its tasks and its definition rendered with the project values
+type IDEAgent struct {
+	Agent
+	Definition []byte
+
+	// project reads the dependencies of the agent, rendered with vals
+	project FileSystem
+	vals    values.Values
 }
 
-func (c *CursorIntegration) GetFileExtension() string {
-	return ".mdc"
+// dependencies collects the given tasks of the agent with their templates and data files,
+// rendered with the project values
+func (a IDEAgent) dependencies(tasks []Task) (*AgentContent, error) {
+	content := NewAgentContent()
+	if a.project == nil {
+		return content, nil
+	}
+
+	scope := a.Agent
+	scope.Tasks = tasks
+	err := content.Collect(scope, func(path string) ([]byte, error) {
+		data, err := a.project.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(values.Render(string(data), a.vals)), nil
+	})
+
+	return content, err
 }
 
-func (c *CursorIntegration) GenerateContent(agentName, role string, yamlContent []byte) string {
-	// Simple title case for agent name (capitalize first letter)
-	titleCaseAgentName := strings.ToUpper(agentName[:1]) + agentName[1:]
+// inlineIntegration is implemented by the template integrations, which embed the dependencies
+// of the agent in the agent file when inlining is enabled
+type inlineIntegration interface {
+	inlines() bool
+	generateAgentContent(agent IDEAgent) ([]byte, error)
+}
 
-	return fmt.Sprintf(`---
-description:
-globs: []
-alwaysApply: false
----
+// GeneratedFile is a file generated by an IDE integration besides the agent files
+type GeneratedFile struct {
+	Path    string
+	Content []byte
+}
 
-# %s Agent Activation
+// AgentFilesIntegration is implemented by IDE integrations that generate more files per agent
+// than the agent file, e.g. a file per agent task
+type AgentFilesIntegration interface {
+	GenerateAgentFiles(agent IDEAgent) ([]GeneratedFile, error)
+}
 
-CRITICAL: Carefully read the YAML agent definition below. Immediately activate the %s persona by following the activation instructions, and remain in this persona until you receive an explicit command to exit.
+// SectionIntegration is implemented by IDE integrations that maintain a managed section listing
+// the installed agents in a file shared with the user
+type SectionIntegration interface {
+	// SectionPath returns the file holding the section, empty when the section is disabled
+	SectionPath() string
+	GenerateSection(agents []IDEAgent) []byte
+}
 
-`+"```yaml\n%s```\n",
-		titleCaseAgentName,
-		role,
-		string(yamlContent))
+// AggregateIntegration is implemented by IDE integrations that generate a single file for all
+// installed agents instead of a file per agent, e.g. the custom modes of Roo Code. The file is
+// shared with the user, entries not generated for the agents are kept as they are.
+type AggregateIntegration interface {
+	AggregatePath() string
+	GenerateAggregate(current []byte, agents []IDEAgent) ([]byte, error)
 }
 
-// ClaudeIntegration implements IDEIntegration for Claude Code
+// ClaudeIntegration implements IDEIntegration for Claude Code slash commands,
+// optionally with a subagent per agent that Claude Code can delegate to
 type ClaudeIntegration struct {
-	projectDir string
+	*TemplateIntegration
+	subagents bool
+}
+
+// claudeSubagent is the frontmatter of a Claude Code subagent
+type claudeSubagent struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	Tools       string `yaml:"tools,omitempty"`
+	Model       string `yaml:"model,omitempty"`
+}
+
+// GenerateAgentFiles generates the task commands of the agent when task commands are enabled, and the
+// .claude/agents/<agent>.md subagent of the agent when subagents are enabled.
+// The subagent settings of the agent definition select its tools and model.
+func (c *ClaudeIntegration) GenerateAgentFiles(agent IDEAgent) ([]GeneratedFile, error) {
+	files, err := c.TemplateIntegration.GenerateAgentFiles(agent)
+	if err != nil || !c.subagents {
+		return files, err
+	}
+
+	definition, err := processor.UnmarshalAgent(agent.Definition)
+	if err != nil {
+		return nil, err
+	}
+	spec := definition.Agent
+
+	frontmatter, err := yaml.Marshal(claudeSubagent{
+		Name:        agent.ShortName,
+		Description: subagentDescription(spec.Identity.Description, spec.Identity.Goal),
+		Tools:       strings.Join(spec.Subagent.Tools, ", "),
+		Model:       spec.Subagent.Model,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode subagent frontmatter: %w", err)
+	}
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "---\n%s---\n\n", frontmatter)
+	fmt.Fprintf(&body, "You are %s, the %s agent of the KubeRocketAI framework defined in %s.\n\nGoal: %s\n",
+		spec.Identity.Name, spec.Identity.Role, slashRel(c.projectDir, agent.FilePath), spec.Identity.Goal)
+	writeMarkdownList(&body, "Activation", spec.ActivationPrompt)
+	writeMarkdownList(&body, "Principles", spec.Principles)
+	if customization := strings.TrimSpace(spec.Customization); customization != "" {
+		fmt.Fprintf(&body, "\n## Customization\n\n%s\n", customization)
+	}
+
+	if len(agent.Tasks) > 0 {
+		body.WriteString("\n## Tasks\n\nWhen asked for one of these tasks, read the task file and follow its instructions:\n\n")
+		for _, task := range agent.Tasks {
+			fmt.Fprintf(&body, "- [%s](%s)\n", taskTitle(task), slashRel(c.projectDir, task.Path))
+		}
+	}
+
+	return append(files, GeneratedFile{
+		Path:    filepath.Join(c.projectDir, claudeAgentsDir, agent.ShortName+mdExtension),
+		Content: []byte(body.String()),
+	}), nil
+}
+
+// slashRel returns the slash separated path of a file relative to base, e.g. the project root
+func slashRel(base, path string) string {
+	rel, err := filepath.Rel(base, path)
+	if err != nil {
+		return filepath.ToSlash(path)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// subagentDescription tells Claude Code when to delegate to an agent, from its description and goal
+func subagentDescription(description, goal string) string {
+	description = strings.TrimSpace(description)
+	if description != "" && !strings.HasSuffix(description, ".") {
+		description += "."
+	}
+
+	goal = strings.TrimSuffix(strings.TrimSpace(goal), ".")
+	if goal == "" {
+		return description
+	}
+
+	return strings.TrimSpace(description + " Goal: " + goal + ".")
+}
+
+// writeMarkdownList writes a markdown section listing items, nothing when there are none
+func writeMarkdownList(b *strings.Builder, heading string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+
+	fmt.Fprintf(b, "\n## %s\n\n", heading)
+	for _, item := range items {
+		fmt.Fprintf(b, "- %s\n", strings.TrimSpace(item))
+	}
 }
 
-func (c *ClaudeIntegration) GetDirectoryPath() string {
-	return filepath.Join(c.projectDir, claudeCommandsDir)
+// RooIntegration implements IDEIntegration for Roo Code custom modes. All agents are
+// generated as modes of the single project modes file .roomodes, next to the modes of the team.
+type RooIntegration struct {
+	*TemplateIntegration
 }
 
-func (c *ClaudeIntegration) GetFileExtension() string {
-	return mdExtension
+// rooModeGroups are the tool groups available to the agent modes
+var rooModeGroups = []string{"read", "edit", "browser", "command", "mcp"}
+
+// AggregatePath returns the project modes file
+func (r *RooIntegration) AggregatePath() string {
+	return filepath.Join(r.projectDir, roomodes.FileName)
 }
 
-func (c *ClaudeIntegration) GenerateContent(agentName, role string, yamlContent []byte) string {
-	return fmt.Sprintf(`# /%s Command
+// GenerateAggregate updates the project modes file with a krci-ai-<agent> mode per agent,
+// replacing the krci-ai modes of current and keeping the other modes
+func (r *RooIntegration) GenerateAggregate(current []byte, agents []IDEAgent) ([]byte, error) {
+	modes := make([]roomodes.Mode, 0, len(agents))
+	for _, agent := range agents {
+		instructions, err := r.generateAgentContent(agent)
+		if err != nil {
+			return nil, err
+		}
 
-CRITICAL: Carefully read the YAML agent definition below. Immediately activate the %s persona by following the activation instructions, and remain in this persona until you receive an explicit command to exit.
+		modes = append(modes, roomodes.Mode{
+			Slug:               roomodes.Prefix + agent.ShortName,
+			Name:               strings.TrimSpace(agent.Icon + " " + agent.Role),
+			RoleDefinition:     fmt.Sprintf("You are %s, the %s agent of the KubeRocketAI framework. %s", agent.Name, agent.Role, subagentDescription(agent.Description, agent.Goal)),
+			WhenToUse:          agent.Description,
+			CustomInstructions: string(instructions),
+			Groups:             rooModeGroups,
+		})
+	}
 
-`+"```yaml\n%s```\n",
-		agentName,
-		role,
-		string(yamlContent))
+	return roomodes.Upsert(current, modes)
 }
 
-// VSCodeIntegration implements IDEIntegration for VS Code
-type VSCodeIntegration struct {
-	targetDir string
+// CopilotIntegration implements IDEIntegration for GitHub Copilot prompt files,
+// optionally with the agents listed in the repository instructions
+type CopilotIntegration struct {
+	*TemplateIntegration
+	instructions bool
 }
 
-func (v *VSCodeIntegration) GetDirectoryPath() string {
-	return filepath.Join(v.targetDir, vscodeModesDir)
+// SectionPath returns the repository instructions file when the agents are listed there
+func (c *CopilotIntegration) SectionPath() string {
+	if !c.instructions {
+		return ""
+	}
+	return filepath.Join(c.projectDir, copilotInstructionsFile)
 }
 
-func (v *VSCodeIntegration) GetFileExtension() string {
-	return ".chatmode.md"
+// GenerateSection lists the agents and their prompts in the repository instructions
+func (c *CopilotIntegration) GenerateSection(agents []IDEAgent) []byte {
+	var body strings.Builder
+	body.WriteString("## KubeRocketAI Agents\n\n")
+	body.WriteString("The following agents are available as prompt files in `" + c.descriptor.Directory + "`. ")
+	if c.taskFiles {
+		body.WriteString("Run `/<agent>` in Copilot Chat to activate an agent, or `/<agent>-<task>` to run one of its tasks.\n")
+	} else {
+		body.WriteString("Run `/<agent>` in Copilot Chat to activate an agent.\n")
+	}
+
+	for _, agent := range agents {
+		fmt.Fprintf(&body, "\n- **%s** (`/%s`): %s", agent.Role, agent.ShortName, agent.Description)
+		if !c.taskFiles || len(agent.Tasks) == 0 {
+			continue
+		}
+
+		tasks := make([]string, 0, len(agent.Tasks))
+		for _, task := range agent.Tasks {
+			tasks = append(tasks, "`/"+agent.ShortName+"-"+task.Name+"`")
+		}
+		body.WriteString("\n  Tasks: " + strings.Join(tasks, ", "))
+	}
+	body.WriteString("\n")
+
+	return []byte(body.String())
+}
+
+// AgentsMDIntegration implements IDEIntegration for AGENTS.md. The installed agents are indexed
+// in a managed section of AGENTS.md, no file is generated per agent.
+type AgentsMDIntegration struct {
+	*TemplateIntegration
+}
+
+// SectionPath returns AGENTS.md at the project root
+func (a *AgentsMDIntegration) SectionPath() string {
+	return filepath.Join(a.projectDir, agentsMDFile)
 }
 
-func (v *VSCodeIntegration) GenerateContent(agentName, role string, yamlContent []byte) string {
-	return fmt.Sprintf(`---
-description: Activate %s role for specialized development assistance
-tools: %s
----
+// GenerateSection indexes the agents with their commands and tasks, and how to activate them
+func (a *AgentsMDIntegration) GenerateSection(agents []IDEAgent) []byte {
+	var body strings.Builder
+	body.WriteString("## KubeRocketAI Agents\n\n")
+	body.WriteString("The following agents are defined in `" + KrciAIDir + "/agents`. ")
+	body.WriteString("When asked to act as one of them, read its agent definition, follow its activation instructions, ")
+	body.WriteString("and remain in this persona until asked to exit. Agent commands run the listed tasks.\n")
+
+	for _, agent := range agents {
+		fmt.Fprintf(&body, "\n### %s (`%s`)\n\n", strings.TrimSpace(agent.Icon+" "+agent.Role), agent.ShortName)
+		if agent.Description != "" {
+			body.WriteString(agent.Description + "\n\n")
+		}
+
+		definition := slashRel(a.projectDir, agent.FilePath)
+		fmt.Fprintf(&body, "Activate: read [%s](%s) and follow its activation instructions.\n", definition, definition)
 
-# %s Agent Chat Mode
+		// Definitions were validated when the agent was loaded
+		if commands, err := processor.AgentCommands(agent.Definition); err == nil && len(commands) > 0 {
+			body.WriteString("\nCommands:\n\n")
+			for _, command := range commands {
+				fmt.Fprintf(&body, "- `%s`: %s\n", command.Name, command.Description)
+			}
+		}
 
-CRITICAL: Carefully read the YAML agent definition below. Immediately activate the %s persona by following the activation instructions, and remain in this persona until you receive an explicit command to exit.
+		if len(agent.Tasks) > 0 {
+			body.WriteString("\nTasks:\n\n")
+			for _, task := range agent.Tasks {
+				fmt.Fprintf(&body, "- [%s](%s)\n", taskTitle(task), slashRel(a.projectDir, task.Path))
+			}
+		}
+	}
 
-`+"```yaml\n%s```\n",
-		role,
-		GitHubToolsList,
-		role,
-		role,
-		string(yamlContent))
+	return []byte(body.String())
 }
 
-// WindsurfIntegration implements IDEIntegration for Windsurf IDE
-type WindsurfIntegration struct {
-	targetDir string
+// taskTitle returns the title of a task, derived from its name when the task file has no heading
+func taskTitle(task Task) string {
+	if task.Title != "" {
+		return task.Title
+	}
+	return strings.ReplaceAll(task.Name, "-", " ")
 }
 
-func (w *WindsurfIntegration) GetDirectoryPath() string {
-	return filepath.Join(w.targetDir, windsurfRulesDir)
+// IDEFileAction is what syncing an IDE integration did with one of its files
+type IDEFileAction string
+
+const (
+	IDEFileAdded     IDEFileAction = "added"
+	IDEFileUpdated   IDEFileAction = "updated"
+	IDEFileUnchanged IDEFileAction = "unchanged"
+	// IDEFileRemoved is a generated file whose agent or task is no longer installed
+	IDEFileRemoved IDEFileAction = "removed"
+)
+
+// IDEFileChange describes what happened to a single IDE file
+type IDEFileChange struct {
+	// Path is slash separated and relative to the project root
+	Path   string
+	Action IDEFileAction
 }
 
-func (w *WindsurfIntegration) GetFileExtension() string {
-	return mdExtension
+// IDESyncResult lists what syncing an IDE integration did with its files
+type IDESyncResult struct {
+	Changes []IDEFileChange
 }
 
-func (w *WindsurfIntegration) GenerateContent(agentName, role string, yamlContent []byte) string {
-	return fmt.Sprintf(`# %s Agent Rule
+// Count returns the number of files with the given action
+func (r *IDESyncResult) Count(action IDEFileAction) int {
+	count := 0
+	for _, change := range r.Changes {
+		if change.Action == action {
+			count++
+		}
+	}
 
-Activate the %s persona by following the agent definition below. This rule provides specialized development assistance for %s-related tasks.
+	return count
+}
 
-## Agent Definition
+// ideFileAction returns whether writing content to a file of the project adds, updates or leaves it unchanged
+func (i *Installer) ideFileAction(target string, content []byte) IDEFileAction {
+	current, err := i.readFile(target)
+	switch {
+	case err != nil:
+		return IDEFileAdded
+	case bytes.Equal(current, content):
+		return IDEFileUnchanged
+	default:
+		return IDEFileUpdated
+	}
+}
 
-`+"```yaml\n%s```\n",
-		role,
-		role,
-		strings.ToLower(role),
-		string(yamlContent))
+// recordIDEChange remembers what happened to a file of an IDE integration
+func (i *Installer) recordIDEChange(ide, path string, action IDEFileAction) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.ideChanges[ide] = append(i.ideChanges[ide], IDEFileChange{Path: i.projectPath(path), Action: action})
 }
 
 // installIDEIntegration is a generic method for installing IDE integrations
 func (i *Installer) installIDEIntegration(integration IDEIntegration, ideName string) error {
 	// Create IDE-specific directory
-	integrationPath := integration.GetDirectoryPath()
-	if err := i.createDirectory(integrationPath); err != nil {
-		return fmt.Errorf("failed to create %s directory: %w", ideName, err)
+	if integrationPath := integration.GetDirectoryPath(); integrationPath != "" {
+		if err := i.createDirectory(integrationPath); err != nil {
+			return fmt.Errorf("failed to create %s directory: %w", ideName, err)
+		}
 	}
 
-	// Get list of agent files
-	agentsPath := i.GetAgentsPath()
-	agentFiles, err := filepath.Glob(filepath.Join(agentsPath, "*.yaml"))
+	_, err := i.syncIDEIntegration(integration, ideName)
+	return err
+}
+
+// generateIntegration generates the IDE files of the given agent files and refreshes
+// the managed section of the integration, which lists all installed agents
+func (i *Installer) generateIntegration(integration IDEIntegration, ideName string, agentFiles []string) error {
+	// Load project values used for placeholder substitution
+	vals, err := values.Load(i.krciPath)
 	if err != nil {
-		return fmt.Errorf("failed to find agent files: %w", err)
+		return err
 	}
 
 	// Generate IDE-specific files for each agent
 	for _, agentFile := range agentFiles {
-		if err := i.generateIDEFile(agentFile, integration); err != nil {
+		if err := i.generateIDEFile(agentFile, integration, vals); err != nil {
 			agentName := strings.TrimSuffix(filepath.Base(agentFile), ".yaml")
 			return fmt.Errorf("failed to generate %s file for %s: %w", ideName, agentName, err)
 		}
 	}
 
+	if err := i.generateSection(integration, vals); err != nil {
+		return fmt.Errorf("failed to generate %s section: %w", ideName, err)
+	}
+
+	if err := i.generateAggregate(integration, vals); err != nil {
+		return fmt.Errorf("failed to generate %s file: %w", ideName, err)
+	}
+
 	return nil
 }
 
-// generateIDEFile creates an IDE-specific file from an agent YAML file
-func (i *Installer) generateIDEFile(agentFile string, integration IDEIntegration) error {
+// generateIDEFile creates the IDE-specific files from an agent YAML file,
+// substituting project values into the agent definition
+func (i *Installer) generateIDEFile(agentFile string, integration IDEIntegration, vals values.Values) error {
 	// Read agent YAML file
-	agentData, err := os.ReadFile(agentFile)
+	agentData, err := i.readFile(agentFile)
 	if err != nil {
 		return fmt.Errorf("failed to read agent file %s: %w", agentFile, err)
 	}
 
-	rawAgent, err := processor.UnmarshalAgentFile(agentFile)
+	files, err := renderIDEFiles(agentFile, agentData, integration, vals, i.projectFS())
+	if err != nil {
+		return err
+	}
+
+	agentName := strings.TrimSuffix(filepath.Base(agentFile), ".yaml")
+	for _, file := range files {
+		if _, err := i.backup.Protect(file.Path, file.Content); err != nil {
+			return err
+		}
+
+		// Write file
+		action := i.ideFileAction(file.Path, file.Content)
+		if err := i.writeFile(file.Path, file.Content); err != nil {
+			return err
+		}
+		i.recordIDEChange(integration.Name(), file.Path, action)
+
+		i.recordFile(file.Path, file.Content)
+		i.recordIDEFile(file.Path, integration.Name(), agentName)
+		i.recordInlinedFile(file.Path, file.Content)
+	}
+
+	return nil
+}
+
+// generateSection writes the managed section of an integration listing all installed agents.
+// Content of the file outside the section is left as it is.
+func (i *Installer) generateSection(integration IDEIntegration, vals values.Values) error {
+	sectionIntegration, ok := integration.(SectionIntegration)
+	if !ok || sectionIntegration.SectionPath() == "" {
+		return nil
+	}
+
+	agentFiles, err := i.glob(filepath.Join(i.GetAgentsPath(), "*.yaml"))
 	if err != nil {
+		return fmt.Errorf("failed to find agent files: %w", err)
+	}
+
+	target := sectionIntegration.SectionPath()
+	current, err := i.readFile(target)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %w", target, err)
+	}
+
+	content, block, agents, err := renderSection(sectionIntegration, current, agentFiles, vals, i.projectFS())
+	if err != nil {
+		return err
+	}
+
+	action := i.ideFileAction(target, content)
+	if err := i.writeFile(target, content); err != nil {
 		return err
 	}
+	i.recordIDEChange(integration.Name(), target, action)
+	i.recordSection(target, block, integration.Name(), agents)
+
+	return nil
+}
+
+// generateAggregate writes the file of an aggregate integration generated for all installed agents
+func (i *Installer) generateAggregate(integration IDEIntegration, vals values.Values) error {
+	aggregateIntegration, ok := integration.(AggregateIntegration)
+	if !ok {
+		return nil
+	}
+
+	agentFiles, err := i.glob(filepath.Join(i.GetAgentsPath(), "*.yaml"))
+	if err != nil {
+		return fmt.Errorf("failed to find agent files: %w", err)
+	}
+
+	target := aggregateIntegration.AggregatePath()
+	current, err := i.readFile(target)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %w", target, err)
+	}
+
+	content, agents, err := renderAggregate(aggregateIntegration, current, agentFiles, vals, i.projectFS())
+	if err != nil {
+		return err
+	}
+
+	// Entries of the user are kept, only changed generated entries need a backup
+	if _, generated := roomodes.Extract(current); generated {
+		if _, err := i.backup.Protect(target, content); err != nil {
+			return err
+		}
+	}
+	action := i.ideFileAction(target, content)
+	if err := i.writeFile(target, content); err != nil {
+		return err
+	}
+	i.recordIDEChange(integration.Name(), target, action)
+	i.recordAggregate(target, content, integration.Name(), agents)
+	i.recordInlinedFile(target, content)
+
+	return nil
+}
+
+// renderIDEFiles returns the IDE files generated from an agent definition: the agent file
+// followed by the files of integrations implementing AgentFilesIntegration.
+// Integrations without a directory generate no files per agent.
+func renderIDEFiles(agentFile string, agentData []byte, integration IDEIntegration, vals values.Values, project FileSystem) ([]GeneratedFile, error) {
+	if integration.GetDirectoryPath() == "" {
+		return nil, nil
+	}
+
+	outputPath, content, err := renderIDEFile(agentFile, agentData, integration, vals, project)
+	if err != nil {
+		return nil, err
+	}
+	files := []GeneratedFile{{Path: outputPath, Content: content}}
+
+	agentIntegration, ok := integration.(AgentFilesIntegration)
+	if !ok {
+		return files, nil
+	}
+
+	agent, err := loadIDEAgent(agentFile, vals, project)
+	if err != nil {
+		return nil, err
+	}
+
+	more, err := agentIntegration.GenerateAgentFiles(agent)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(files, more...), nil
+}
+
+// renderIDEFile returns the output path and content of the IDE file generated from an agent definition,
+// with the dependencies of the agent when the integration inlines them
+func renderIDEFile(agentFile string, agentData []byte, integration IDEIntegration, vals values.Values, project FileSystem) (string, []byte, error) {
+	rawAgent, err := processor.UnmarshalAgent(agentData)
+	if err != nil {
+		return "", nil, err
+	}
 
 	agent := MakeAgent(agentFile, rawAgent, []Task{})
 
-	// Generate output file path
-	outputPath := filepath.Join(integration.GetDirectoryPath(), agent.ShortName+integration.GetFileExtension())
+	fileName, err := integration.AgentFileName(agent.ShortName)
+	if err != nil {
+		return "", nil, err
+	}
+	outputPath := filepath.Join(integration.GetDirectoryPath(), fileName)
+
+	if inline, ok := integration.(inlineIntegration); ok && inline.inlines() {
+		ideAgent, err := loadIDEAgent(agentFile, vals, project)
+		if err != nil {
+			return "", nil, err
+		}
+
+		content, err := inline.generateAgentContent(ideAgent)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to generate content for %s: %w", agent.ShortName, err)
+		}
+
+		return outputPath, content, nil
+	}
 
 	// Generate content using the integration-specific logic
-	content := integration.GenerateContent(agent.ShortName, agent.Role, agentData)
+	content, err := integration.GenerateContent(agent.ShortName, agent.Role, []byte(values.Render(string(agentData), vals)))
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to generate content for %s: %w", agent.ShortName, err)
+	}
+
+	return outputPath, content, nil
+}
+
+// renderSection returns current with the managed section of the integration updated for the given
+// agent files, the section itself as recorded in the lockfile, and the listed agent names
+func renderSection(integration SectionIntegration, current []byte, agentFiles []string, vals values.Values, project FileSystem) ([]byte, []byte, []string, error) {
+	agents, names, err := loadIDEAgents(agentFiles, vals, project)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	body := integration.GenerateSection(agents)
+
+	return section.Upsert(current, body), section.Block(body), names, nil
+}
+
+// renderAggregate returns current with the entries of an aggregate integration updated for the given agent files,
+// and the listed agent names
+func renderAggregate(integration AggregateIntegration, current []byte, agentFiles []string, vals values.Values, project FileSystem) ([]byte, []string, error) {
+	agents, names, err := loadIDEAgents(agentFiles, vals, project)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := integration.GenerateAggregate(current, agents)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return content, names, nil
+}
+
+// loadIDEAgents loads the installed agents of the given agent files sorted by file name, with their names
+func loadIDEAgents(agentFiles []string, vals values.Values, project FileSystem) ([]IDEAgent, []string, error) {
+	agents := make([]IDEAgent, 0, len(agentFiles))
+	names := make([]string, 0, len(agentFiles))
+	for _, agentFile := range slices.Sorted(slices.Values(agentFiles)) {
+		agent, err := loadIDEAgent(agentFile, vals, project)
+		if err != nil {
+			return nil, nil, err
+		}
+		agents = append(agents, agent)
+		names = append(names, agent.ShortName)
+	}
+
+	return agents, names, nil
+}
+
+// loadIDEAgent loads an installed agent with its tasks using the discovery of the project framework files
+func loadIDEAgent(agentFile string, vals values.Values, project FileSystem) (IDEAgent, error) {
+	discovery := &Discovery{fs: project, frameworkDir: filepath.Dir(filepath.Dir(agentFile))}
+
+	agent, err := discovery.LoadAgent(context.Background(), agentFile)
+	if err != nil {
+		return IDEAgent{}, err
+	}
+
+	data, err := project.ReadFile(agentFile)
+	if err != nil {
+		return IDEAgent{}, fmt.Errorf("failed to read agent file %s: %w", agentFile, err)
+	}
 
-	// Write file
-	if err := os.WriteFile(outputPath, []byte(content), 0644); err != nil {
-		return fmt.Errorf("failed to write file %s: %w", outputPath, err)
+	return IDEAgent{Agent: agent, Definition: []byte(values.Render(string(data), vals)), project: project, vals: vals}, nil
+}
+
+// projectFileSystem reads the files of the project through read, e.g. as staged during an installation
+type projectFileSystem struct {
+	OSFileSystem
+	read func(name string) ([]byte, error)
+}
+
+func (p projectFileSystem) ReadFile(name string) ([]byte, error) {
+	return p.read(name)
+}
+
+// projectFS returns the project files as the installer sees them
+func (i *Installer) projectFS() FileSystem {
+	return projectFileSystem{read: i.readFile}
+}
+
+// GenerateIDEFiles generates the files of an IDE integration for the given installed agents only
+func (i *Installer) GenerateIDEFiles(ide string, agentNames []string) error {
+	integration, err := i.integrationFor(ide)
+	if err != nil {
+		return err
+	}
+
+	agentFiles := make([]string, 0, len(agentNames))
+	for _, name := range agentNames {
+		agentFiles = append(agentFiles, filepath.Join(i.GetAgentsPath(), name+".yaml"))
+	}
+
+	return i.generateIntegration(integration, ide, agentFiles)
+}
+
+// SyncIDESections refreshes the managed sections and aggregate files listing the installed agents
+// of the given IDE integrations, e.g. after agents were removed
+func (i *Installer) SyncIDESections(ides []string) error {
+	vals, err := values.Load(i.krciPath)
+	if err != nil {
+		return err
+	}
+
+	registry, err := i.IDERegistry()
+	if err != nil {
+		return err
+	}
+
+	for _, ide := range ides {
+		// Custom IDEs whose descriptor was removed have no sections or aggregate files
+		if _, ok := registry.Get(ide); !ok {
+			continue
+		}
+
+		integration, err := i.integrationFor(ide)
+		if err != nil {
+			return err
+		}
+		if err := i.generateSection(integration, vals); err != nil {
+			return fmt.Errorf("failed to generate %s section: %w", ide, err)
+		}
+		if err := i.generateAggregate(integration, vals); err != nil {
+			return fmt.Errorf("failed to generate %s file: %w", ide, err)
+		}
 	}
 
 	return nil
 }
 
-// InstallCursorIntegration creates .cursor/rules directory and generates .mdc files for agents
-func (i *Installer) InstallCursorIntegration() error {
-	integration := &CursorIntegration{projectDir: i.projectDir}
-	return i.installIDEIntegration(integration, "Cursor IDE")
+// IDERegistry returns the built-in IDE integrations and the custom ones of the project
+func (i *Installer) IDERegistry() (*IDERegistry, error) {
+	if i.ideRegistry != nil {
+		return i.ideRegistry, nil
+	}
+
+	registry, err := LoadIDERegistry(i.krciPath)
+	if err != nil {
+		return nil, err
+	}
+	i.ideRegistry = registry
+
+	return registry, nil
 }
 
-// InstallClaudeIntegration creates .claude/commands directory and generates .md files for agents
-func (i *Installer) InstallClaudeIntegration() error {
-	integration := &ClaudeIntegration{projectDir: i.projectDir}
-	return i.installIDEIntegration(integration, "Claude Code")
+// IDEDescriptor returns the descriptor of a registered IDE integration
+func (i *Installer) IDEDescriptor(ide string) (IDEDescriptor, error) {
+	registry, err := i.IDERegistry()
+	if err != nil {
+		return IDEDescriptor{}, err
+	}
+
+	descriptor, ok := registry.Get(ide)
+	if !ok {
+		return IDEDescriptor{}, fmt.Errorf("unsupported IDE %s (available: %s)", ide, strings.Join(registry.Names(), ", "))
+	}
+
+	return descriptor, nil
 }
 
-// InstallVSCodeIntegration creates .github/chatmodes directory and generates .chatmode.md files for agents
-func (i *Installer) InstallVSCodeIntegration() error {
-	integration := &VSCodeIntegration{targetDir: i.projectDir}
-	return i.installIDEIntegration(integration, "VS Code")
+// integrationFor returns the IDE integration for an IDE name
+func (i *Installer) integrationFor(ide string) (IDEIntegration, error) {
+	descriptor, err := i.IDEDescriptor(ide)
+	if err != nil {
+		return nil, err
+	}
+
+	integration, err := newTemplateIntegration(i.projectDir, descriptor)
+	if err != nil {
+		return nil, err
+	}
+	if i.taskCommands != nil {
+		integration.taskFiles = *i.taskCommands
+	} else {
+		integration.taskFiles = i.hasTaskFiles(integration)
+	}
+	if i.inline != nil {
+		integration.inline = *i.inline
+	} else {
+		integration.inline = i.hasInlinedFiles(integration)
+	}
+	if !descriptor.Builtin() {
+		return integration, nil
+	}
+
+	// Built-in integrations generating more than the agent files
+	switch ide {
+	case "claude":
+		return i.claudeIntegration(integration), nil
+	case "copilot":
+		return i.copilotIntegration(integration), nil
+	case "roo":
+		return &RooIntegration{TemplateIntegration: integration}, nil
+	case "agentsmd":
+		return &AgentsMDIntegration{TemplateIntegration: integration}, nil
+	default:
+		return integration, nil
+	}
 }
 
-// InstallWindsurfIntegration creates .windsurf/rules directory and generates .md files for agents
-func (i *Installer) InstallWindsurfIntegration() error {
-	integration := &WindsurfIntegration{targetDir: i.projectDir}
-	return i.installIDEIntegration(integration, "Windsurf IDE")
+// hasTaskFiles reports whether the installation has task files of an integration already: files of the
+// integration directory recorded for a single agent that are not the agent file
+func (i *Installer) hasTaskFiles(integration *TemplateIntegration) bool {
+	if integration.templates.task == nil {
+		return false
+	}
+
+	lock, err := i.loadLockfile()
+	if err != nil {
+		return false
+	}
+
+	return slices.ContainsFunc(lock.Files, func(file lockfile.File) bool {
+		if file.IDE != integration.Name() || len(file.Agents) != 1 || path.Dir(file.Path) != path.Clean(integration.descriptor.Directory) {
+			return false
+		}
+		agentFile, err := integration.AgentFileName(file.Agents[0])
+		return err == nil && path.Base(file.Path) != agentFile
+	})
 }
 
-// GetCursorRulesPath returns the path to the Cursor rules directory
-func (i *Installer) GetCursorRulesPath() string {
-	return filepath.Join(i.projectDir, cursorRulesDir)
+// hasInlinedFiles reports whether the installation has files of an integration with inlined dependencies already
+func (i *Installer) hasInlinedFiles(integration *TemplateIntegration) bool {
+	lock, err := i.loadLockfile()
+	if err != nil {
+		return false
+	}
+
+	return slices.ContainsFunc(lock.Files, func(file lockfile.File) bool {
+		if file.IDE != integration.Name() || file.Section {
+			return false
+		}
+		data, err := i.readFile(filepath.Join(i.projectDir, filepath.FromSlash(file.Path)))
+		return err == nil && bytes.Contains(data, []byte(inlineHeading))
+	})
 }
 
-// GetClaudeCommandsPath returns the path to the Claude commands directory
-func (i *Installer) GetClaudeCommandsPath() string {
-	return filepath.Join(i.projectDir, claudeCommandsDir)
+// recordInlinedFile records an IDE file written with inlined dependencies
+func (i *Installer) recordInlinedFile(path string, content []byte) {
+	if !bytes.Contains(content, []byte(inlineHeading)) {
+		return
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.inlinedFiles[path] = content
 }
 
-// GetVSCodeChatmodesPath returns the path to the VS Code chatmodes directory
-func (i *Installer) GetVSCodeChatmodesPath() string {
-	return filepath.Join(i.projectDir, vscodeModesDir)
+// InlinedFiles returns the IDE files written with inlined dependencies, sorted by path
+func (i *Installer) InlinedFiles() []GeneratedFile {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	files := make([]GeneratedFile, 0, len(i.inlinedFiles))
+	for _, path := range slices.Sorted(maps.Keys(i.inlinedFiles)) {
+		files = append(files, GeneratedFile{Path: path, Content: i.inlinedFiles[path]})
+	}
+
+	return files
 }
 
-// GetWindsurfRulesPath returns the path to the Windsurf rules directory
-func (i *Installer) GetWindsurfRulesPath() string {
-	return filepath.Join(i.projectDir, windsurfRulesDir)
+// InstallIDE creates the directory of an IDE integration and generates its files for the installed agents
+func (i *Installer) InstallIDE(ide string) error {
+	integration, err := i.integrationFor(ide)
+	if err != nil {
+		return err
+	}
+
+	descriptor, err := i.IDEDescriptor(ide)
+	if err != nil {
+		return err
+	}
+
+	return i.installIDEIntegration(integration, descriptor.Title())
 }
 
-func (i *Installer) GetAgentsPath() string {
-	return GetAgentsPath(i.krciPath)
+// SyncIDE regenerates the files of an IDE integration from the installed agents and removes
+// its generated files whose agent or task is no longer installed
+func (i *Installer) SyncIDE(ide string) (*IDESyncResult, error) {
+	integration, err := i.integrationFor(ide)
+	if err != nil {
+		return nil, err
+	}
+
+	descriptor, err := i.IDEDescriptor(ide)
+	if err != nil {
+		return nil, err
+	}
+
+	return i.syncIDEIntegration(integration, descriptor.Title())
 }
 
-func (i *Installer) GetTasksPath() string {
-	return GetTasksPath(i.krciPath)
+// HasIDE checks whether an IDE integration is installed: recorded in the lockfile, or for installations
+// without a lockfile, its directory or managed section exists
+func (i *Installer) HasIDE(ide string) bool {
+	lock, err := i.loadLockfile()
+	if err == nil {
+		return slices.Contains(lock.IDEs, ide)
+	}
+	if !errors.Is(err, lockfile.ErrNotFound) {
+		return false
+	}
+
+	integration, err := i.integrationFor(ide)
+	if err != nil {
+		return false
+	}
+
+	if dir := integration.GetDirectoryPath(); dir != "" {
+		return i.exists(dir)
+	}
+
+	// Aggregate files may be maintained by hand, e.g. .roomodes with the custom modes of the team
+	if sectionIntegration, ok := integration.(SectionIntegration); ok && sectionIntegration.SectionPath() != "" {
+		data, err := i.readFile(sectionIntegration.SectionPath())
+		if err != nil {
+			return false
+		}
+		_, found := section.Extract(data)
+		return found
+	}
+
+	return false
 }
 
-func (i *Installer) GetTemplatesPath() string {
-	return GetTemplatesPath(i.krciPath)
+// IDEPath returns where an IDE integration writes: its directory, or the file generated
+// for all agents or holding its managed section
+func (i *Installer) IDEPath(ide string) string {
+	integration, err := i.integrationFor(ide)
+	if err != nil {
+		return ""
+	}
+
+	if dir := integration.GetDirectoryPath(); dir != "" {
+		return dir
+	}
+	if aggregateIntegration, ok := integration.(AggregateIntegration); ok {
+		return aggregateIntegration.AggregatePath()
+	}
+	if sectionIntegration, ok := integration.(SectionIntegration); ok {
+		return sectionIntegration.SectionPath()
+	}
+
+	return ""
 }
 
-func (i *Installer) GetDataPath() string {
-	return GetDataPath(i.krciPath)
+// InstalledIDEs returns the registered IDE integrations installed in the project
+func (i *Installer) InstalledIDEs() []string {
+	registry, err := i.IDERegistry()
+	if err != nil {
+		return nil
+	}
+
+	var ides []string
+	for _, ide := range registry.Names() {
+		if i.HasIDE(ide) {
+			ides = append(ides, ide)
+		}
+	}
+
+	return ides
 }
 
-// HasCursorIntegration checks if Cursor IDE integration directory exists
-func (i *Installer) HasCursorIntegration() bool {
-	cursorPath := i.GetCursorRulesPath()
-	info, err := os.Stat(cursorPath)
-	return err == nil && info.IsDir()
+// claudeIntegration returns the Claude Code integration, generating subagents as set with
+// WithClaudeSubagents, or when the installation has subagents already
+func (i *Installer) claudeIntegration(integration *TemplateIntegration) *ClaudeIntegration {
+	var subagents bool
+	if i.claudeSubagents != nil {
+		subagents = *i.claudeSubagents
+	} else if lock, err := i.loadLockfile(); err == nil {
+		prefix := claudeAgentsDir + "/"
+		subagents = slices.ContainsFunc(lock.Files, func(file lockfile.File) bool {
+			return file.IDE == "claude" && strings.HasPrefix(file.Path, prefix)
+		})
+	}
+
+	return &ClaudeIntegration{TemplateIntegration: integration, subagents: subagents}
 }
 
-// HasClaudeIntegration checks if Claude Code integration directory exists
-func (i *Installer) HasClaudeIntegration() bool {
-	claudePath := i.GetClaudeCommandsPath()
-	info, err := os.Stat(claudePath)
-	return err == nil && info.IsDir()
+// copilotIntegration returns the GitHub Copilot integration, listing the agents in the repository
+// instructions when requested with WithCopilotInstructions or when they are listed there already
+func (i *Installer) copilotIntegration(integration *TemplateIntegration) *CopilotIntegration {
+	instructions := i.copilotInstructions
+	if !instructions {
+		if data, err := i.readFile(i.GetCopilotInstructionsPath()); err == nil {
+			_, instructions = section.Extract(data)
+		}
+	}
+
+	return &CopilotIntegration{TemplateIntegration: integration, instructions: instructions}
 }
 
-// HasVSCodeIntegration checks if VS Code integration directory exists
-func (i *Installer) HasVSCodeIntegration() bool {
-	vscodePath := i.GetVSCodeChatmodesPath()
-	info, err := os.Stat(vscodePath)
-	return err == nil && info.IsDir()
+// GetClaudeAgentsPath returns the path to the Claude Code subagents directory
+func (i *Installer) GetClaudeAgentsPath() string {
+	return filepath.Join(i.projectDir, claudeAgentsDir)
 }
 
-// HasWindsurfIntegration checks if Windsurf IDE integration directory exists
-func (i *Installer) HasWindsurfIntegration() bool {
-	windsurfPath := i.GetWindsurfRulesPath()
-	info, err := os.Stat(windsurfPath)
-	return err == nil && info.IsDir()
+// GetCopilotInstructionsPath returns the path to the GitHub Copilot repository instructions
+func (i *Installer) GetCopilotInstructionsPath() string {
+	return filepath.Join(i.projectDir, copilotInstructionsFile)
 }
 
-// SyncCursorIntegration syncs Cursor IDE integration from installed agents
-func (i *Installer) SyncCursorIntegration() error {
-	integration := &CursorIntegration{projectDir: i.projectDir}
-	return i.syncIDEIntegration(integration, "Cursor IDE")
+func (i *Installer) GetAgentsPath() string {
+	return GetAgentsPath(i.krciPath)
 }
 
-// SyncClaudeIntegration syncs Claude Code integration from installed agents
-func (i *Installer) SyncClaudeIntegration() error {
-	integration := &ClaudeIntegration{projectDir: i.projectDir}
-	return i.syncIDEIntegration(integration, "Claude Code")
+func (i *Installer) GetTasksPath() string {
+	return GetTasksPath(i.krciPath)
 }
 
-// SyncVSCodeIntegration syncs VS Code integration from installed agents
-func (i *Installer) SyncVSCodeIntegration() error {
-	integration := &VSCodeIntegration{targetDir: i.projectDir}
-	return i.syncIDEIntegration(integration, "VS Code")
+func (i *Installer) GetTemplatesPath() string {
+	return GetTemplatesPath(i.krciPath)
 }
 
-// SyncWindsurfIntegration syncs Windsurf IDE integration from installed agents
-func (i *Installer) SyncWindsurfIntegration() error {
-	integration := &WindsurfIntegration{targetDir: i.projectDir}
-	return i.syncIDEIntegration(integration, "Windsurf IDE")
+func (i *Installer) GetDataPath() string {
+	return GetDataPath(i.krciPath)
 }
 
-// syncIDEIntegration is a generic method for syncing IDE integrations from installed agents
-func (i *Installer) syncIDEIntegration(integration IDEIntegration, ideName string) error {
+// syncIDEIntegration is a generic method for syncing IDE integrations from installed agents.
+// Generated files left from agents or tasks that are no longer installed are removed.
+func (i *Installer) syncIDEIntegration(integration IDEIntegration, ideName string) (*IDESyncResult, error) {
 	// Get list of agent files from installed location (not embedded)
 	agentsPath := i.GetAgentsPath()
-	agentFiles, err := filepath.Glob(filepath.Join(agentsPath, "*.yaml"))
+	agentFiles, err := i.glob(filepath.Join(agentsPath, "*.yaml"))
 	if err != nil {
-		return fmt.Errorf("failed to find agent files: %w", err)
+		return nil, fmt.Errorf("failed to find agent files: %w", err)
 	}
 
-	// Generate IDE-specific files for each agent
-	for _, agentFile := range agentFiles {
-		if err := i.generateIDEFile(agentFile, integration); err != nil {
-			agentName := strings.TrimSuffix(filepath.Base(agentFile), ".yaml")
-			return fmt.Errorf("failed to generate %s file for %s: %w", ideName, agentName, err)
+	i.mu.Lock()
+	delete(i.ideChanges, integration.Name())
+	i.mu.Unlock()
+
+	if err := i.generateIntegration(integration, ideName, agentFiles); err != nil {
+		return nil, err
+	}
+
+	if err := i.pruneIDEFiles(integration.Name()); err != nil {
+		return nil, fmt.Errorf("failed to remove stale %s files: %w", ideName, err)
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return &IDESyncResult{Changes: slices.Clone(i.ideChanges[integration.Name()])}, nil
+}
+
+// pruneIDEFiles removes the files of an IDE integration recorded in the lockfile that were not generated
+// again, e.g. the files of removed or renamed agents. Files not recorded in the lockfile, like files of
+// the user next to the generated ones, are never touched. Local changes are backed up.
+func (i *Installer) pruneIDEFiles(ide string) error {
+	lock, err := i.loadLockfile()
+	if errors.Is(err, lockfile.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	i.mu.Lock()
+	generated := make(map[string]bool)
+	for _, change := range i.ideChanges[ide] {
+		generated[change.Path] = true
+	}
+	i.mu.Unlock()
+
+	for _, file := range lock.Files {
+		if file.IDE != ide || file.Section || generated[file.Path] {
+			continue
+		}
+
+		target := filepath.Join(i.projectDir, filepath.FromSlash(file.Path))
+		if !i.exists(target) {
+			continue
+		}
+		if _, err := i.backup.Protect(target, nil); err != nil {
+			return err
+		}
+		if err := i.removeFile(target); err != nil {
+			return err
 		}
+		i.recordIDEChange(ide, target, IDEFileRemoved)
 	}
 
 	return nil
@@ -0,0 +1,611 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package assets
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/KubeRocketCI/kuberocketai/internal/lockfile"
+	"github.com/KubeRocketCI/kuberocketai/internal/roomodes"
+	"github.com/KubeRocketCI/kuberocketai/internal/section"
+)
+
+func TestCopilotIntegration(t *testing.T) {
+	sourceDir, projectDir := prepareInstall(t)
+	instructionsPath := filepath.Join(projectDir, ".github", "copilot-instructions.md")
+	writeTree(t, projectDir, map[string]string{".github/copilot-instructions.md": "# Team rules\n"})
+
+	installer := NewInstallerFromSource(projectDir, OSFileSystem{}, sourceDir, NewDiscovery(sourceDir)).WithCopilotInstructions().WithTaskCommands(true)
+	require.NoError(t, installer.Begin())
+	require.NoError(t, installer.Install())
+	require.NoError(t, installer.InstallIDE("copilot"))
+	require.NoError(t, installer.UpdateLockfile([]string{"copilot"}))
+	require.NoError(t, installer.Commit())
+
+	promptsPath := installer.IDEPath("copilot")
+	for _, name := range []string{"tester.prompt.md", "tester-plan-tests.prompt.md", "tester-report-results.prompt.md"} {
+		assert.FileExists(t, filepath.Join(promptsPath, name))
+	}
+
+	data, err := os.ReadFile(filepath.Join(promptsPath, "tester-plan-tests.prompt.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "description: Plan tests\n")
+	assert.Contains(t, string(data), "[Plan tests](../../.krci-ai/tasks/tester/plan-tests.md)")
+
+	data, err = os.ReadFile(instructionsPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "# Team rules\n\n<!-- BEGIN krci-ai")
+	assert.Contains(t, string(data), "`/tester-plan-tests`, `/tester-report-results`")
+
+	lock, err := lockfile.Load(installer.GetFrameworkPath())
+	require.NoError(t, err)
+	file, ok := lock.GetFile(".github/copilot-instructions.md")
+	require.True(t, ok)
+	assert.True(t, file.Section)
+	assert.Equal(t, []string{"tester"}, file.Agents)
+
+	// User changes outside the section are not local changes of the installation
+	require.NoError(t, os.WriteFile(instructionsPath, append(data, "More rules\n"...), 0644))
+	modified, err := lock.Modified(projectDir)
+	require.NoError(t, err)
+	assert.Empty(t, modified)
+
+	// The section is kept up to date without WithCopilotInstructions once it exists
+	synced := NewInstallerFromSource(projectDir, OSFileSystem{}, sourceDir, NewDiscovery(sourceDir))
+	plan, err := synced.PlanInstall(PlanOptions{SkipFramework: true, IDEs: []string{"copilot"}})
+	require.NoError(t, err)
+	assert.Len(t, plan.Files, 4)
+	assert.Equal(t, len(plan.Files), plan.Count(PlanUnchanged))
+
+	_, err = synced.SyncIDE("copilot")
+	require.NoError(t, err)
+	data, err = os.ReadFile(instructionsPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "<!-- END krci-ai -->\nMore rules\n")
+}
+
+func TestCopilotTaskPromptFrontmatter(t *testing.T) {
+	sourceDir, projectDir := prepareInstall(t)
+	writeTree(t, sourceDir, map[string]string{
+		"tasks/tester/plan-tests.md": "---\ndependencies:\n  templates:\n    - tester/plan-template.md\n---\n# Plan tests: \"smoke\" #1\n",
+	})
+
+	installer := NewInstallerFromSource(projectDir, OSFileSystem{}, sourceDir, NewDiscovery(sourceDir)).WithTaskCommands(true)
+	require.NoError(t, installer.Install())
+	require.NoError(t, installer.InstallIDE("copilot"))
+
+	data, err := os.ReadFile(filepath.Join(installer.IDEPath("copilot"), "tester-plan-tests.prompt.md"))
+	require.NoError(t, err)
+	frontmatter, _, ok := strings.Cut(strings.TrimPrefix(string(data), "---\n"), "---\n")
+	require.True(t, ok)
+
+	var prompt struct {
+		Mode        string   `yaml:"mode"`
+		Description string   `yaml:"description"`
+		Tools       []string `yaml:"tools"`
+	}
+	require.NoError(t, yaml.Unmarshal([]byte(frontmatter), &prompt))
+	assert.Equal(t, "agent", prompt.Mode)
+	assert.Equal(t, `Plan tests: "smoke" #1`, prompt.Description)
+	assert.NotEmpty(t, prompt.Tools)
+}
+
+func TestCopilotTaskPrompts(t *testing.T) {
+	sourceDir, projectDir := prepareInstall(t)
+	writeTree(t, projectDir, map[string]string{".github/copilot-instructions.md": "# Team rules\n"})
+	instructionsPath := filepath.Join(projectDir, ".github", "copilot-instructions.md")
+
+	installer := NewInstallerFromSource(projectDir, OSFileSystem{}, sourceDir, NewDiscovery(sourceDir)).WithCopilotInstructions()
+	require.NoError(t, installer.Install())
+	require.NoError(t, installer.InstallIDE("copilot"))
+	require.NoError(t, installer.UpdateLockfile([]string{"copilot"}))
+
+	// Task prompts are optional
+	promptsPath := installer.IDEPath("copilot")
+	assert.FileExists(t, filepath.Join(promptsPath, "tester.prompt.md"))
+	assert.NoFileExists(t, filepath.Join(promptsPath, "tester-plan-tests.prompt.md"))
+	data, err := os.ReadFile(instructionsPath)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "/tester-plan-tests")
+
+	enabled := NewInstallerFromSource(projectDir, OSFileSystem{}, sourceDir, NewDiscovery(sourceDir)).WithTaskCommands(true)
+	_, err = enabled.SyncIDE("copilot")
+	require.NoError(t, err)
+	require.NoError(t, enabled.UpdateLockfile([]string{"copilot"}))
+
+	data, err = os.ReadFile(filepath.Join(promptsPath, "tester-plan-tests.prompt.md"))
+	require.NoError(t, err)
+	assert.Equal(t, `---
+mode: agent
+description: Plan tests
+tools: `+GitHubToolsList+`
+---
+
+# /tester-plan-tests Prompt
+
+Activate the Tester persona defined in [tester.prompt.md](tester.prompt.md) and execute the task [Plan tests](../../.krci-ai/tasks/tester/plan-tests.md) by following its instructions.
+`, string(data))
+	data, err = os.ReadFile(instructionsPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "`/tester-plan-tests`, `/tester-report-results`")
+
+	// Turning task commands off removes the task prompts and keeps the agent prompt
+	disabled := NewInstallerFromSource(projectDir, OSFileSystem{}, sourceDir, NewDiscovery(sourceDir)).WithTaskCommands(false)
+	result, err := disabled.SyncIDE("copilot")
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Count(IDEFileRemoved))
+	require.NoError(t, disabled.UpdateLockfile([]string{"copilot"}))
+
+	assert.FileExists(t, filepath.Join(promptsPath, "tester.prompt.md"))
+	assert.NoFileExists(t, filepath.Join(promptsPath, "tester-plan-tests.prompt.md"))
+	assert.NoFileExists(t, filepath.Join(promptsPath, "tester-report-results.prompt.md"))
+	data, err = os.ReadFile(instructionsPath)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "/tester-plan-tests")
+
+	lock, err := lockfile.Load(installer.GetFrameworkPath())
+	require.NoError(t, err)
+	_, ok := lock.GetFile(".github/prompts/tester-plan-tests.prompt.md")
+	assert.False(t, ok)
+}
+
+func TestClaudeSubagents(t *testing.T) {
+	sourceDir, projectDir := prepareInstall(t)
+	writeTree(t, sourceDir, map[string]string{
+		"agents/tester.yaml": testerAgent + `
+  activation_prompt:
+    - Greet the user
+  principles:
+    - "Test: everything"
+  customization: ""
+  subagent:
+    tools: [Read, Grep, Bash]
+    model: sonnet
+`,
+	})
+
+	installer := NewInstallerFromSource(projectDir, OSFileSystem{}, sourceDir, NewDiscovery(sourceDir)).WithClaudeSubagents(true)
+	require.NoError(t, installer.Install())
+	require.NoError(t, installer.InstallIDE("claude"))
+	require.NoError(t, installer.UpdateLockfile([]string{"claude"}))
+
+	assert.FileExists(t, filepath.Join(installer.IDEPath("claude"), "tester.md"))
+	data, err := os.ReadFile(filepath.Join(installer.GetClaudeAgentsPath(), "tester.md"))
+	require.NoError(t, err)
+
+	assert.Equal(t, `---
+name: tester
+description: 'Agent for installation tests. Goal: Test installations.'
+tools: Read, Grep, Bash
+model: sonnet
+---
+
+You are Tester, the Tester agent of the KubeRocketAI framework defined in .krci-ai/agents/tester.yaml.
+
+Goal: Test installations
+
+## Activation
+
+- Greet the user
+
+## Principles
+
+- Test: everything
+
+## Tasks
+
+When asked for one of these tasks, read the task file and follow its instructions:
+
+- [Plan tests](.krci-ai/tasks/tester/plan-tests.md)
+- [Report results](.krci-ai/tasks/tester/report-results.md)
+`, string(data))
+
+	// Installations with subagents keep them when syncing
+	synced := NewInstallerFromSource(projectDir, OSFileSystem{}, sourceDir, NewDiscovery(sourceDir))
+	plan, err := synced.PlanInstall(PlanOptions{SkipFramework: true, IDEs: []string{"claude"}})
+	require.NoError(t, err)
+	assert.Len(t, plan.Files, 2)
+	assert.Equal(t, len(plan.Files), plan.Count(PlanUnchanged))
+
+	// Turning subagents off removes them and keeps the slash commands
+	disabled := NewInstallerFromSource(projectDir, OSFileSystem{}, sourceDir, NewDiscovery(sourceDir)).WithClaudeSubagents(false)
+	result, err := disabled.SyncIDE("claude")
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Count(IDEFileRemoved))
+	require.NoError(t, disabled.UpdateLockfile([]string{"claude"}))
+
+	assert.NoFileExists(t, filepath.Join(installer.GetClaudeAgentsPath(), "tester.md"))
+	assert.FileExists(t, filepath.Join(installer.IDEPath("claude"), "tester.md"))
+	lock, err := lockfile.Load(installer.GetFrameworkPath())
+	require.NoError(t, err)
+	_, ok := lock.GetFile(".claude/agents/tester.md")
+	assert.False(t, ok)
+
+	// and later syncs do not bring them back
+	plan, err = NewInstallerFromSource(projectDir, OSFileSystem{}, sourceDir, NewDiscovery(sourceDir)).
+		PlanInstall(PlanOptions{SkipFramework: true, IDEs: []string{"claude"}})
+	require.NoError(t, err)
+	assert.Len(t, plan.Files, 1)
+}
+
+func TestTaskCommands(t *testing.T) {
+	sourceDir, projectDir := prepareInstall(t)
+
+	installer := NewInstallerFromSource(projectDir, OSFileSystem{}, sourceDir, NewDiscovery(sourceDir))
+	require.NoError(t, installer.Install())
+	require.NoError(t, installer.InstallIDE("cursor"))
+	require.NoError(t, installer.UpdateLockfile([]string{"cursor"}))
+	// Task commands are optional
+	assert.NoFileExists(t, filepath.Join(installer.IDEPath("cursor"), "tester-plan-tests.mdc"))
+
+	installer = NewInstallerFromSource(projectDir, OSFileSystem{}, sourceDir, NewDiscovery(sourceDir)).WithTaskCommands(true)
+	require.NoError(t, installer.InstallIDE("claude"))
+	require.NoError(t, installer.UpdateLockfile([]string{"claude"}))
+
+	commandsPath := installer.IDEPath("claude")
+	for _, name := range []string{"tester.md", "tester-plan-tests.md", "tester-report-results.md"} {
+		assert.FileExists(t, filepath.Join(commandsPath, name))
+	}
+
+	data, err := os.ReadFile(filepath.Join(commandsPath, "tester-plan-tests.md"))
+	require.NoError(t, err)
+	assert.Equal(t, `---
+description: Plan tests as the Tester agent
+---
+
+# /tester-plan-tests Command
+
+CRITICAL: Read the agent definition .krci-ai/agents/tester.yaml and activate the Tester persona by following its activation instructions, without waiting for a command. Then immediately execute the task .krci-ai/tasks/tester/plan-tests.md by following its instructions, and remain in this persona until you receive an explicit command to exit.
+`, string(data))
+
+	lock, err := lockfile.Load(installer.GetFrameworkPath())
+	require.NoError(t, err)
+	file, ok := lock.GetFile(".claude/commands/krci-ai/tester-plan-tests.md")
+	require.True(t, ok)
+	assert.Equal(t, "claude", file.IDE)
+	assert.Equal(t, []string{"tester"}, file.Agents)
+
+	// Installations with task commands keep them when syncing, others stay without
+	synced := NewInstallerFromSource(projectDir, OSFileSystem{}, sourceDir, NewDiscovery(sourceDir))
+	plan, err := synced.PlanInstall(PlanOptions{SkipFramework: true, IDEs: []string{"claude", "cursor"}})
+	require.NoError(t, err)
+	assert.Len(t, plan.Files, 4)
+	assert.Equal(t, len(plan.Files), plan.Count(PlanUnchanged))
+
+	// Turning task commands off removes them and keeps the agent commands
+	disabled := NewInstallerFromSource(projectDir, OSFileSystem{}, sourceDir, NewDiscovery(sourceDir)).WithTaskCommands(false)
+	result, err := disabled.SyncIDE("claude")
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Count(IDEFileRemoved))
+	require.NoError(t, disabled.UpdateLockfile([]string{"claude"}))
+
+	assert.FileExists(t, filepath.Join(commandsPath, "tester.md"))
+	assert.NoFileExists(t, filepath.Join(commandsPath, "tester-plan-tests.md"))
+	assert.NoFileExists(t, filepath.Join(commandsPath, "tester-report-results.md"))
+
+	plan, err = NewInstallerFromSource(projectDir, OSFileSystem{}, sourceDir, NewDiscovery(sourceDir)).
+		PlanInstall(PlanOptions{SkipFramework: true, IDEs: []string{"claude"}})
+	require.NoError(t, err)
+	assert.Len(t, plan.Files, 1)
+	assert.Equal(t, 1, plan.Count(PlanUnchanged))
+}
+
+func TestSyncRemovesStaleFiles(t *testing.T) {
+	sourceDir, projectDir := prepareInstall(t)
+
+	installer := NewInstallerFromSource(projectDir, OSFileSystem{}, sourceDir, NewDiscovery(sourceDir))
+	require.NoError(t, installer.Install())
+	require.NoError(t, installer.InstallIDE("claude"))
+	require.NoError(t, installer.UpdateLockfile([]string{"claude"}))
+
+	// Rename the agent and keep a file of the user next to the generated ones
+	commandsPath := installer.IDEPath("claude")
+	agentsPath := installer.GetAgentsPath()
+	require.NoError(t, os.Rename(filepath.Join(agentsPath, "tester.yaml"), filepath.Join(agentsPath, "checker.yaml")))
+	writeTree(t, projectDir, map[string]string{".claude/commands/krci-ai/notes.md": "# Notes\n"})
+
+	synced := NewInstallerFromSource(projectDir, OSFileSystem{}, sourceDir, NewDiscovery(sourceDir))
+	plan, err := synced.PlanInstall(PlanOptions{SkipFramework: true, IDEs: []string{"claude"}})
+	require.NoError(t, err)
+	assert.Equal(t, 1, plan.Count(PlanCreate))
+	assert.Equal(t, 1, plan.Count(PlanRemove))
+	assert.Contains(t, plan.Files, PlannedFile{Path: ".claude/commands/krci-ai/tester.md", Component: "claude", Status: PlanRemove})
+
+	require.NoError(t, synced.Begin())
+	result, err := synced.SyncIDE("claude")
+	require.NoError(t, err)
+	require.NoError(t, synced.UpdateLockfile([]string{"claude"}))
+	require.NoError(t, synced.Commit())
+
+	assert.Equal(t, []IDEFileChange{
+		{Path: ".claude/commands/krci-ai/checker.md", Action: IDEFileAdded},
+		{Path: ".claude/commands/krci-ai/tester.md", Action: IDEFileRemoved},
+	}, result.Changes)
+	assert.FileExists(t, filepath.Join(commandsPath, "checker.md"))
+	assert.NoFileExists(t, filepath.Join(commandsPath, "tester.md"))
+	assert.FileExists(t, filepath.Join(commandsPath, "notes.md"))
+
+	lock, err := lockfile.Load(installer.GetFrameworkPath())
+	require.NoError(t, err)
+	_, ok := lock.GetFile(".claude/commands/krci-ai/tester.md")
+	assert.False(t, ok)
+
+	// Nothing is left to remove once in sync
+	result, err = synced.SyncIDE("claude")
+	require.NoError(t, err)
+	assert.Equal(t, []IDEFileChange{{Path: ".claude/commands/krci-ai/checker.md", Action: IDEFileUnchanged}}, result.Changes)
+}
+
+func TestInline(t *testing.T) {
+	sourceDir, projectDir := prepareInstall(t)
+
+	installer := NewInstallerFromSource(projectDir, OSFileSystem{}, sourceDir, NewDiscovery(sourceDir)).WithInline(true).WithTaskCommands(true)
+	require.NoError(t, installer.Install())
+	require.NoError(t, installer.InstallIDE("windsurf"))
+	require.NoError(t, installer.InstallIDE("claude"))
+	require.NoError(t, installer.UpdateLockfile([]string{"windsurf", "claude"}))
+
+	rulesPath := installer.IDEPath("windsurf")
+	data, err := os.ReadFile(filepath.Join(rulesPath, "tester.md"))
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(string(data), "```\n\n"+inlineHeading+`
+
+The files referenced by the agent are inlined below, each between `+"`==== FILE: <path> ====` and `==== END FILE ====`"+`. Use them instead of reading the files from the project.
+
+==== FILE: .krci-ai/tasks/tester/plan-tests.md ====
+---
+dependencies:
+  templates:
+    - tester/plan-template.md
+---
+# Plan tests
+
+==== END FILE ====
+
+==== FILE: .krci-ai/tasks/tester/report-results.md ====
+---
+dependencies:
+  data:
+    - tester/report-guide.md
+---
+# Report results
+
+==== END FILE ====
+
+==== FILE: .krci-ai/templates/tester/plan-template.md ====
+# Plan
+
+==== END FILE ====
+
+==== FILE: .krci-ai/data/tester/report-guide.md ====
+# Guide
+
+==== END FILE ====
+`), string(data))
+
+	// Task files inline the agent definition and their own task only
+	data, err = os.ReadFile(filepath.Join(installer.IDEPath("claude"), "tester-plan-tests.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "==== FILE: .krci-ai/agents/tester.yaml ====\n# new\n"+testerAgent)
+	assert.Contains(t, string(data), "==== FILE: .krci-ai/templates/tester/plan-template.md ====")
+	assert.NotContains(t, string(data), "==== FILE: .krci-ai/tasks/tester/report-results.md")
+
+	var inlined []string
+	for _, file := range installer.InlinedFiles() {
+		inlined = append(inlined, slashRel(projectDir, file.Path))
+	}
+	assert.Equal(t, []string{
+		".claude/commands/krci-ai/tester-plan-tests.md",
+		".claude/commands/krci-ai/tester-report-results.md",
+		".claude/commands/krci-ai/tester.md",
+		".windsurf/rules/tester.md",
+	}, inlined)
+
+	// Installations with inlined files keep inlining when syncing
+	synced := NewInstallerFromSource(projectDir, OSFileSystem{}, sourceDir, NewDiscovery(sourceDir))
+	plan, err := synced.PlanInstall(PlanOptions{SkipFramework: true, IDEs: []string{"windsurf", "claude"}})
+	require.NoError(t, err)
+	assert.Len(t, plan.Files, 4)
+	assert.Equal(t, len(plan.Files), plan.Count(PlanUnchanged))
+
+	// Turning inlining off refers to the framework files again
+	disabled := NewInstallerFromSource(projectDir, OSFileSystem{}, sourceDir, NewDiscovery(sourceDir)).WithInline(false)
+	result, err := disabled.SyncIDE("windsurf")
+	require.NoError(t, err)
+	assert.Equal(t, []IDEFileChange{{Path: ".windsurf/rules/tester.md", Action: IDEFileUpdated}}, result.Changes)
+	require.NoError(t, disabled.UpdateLockfile([]string{"windsurf"}))
+	assert.Empty(t, disabled.InlinedFiles())
+
+	data, err = os.ReadFile(filepath.Join(rulesPath, "tester.md"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), inlineHeading)
+
+	plan, err = NewInstallerFromSource(projectDir, OSFileSystem{}, sourceDir, NewDiscovery(sourceDir)).
+		PlanInstall(PlanOptions{SkipFramework: true, IDEs: []string{"windsurf"}})
+	require.NoError(t, err)
+	assert.Len(t, plan.Files, 1)
+	assert.Equal(t, 1, plan.Count(PlanUnchanged))
+}
+
+// teamModes is a modes file of the user with a custom mode of the team
+const teamModes = `# Modes of the team
+customModes:
+  - slug: team-reviewer
+    name: Reviewer
+    roleDefinition: You review pull requests
+    groups: [read]
+    source: project
+`
+
+func TestRooIntegration(t *testing.T) {
+	sourceDir, projectDir := prepareInstall(t)
+	writeTree(t, projectDir, map[string]string{".roomodes": teamModes})
+
+	installer := NewInstallerFromSource(projectDir, OSFileSystem{}, sourceDir, NewDiscovery(sourceDir))
+	// A modes file of the user alone is no integration
+	assert.False(t, installer.HasIDE("roo"))
+
+	require.NoError(t, installer.Begin())
+	require.NoError(t, installer.Install())
+	require.NoError(t, installer.InstallIDE("roo"))
+	require.NoError(t, installer.UpdateLockfile([]string{"roo"}))
+	require.NoError(t, installer.Commit())
+	assert.True(t, installer.HasIDE("roo"))
+
+	data, err := os.ReadFile(installer.IDEPath("roo"))
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(data), teamModes), "the modes of the team are kept as they are")
+	var modes struct {
+		CustomModes []roomodes.Mode `yaml:"customModes"`
+	}
+	require.NoError(t, yaml.Unmarshal(data, &modes))
+	require.Len(t, modes.CustomModes, 2)
+
+	mode := modes.CustomModes[1]
+	assert.Equal(t, "krci-ai-tester", mode.Slug)
+	assert.Equal(t, "🧪 Tester", mode.Name)
+	assert.Equal(t, "You are Tester, the Tester agent of the KubeRocketAI framework. Agent for installation tests. Goal: Test installations.", mode.RoleDefinition)
+	assert.Equal(t, "Agent for installation tests", mode.WhenToUse)
+	assert.Contains(t, mode.CustomInstructions, "```yaml\n# new\n"+testerAgent+"```\n")
+	assert.Equal(t, rooModeGroups, mode.Groups)
+
+	lock, err := lockfile.Load(installer.GetFrameworkPath())
+	require.NoError(t, err)
+	file, ok := lock.GetFile(".roomodes")
+	require.True(t, ok)
+	assert.Equal(t, "roo", file.IDE)
+	assert.Equal(t, []string{"tester"}, file.Agents)
+	assert.True(t, file.Modes)
+
+	// Changes to the modes of the team are not local changes of the installation
+	edited := strings.Replace(string(data), "You review pull requests", "You review every pull request", 1)
+	require.NoError(t, os.WriteFile(installer.IDEPath("roo"), []byte(edited), 0644))
+	modified, err := lock.Modified(projectDir)
+	require.NoError(t, err)
+	assert.Empty(t, modified)
+
+	plan, err := installer.PlanInstall(PlanOptions{SkipFramework: true, IDEs: []string{"roo"}})
+	require.NoError(t, err)
+	require.Len(t, plan.Files, 1)
+	assert.Equal(t, PlanUnchanged, plan.Files[0].Status)
+
+	// Syncing replaces the krci-ai modes in place
+	synced := NewInstallerFromSource(projectDir, OSFileSystem{}, sourceDir, NewDiscovery(sourceDir))
+	result, err := synced.SyncIDE("roo")
+	require.NoError(t, err)
+	assert.Equal(t, []IDEFileChange{{Path: ".roomodes", Action: IDEFileUnchanged}}, result.Changes)
+	data, err = os.ReadFile(installer.IDEPath("roo"))
+	require.NoError(t, err)
+	assert.Equal(t, edited, string(data))
+}
+
+func TestAgentsMDIntegration(t *testing.T) {
+	sourceDir, projectDir := prepareInstall(t)
+	agentsMDPath := filepath.Join(projectDir, "AGENTS.md")
+	writeTree(t, projectDir, map[string]string{"AGENTS.md": "# Contributing\n\nRun make test.\n"})
+
+	installer := NewInstallerFromSource(projectDir, OSFileSystem{}, sourceDir, NewDiscovery(sourceDir))
+	assert.False(t, installer.HasIDE("agentsmd"))
+
+	require.NoError(t, installer.Begin())
+	require.NoError(t, installer.Install())
+	require.NoError(t, installer.InstallIDE("agentsmd"))
+	require.NoError(t, installer.UpdateLockfile([]string{"agentsmd"}))
+	require.NoError(t, installer.Commit())
+	assert.True(t, installer.HasIDE("agentsmd"))
+
+	data, err := os.ReadFile(agentsMDPath)
+	require.NoError(t, err)
+	assert.Equal(t, "# Contributing\n\nRun make test.\n\n"+string(section.Block([]byte(`## KubeRocketAI Agents
+
+The following agents are defined in `+"`.krci-ai/agents`"+`. When asked to act as one of them, read its agent definition, follow its activation instructions, and remain in this persona until asked to exit. Agent commands run the listed tasks.
+
+### 🧪 Tester (`+"`tester`"+`)
+
+Agent for installation tests
+
+Activate: read [.krci-ai/agents/tester.yaml](.krci-ai/agents/tester.yaml) and follow its activation instructions.
+
+Commands:
+
+- `+"`help`"+`: Show available commands
+- `+"`plan`"+`: Plan tests by executing task plan-tests
+- `+"`report`"+`: Report results by executing task report-results
+
+Tasks:
+
+- [Plan tests](.krci-ai/tasks/tester/plan-tests.md)
+- [Report results](.krci-ai/tasks/tester/report-results.md)
+`))), string(data))
+
+	lock, err := lockfile.Load(installer.GetFrameworkPath())
+	require.NoError(t, err)
+	file, ok := lock.GetFile("AGENTS.md")
+	require.True(t, ok)
+	assert.True(t, file.Section)
+	assert.Equal(t, "agentsmd", file.IDE)
+
+	// User content around the section is kept on re-sync
+	require.NoError(t, os.WriteFile(agentsMDPath, append(data, "\n## Release\n"...), 0644))
+	synced := NewInstallerFromSource(projectDir, OSFileSystem{}, sourceDir, NewDiscovery(sourceDir))
+	plan, err := synced.PlanInstall(PlanOptions{SkipFramework: true, IDEs: []string{"agentsmd"}})
+	require.NoError(t, err)
+	require.Len(t, plan.Files, 1)
+	assert.Equal(t, PlanUnchanged, plan.Files[0].Status)
+
+	_, err = synced.SyncIDE("agentsmd")
+	require.NoError(t, err)
+	updated, err := os.ReadFile(agentsMDPath)
+	require.NoError(t, err)
+	assert.Equal(t, string(data)+"\n## Release\n", string(updated))
+}
+
+// TestHasIDE verifies that installed IDE integrations are taken from the lockfile, and detected
+// from their directories for installations without a lockfile only
+func TestHasIDE(t *testing.T) {
+	sourceDir, projectDir := prepareInstall(t)
+	// Prompts of the team, not generated by krci-ai
+	writeTree(t, projectDir, map[string]string{".github/prompts/release.prompt.md": "# Release\n"})
+
+	installer := NewInstallerFromSource(projectDir, OSFileSystem{}, sourceDir, NewDiscovery(sourceDir))
+	assert.True(t, installer.HasIDE("copilot"), "detected without a lockfile")
+
+	require.NoError(t, installer.Begin())
+	require.NoError(t, installer.Install())
+	require.NoError(t, installer.InstallIDE("claude"))
+	assert.True(t, installer.HasIDE("claude"), "staged files are seen")
+	require.NoError(t, installer.UpdateLockfile([]string{"claude"}))
+	require.NoError(t, installer.Commit())
+
+	assert.True(t, installer.HasIDE("claude"))
+	assert.False(t, installer.HasIDE("copilot"), "a directory shared with the user is no integration")
+	assert.Equal(t, []string{"claude"}, installer.InstalledIDEs())
+
+	// Syncing leaves integrations the project never opted into alone
+	synced := NewInstallerFromSource(projectDir, OSFileSystem{}, sourceDir, NewDiscovery(sourceDir))
+	for _, ide := range synced.InstalledIDEs() {
+		_, err := synced.SyncIDE(ide)
+		require.NoError(t, err)
+	}
+	assert.NoFileExists(t, filepath.Join(projectDir, ".github", "prompts", "tester.prompt.md"))
+}
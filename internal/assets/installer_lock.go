@@ -0,0 +1,217 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package assets
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/KubeRocketCI/kuberocketai/internal/lockfile"
+	"github.com/KubeRocketCI/kuberocketai/internal/roomodes"
+)
+
+// projectPath returns the slash separated path of a file relative to the project root
+func (i *Installer) projectPath(path string) string {
+	rel, err := filepath.Rel(i.projectDir, path)
+	if err != nil {
+		rel = path
+	}
+
+	return filepath.ToSlash(rel)
+}
+
+// recordFile remembers the content hash of a file written by the installer
+func (i *Installer) recordFile(path string, data []byte) {
+	rel := i.projectPath(path)
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.installedFiles[rel] = lockfile.Hash(data)
+}
+
+// recordAgent remembers an agent selected for installation and the framework files it needs.
+// Files are relative to the framework directory as in the files filter.
+func (i *Installer) recordAgent(name string, files map[string]struct{}) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.agentNames = append(i.agentNames, name)
+
+	for path := range files {
+		rel := i.projectPath(filepath.Join(i.krciPath, path))
+		i.fileAgents[rel] = append(i.fileAgents[rel], name)
+	}
+}
+
+// recordAgentTasks remembers the selected tasks of an agent installed with InstallTasks
+func (i *Installer) recordAgentTasks(name string, tasks []string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.agentTasks[name] = tasks
+}
+
+// recordIDEFile remembers the IDE integration and agent of a generated IDE file
+func (i *Installer) recordIDEFile(path, ide, agent string) {
+	rel := i.projectPath(path)
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.fileIDEs[rel] = ide
+	i.fileAgents[rel] = []string{agent}
+}
+
+// recordSection remembers the managed section written to a file shared with the user,
+// which lists the given agents
+func (i *Installer) recordSection(path string, block []byte, ide string, agents []string) {
+	rel := i.projectPath(path)
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.installedFiles[rel] = lockfile.Hash(block)
+	i.sectionFiles[rel] = true
+	i.fileIDEs[rel] = ide
+	i.fileAgents[rel] = agents
+}
+
+// recordAggregate remembers a file of an aggregate IDE integration generated for the given agents,
+// of which only the krci-ai modes are installed
+func (i *Installer) recordAggregate(path string, data []byte, ide string, agents []string) {
+	rel := i.projectPath(path)
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	modes, _ := roomodes.Extract(data)
+	i.installedFiles[rel] = lockfile.Hash(modes)
+	i.aggregateFiles[rel] = true
+	i.fileIDEs[rel] = ide
+	i.fileAgents[rel] = agents
+}
+
+// GetLockfilePath returns the path to the framework lockfile
+func (i *Installer) GetLockfilePath() string {
+	return lockfile.GetPath(i.krciPath)
+}
+
+// loadLockfile reads the lockfile of the project, as staged during an installation
+func (i *Installer) loadLockfile() (*lockfile.Lockfile, error) {
+	path := i.GetLockfilePath()
+	data, err := i.readFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", lockfile.ErrNotFound, path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lockfile %s: %w", path, err)
+	}
+
+	return lockfile.Parse(path, data)
+}
+
+// UpdateLockfile merges everything installed by this installer into the lockfile.
+// Entries of previous installs are kept unless their files no longer exist.
+func (i *Installer) UpdateLockfile(ides []string) error {
+	lock, err := i.loadLockfile()
+	switch {
+	case errors.Is(err, lockfile.ErrNotFound):
+		lock = lockfile.New()
+	case err != nil:
+		return err
+	default:
+		lock.CLI = lockfile.New().CLI
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	// Agents added to an installation from a pack keep the pack as its source
+	switch {
+	case i.sourceRef != nil:
+		lock.Source = i.sourceRef
+	case len(i.agentNames) > 0 && (i.complete || lock.Source == nil):
+		lock.Source = &lockfile.Source{Type: lockfile.SourceEmbedded}
+	}
+
+	if i.packRef != nil {
+		pack := *i.packRef
+		if i.sourceRef != nil {
+			pack.Source = *i.sourceRef
+		}
+		lock.SetPack(pack)
+	}
+
+	lock.AddAgents(i.agentNames...)
+	for _, name := range i.agentNames {
+		lock.SetAgentTasks(name, i.agentTasks[name])
+		lock.SetAgentSource(name, i.sourceRef)
+	}
+	lock.AddIDEs(ides...)
+	for path, sha := range i.installedFiles {
+		lock.SetFile(path, sha)
+		lock.AddFileOwners(path, i.fileIDEs[path], i.fileAgents[path]...)
+		if i.sectionFiles[path] {
+			lock.MarkSection(path)
+		}
+		if i.aggregateFiles[path] {
+			lock.MarkModes(path)
+		}
+		if i.sectionFiles[path] || i.aggregateFiles[path] {
+			lock.SetFileAgents(path, i.fileAgents[path])
+		}
+	}
+
+	var missing []string
+	for _, file := range lock.Files {
+		if !i.exists(filepath.Join(i.projectDir, filepath.FromSlash(file.Path))) {
+			missing = append(missing, file.Path)
+		}
+	}
+	for _, path := range missing {
+		lock.RemoveFile(path)
+	}
+
+	return i.SaveLockfile(lock)
+}
+
+// SaveLockfile writes the lockfile of the project, staged during an installation, and removes the
+// stored originals of files it no longer records
+func (i *Installer) SaveLockfile(lock *lockfile.Lockfile) error {
+	if err := i.stage(i.krciPath, func(frameworkDir string) error {
+		if err := os.MkdirAll(frameworkDir, DirectoryPermissions); err != nil {
+			return fmt.Errorf("failed to create framework directory: %w", err)
+		}
+		return lock.Save(frameworkDir)
+	}); err != nil {
+		return err
+	}
+
+	return i.pruneObjects(lock)
+}
+
+// pruneObjects removes the stored originals of files the lockfile no longer records
+func (i *Installer) pruneObjects(lock *lockfile.Lockfile) error {
+	objects, err := i.glob(lockfile.ObjectsPattern(i.krciPath))
+	if err != nil {
+		return fmt.Errorf("failed to find objects: %w", err)
+	}
+
+	for _, path := range lock.UnreferencedObjects(objects) {
+		if err := i.removeFile(path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
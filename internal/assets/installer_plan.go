@@ -0,0 +1,361 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package assets
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/KubeRocketCI/kuberocketai/internal/lockfile"
+	"github.com/KubeRocketCI/kuberocketai/internal/values"
+)
+
+// PlanStatus is what an installation would do with a file
+type PlanStatus string
+
+const (
+	PlanCreate    PlanStatus = "create"
+	PlanOverwrite PlanStatus = "overwrite"
+	PlanUnchanged PlanStatus = "unchanged"
+	// PlanRemove is a generated IDE file recorded in the lockfile whose agent or task is no longer installed
+	PlanRemove PlanStatus = "remove"
+)
+
+// ComponentFramework marks planned files inside the framework directory
+const ComponentFramework = "framework"
+
+// PlannedFile is a file an installation would write
+type PlannedFile struct {
+	// Path is slash separated and relative to the project root
+	Path string `json:"path"`
+	// Component is ComponentFramework or the IDE the file belongs to
+	Component string     `json:"component"`
+	Status    PlanStatus `json:"status"`
+	// LocalChanges is set when an overwritten file differs from the installed version recorded in the lockfile
+	LocalChanges bool `json:"local_changes,omitempty"`
+}
+
+// InstallPlan lists the files an installation would write
+type InstallPlan struct {
+	Agents []string      `json:"agents"`
+	Files  []PlannedFile `json:"files"`
+}
+
+// Count returns the number of planned files with the given status
+func (p *InstallPlan) Count(status PlanStatus) int {
+	count := 0
+	for _, file := range p.Files {
+		if file.Status == status {
+			count++
+		}
+	}
+
+	return count
+}
+
+// PlanOptions selects what to plan
+type PlanOptions struct {
+	// Agents limits the installation to the given agents as InstallSelective does, all agents when empty
+	Agents []string
+	// Tasks limits the installation to the selected tasks per agent as InstallTasks does, overrides Agents
+	Tasks map[string][]string
+	// SkipFramework plans only the IDE files, e.g. when syncing an existing installation
+	SkipFramework bool
+	// IDEs are the IDE integrations to generate
+	IDEs []string
+}
+
+// PlanInstall computes which files Install, InstallSelective or InstallTasks and the IDE integrations would
+// create, overwrite or leave unchanged, without writing anything
+func (i *Installer) PlanInstall(opts PlanOptions) (*InstallPlan, error) {
+	plan := &InstallPlan{}
+
+	lock, err := i.loadLockfile()
+	if err != nil {
+		lock = nil
+	}
+
+	// Agent definitions and the framework files as they will be after the framework files are written
+	agentFiles := make(map[string]bool)
+	planned := make(map[string][]byte)
+	existing, err := filepath.Glob(filepath.Join(i.GetAgentsPath(), "*.yaml"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find agent files: %w", err)
+	}
+	for _, path := range existing {
+		agentFiles[path] = true
+	}
+
+	if !opts.SkipFramework {
+		var (
+			paths, agents []string
+			trimmed       map[string][]byte
+		)
+		if len(opts.Tasks) > 0 {
+			paths, trimmed, err = i.ResolveTaskFiles(opts.Tasks)
+			agents = slices.Sorted(maps.Keys(opts.Tasks))
+		} else {
+			paths, agents, err = i.planFramework(opts.Agents)
+		}
+		if err != nil {
+			return nil, err
+		}
+		plan.Agents = agents
+
+		for _, path := range paths {
+			data, ok := trimmed[path]
+			if !ok {
+				if data, err = i.ReadSourceFile(path); err != nil {
+					return nil, err
+				}
+			}
+
+			target := filepath.Join(i.krciPath, filepath.FromSlash(path))
+			file, err := i.planFile(target, data, ComponentFramework, lock)
+			if err != nil {
+				return nil, err
+			}
+			plan.Files = append(plan.Files, file)
+			planned[target] = data
+
+			if filepath.Dir(target) == i.GetAgentsPath() && filepath.Ext(target) == ".yaml" {
+				agentFiles[target] = true
+			}
+		}
+	}
+
+	if len(opts.IDEs) == 0 {
+		return plan, nil
+	}
+
+	vals, err := values.Load(i.krciPath)
+	if err != nil {
+		return nil, err
+	}
+
+	project := projectFileSystem{read: func(name string) ([]byte, error) {
+		if data, ok := planned[name]; ok {
+			return data, nil
+		}
+		return os.ReadFile(name)
+	}}
+
+	for _, ide := range opts.IDEs {
+		integration, err := i.integrationFor(ide)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, agentFile := range slices.Sorted(maps.Keys(agentFiles)) {
+			data, err := project.ReadFile(agentFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read agent file %s: %w", agentFile, err)
+			}
+
+			files, err := renderIDEFiles(agentFile, data, integration, vals, project)
+			if err != nil {
+				return nil, fmt.Errorf("failed to render %s file for %s: %w", ide, agentFile, err)
+			}
+
+			for _, generated := range files {
+				file, err := i.planFile(generated.Path, generated.Content, ide, lock)
+				if err != nil {
+					return nil, err
+				}
+				plan.Files = append(plan.Files, file)
+			}
+		}
+
+		if aggregateIntegration, ok := integration.(AggregateIntegration); ok {
+			current, err := os.ReadFile(aggregateIntegration.AggregatePath())
+			if err != nil && !os.IsNotExist(err) {
+				return nil, fmt.Errorf("failed to read %s: %w", aggregateIntegration.AggregatePath(), err)
+			}
+			content, _, err := renderAggregate(aggregateIntegration, current, slices.Collect(maps.Keys(agentFiles)), vals, project)
+			if err != nil {
+				return nil, fmt.Errorf("failed to render %s file: %w", ide, err)
+			}
+			file, err := i.planFile(aggregateIntegration.AggregatePath(), content, ide, lock)
+			if err != nil {
+				return nil, err
+			}
+			plan.Files = append(plan.Files, file)
+		}
+
+		removals, err := i.planRemovals(ide, plan.Files, lock)
+		if err != nil {
+			return nil, err
+		}
+		plan.Files = append(plan.Files, removals...)
+
+		sectionIntegration, ok := integration.(SectionIntegration)
+		if !ok || sectionIntegration.SectionPath() == "" {
+			continue
+		}
+		file, err := i.planSection(sectionIntegration, slices.Sorted(maps.Keys(agentFiles)), vals, project, ide, lock)
+		if err != nil {
+			return nil, err
+		}
+		plan.Files = append(plan.Files, file)
+	}
+
+	return plan, nil
+}
+
+// planFramework returns the framework files and agent names the installation would write
+func (i *Installer) planFramework(agentNames []string) ([]string, []string, error) {
+	var (
+		agents []Agent
+		err    error
+	)
+	if len(agentNames) == 0 {
+		agents, err = i.discovery.GetAgents(context.Background())
+	} else {
+		agents, err = i.discovery.GetAgentsByNames(context.Background(), agentNames)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get agents: %w", err)
+	}
+
+	if len(agentNames) > 0 {
+		if err := i.validateAgentsFound(agents, agentNames); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if err := checkCLICompatibility(agents); err != nil {
+		return nil, nil, err
+	}
+
+	prefix := filepath.Clean(i.sourceDir + "/")
+	filesFilter := make(map[string]struct{})
+	names := make([]string, 0, len(agents))
+	for _, agent := range agents {
+		i.addAgentDependencies(agent, filesFilter, prefix)
+		if err := i.addReferencedTaskDependencies(agent, filesFilter, prefix); err != nil {
+			return nil, nil, err
+		}
+		names = append(names, agent.ShortName)
+	}
+	slices.Sort(names)
+
+	return filterPaths(filesFilter), names, nil
+}
+
+// planFile compares the content the installation would write with the file on disk
+func (i *Installer) planFile(target string, content []byte, component string, lock *lockfile.Lockfile) (PlannedFile, error) {
+	rel, err := filepath.Rel(i.projectDir, target)
+	if err != nil {
+		rel = target
+	}
+	file := PlannedFile{Path: filepath.ToSlash(rel), Component: component}
+
+	current, err := os.ReadFile(target)
+	switch {
+	case os.IsNotExist(err):
+		file.Status = PlanCreate
+		return file, nil
+	case err != nil:
+		return PlannedFile{}, fmt.Errorf("failed to read %s: %w", target, err)
+	case bytes.Equal(current, content):
+		file.Status = PlanUnchanged
+		return file, nil
+	}
+
+	file.Status = PlanOverwrite
+	if lock != nil {
+		if entry, ok := lock.GetFile(file.Path); !ok || !entry.Matches(current) {
+			file.LocalChanges = true
+		}
+	}
+
+	return file, nil
+}
+
+// planRemovals returns the files of an IDE integration recorded in the lockfile that the installation
+// would not generate again and removes, as syncing the integration does
+func (i *Installer) planRemovals(ide string, planned []PlannedFile, lock *lockfile.Lockfile) ([]PlannedFile, error) {
+	if lock == nil {
+		return nil, nil
+	}
+
+	generated := make(map[string]bool)
+	for _, file := range planned {
+		if file.Component == ide {
+			generated[file.Path] = true
+		}
+	}
+
+	var removals []PlannedFile
+	for _, entry := range lock.Files {
+		if entry.IDE != ide || entry.Section || generated[entry.Path] {
+			continue
+		}
+
+		status, err := lockfile.CheckEntry(i.projectDir, entry)
+		if err != nil {
+			return nil, err
+		}
+		if status == lockfile.StatusMissing {
+			continue
+		}
+		removals = append(removals, PlannedFile{
+			Path:         entry.Path,
+			Component:    ide,
+			Status:       PlanRemove,
+			LocalChanges: status == lockfile.StatusModified,
+		})
+	}
+
+	return removals, nil
+}
+
+// planSection compares the file with the managed section the installation would write with the file on disk,
+// local changes only count inside the section
+func (i *Installer) planSection(integration SectionIntegration, agentFiles []string, vals values.Values, project FileSystem, component string, lock *lockfile.Lockfile) (PlannedFile, error) {
+	target := integration.SectionPath()
+
+	current, err := os.ReadFile(target)
+	if err != nil && !os.IsNotExist(err) {
+		return PlannedFile{}, fmt.Errorf("failed to read %s: %w", target, err)
+	}
+
+	content, _, _, err := renderSection(integration, current, agentFiles, vals, project)
+	if err != nil {
+		return PlannedFile{}, fmt.Errorf("failed to render %s section: %w", component, err)
+	}
+
+	file, err := i.planFile(target, content, component, lock)
+	if err != nil || file.Status != PlanOverwrite || lock == nil {
+		return file, err
+	}
+
+	file.LocalChanges = false
+	if entry, ok := lock.GetFile(file.Path); ok {
+		status, err := lockfile.CheckEntry(i.projectDir, entry)
+		if err != nil {
+			return PlannedFile{}, err
+		}
+		file.LocalChanges = status == lockfile.StatusModified
+	}
+
+	return file, nil
+}
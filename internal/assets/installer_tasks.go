@@ -0,0 +1,213 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package assets
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/KubeRocketCI/kuberocketai/internal/processor"
+)
+
+// InstallTasks installs only the selected tasks of each agent, keyed by agent short name,
+// with their transitive dependencies. The installed agent definitions list only the
+// selected tasks and the commands running them.
+func (i *Installer) InstallTasks(selection map[string][]string) error {
+	if len(selection) == 0 {
+		return fmt.Errorf("no tasks specified")
+	}
+
+	filesFilter, agentFiles, err := i.resolveTaskFiles(selection)
+	if err != nil {
+		return err
+	}
+
+	// Agent definitions are written trimmed instead of copied
+	for path := range agentFiles {
+		delete(filesFilter, path)
+	}
+
+	if err := i.copySourceFiles(context.TODO(), filesFilter); err != nil {
+		return err
+	}
+
+	for _, path := range slices.Sorted(maps.Keys(agentFiles)) {
+		if err := i.writeFrameworkFile(path, agentFiles[path]); err != nil {
+			return fmt.Errorf("failed to write trimmed agent: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ResolveTaskFiles returns the source files needed by the selected tasks of each agent, including
+// the agent definitions, and the agent definitions trimmed to the selected tasks keyed by path.
+// Paths are slash separated and relative to the framework directory, sorted for reproducible output.
+func (i *Installer) ResolveTaskFiles(selection map[string][]string) ([]string, map[string][]byte, error) {
+	filesFilter, agentFiles, err := i.resolveTaskFiles(selection)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	trimmed := make(map[string][]byte, len(agentFiles))
+	for path, data := range agentFiles {
+		trimmed[filterPath(path)] = data
+	}
+
+	return filterPaths(filesFilter), trimmed, nil
+}
+
+// resolveTaskFiles collects the files filter for the selected tasks and the trimmed agent
+// definitions keyed by their path in the files filter
+func (i *Installer) resolveTaskFiles(selection map[string][]string) (map[string]struct{}, map[string][]byte, error) {
+	agentNames := slices.Sorted(maps.Keys(selection))
+	agents, err := i.discovery.GetAgentsByNames(context.Background(), agentNames)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get agents: %w", err)
+	}
+
+	if err := i.validateAgentsFound(agents, agentNames); err != nil {
+		return nil, nil, err
+	}
+
+	if err := checkCLICompatibility(agents); err != nil {
+		return nil, nil, err
+	}
+
+	prefix := filepath.Clean(i.sourceDir + "/")
+	filesFilter := make(map[string]struct{})
+	agentFiles := make(map[string][]byte, len(agents))
+	for _, agent := range agents {
+		selected, err := selectAgentTasks(agent, selection[agent.ShortName])
+		if err != nil {
+			return nil, nil, err
+		}
+
+		files := make(map[string]struct{})
+		i.addAgentDependencies(selected, files, prefix)
+		if err := i.addReferencedTaskDependencies(selected, files, prefix); err != nil {
+			return nil, nil, err
+		}
+
+		data, err := i.source.ReadFile(agent.FilePath)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read agent file %s: %w", agent.FilePath, err)
+		}
+		names := make([]string, 0, len(selected.Tasks))
+		for _, task := range selected.Tasks {
+			names = append(names, task.Name)
+		}
+		trimmed, err := processor.TrimAgentTasks(data, names)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to trim agent %s: %w", agent.ShortName, err)
+		}
+
+		agentFiles[strings.TrimPrefix(agent.FilePath, prefix)] = trimmed
+		maps.Copy(filesFilter, files)
+		i.recordAgent(agent.ShortName, files)
+		i.recordAgentTasks(agent.ShortName, names)
+	}
+
+	return filesFilter, agentFiles, nil
+}
+
+// selectAgentTasks returns the agent with only the tasks of the given names
+func selectAgentTasks(agent Agent, names []string) (Agent, error) {
+	var (
+		tasks   []Task
+		missing []string
+	)
+	for _, name := range names {
+		idx := slices.IndexFunc(agent.Tasks, func(task Task) bool { return task.Name == name })
+		switch {
+		case idx < 0:
+			missing = append(missing, name)
+		case !slices.ContainsFunc(tasks, func(task Task) bool { return task.Name == name }):
+			tasks = append(tasks, agent.Tasks[idx])
+		}
+	}
+
+	if len(missing) > 0 {
+		available := make([]string, 0, len(agent.Tasks))
+		for _, task := range agent.Tasks {
+			available = append(available, task.Name)
+		}
+		slices.Sort(available)
+		return Agent{}, fmt.Errorf("tasks not found in agent %s: %s (available: %s)",
+			agent.ShortName, strings.Join(missing, ", "), strings.Join(available, ", "))
+	}
+
+	agent.Tasks = tasks
+	return agent, nil
+}
+
+// addReferencedTaskDependencies adds the templates, data and tasks needed by the tasks the
+// agent tasks reference, following references recursively
+func (i *Installer) addReferencedTaskDependencies(agent Agent, filesFilter map[string]struct{}, prefix string) error {
+	seen := make(map[string]struct{}, len(agent.Tasks))
+	for _, task := range agent.Tasks {
+		seen[task.Path] = struct{}{}
+	}
+
+	queue := agent.GetAllReferencedTasksPaths()
+	for len(queue) > 0 {
+		path := queue[0]
+		queue = queue[1:]
+		if _, ok := seen[path]; ok {
+			continue
+		}
+		seen[path] = struct{}{}
+
+		raw, err := processor.UnmarshalTaskDependenciesFileFromFS(i.source, path)
+		if err != nil {
+			return fmt.Errorf("failed to resolve referenced task: %w", err)
+		}
+		dependencies := MakeTaskDependency(i.sourceDir, *raw)
+
+		filesFilter[strings.TrimPrefix(path, prefix)] = struct{}{}
+		for _, template := range dependencies.Templates {
+			filesFilter[strings.TrimPrefix(template.Path, prefix)] = struct{}{}
+		}
+		for _, dataFile := range dependencies.DataFiles {
+			filesFilter[strings.TrimPrefix(dataFile.Path, prefix)] = struct{}{}
+		}
+		for _, task := range dependencies.Tasks {
+			queue = append(queue, task.Path)
+		}
+	}
+
+	return nil
+}
+
+// filterPath returns a files filter path slash separated and relative to the framework directory
+func filterPath(path string) string {
+	return strings.TrimPrefix(filepath.ToSlash(path), "/")
+}
+
+// filterPaths returns the sorted paths of a files filter, see filterPath
+func filterPaths(filesFilter map[string]struct{}) []string {
+	paths := make([]string, 0, len(filesFilter))
+	for path := range filesFilter {
+		paths = append(paths, filterPath(path))
+	}
+	slices.Sort(paths)
+
+	return paths
+}
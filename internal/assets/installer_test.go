@@ -0,0 +1,193 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package assets
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KubeRocketCI/kuberocketai/internal/lockfile"
+)
+
+// TestInstallReferencedTaskDependencies verifies that the templates and data of tasks referenced by
+// agent tasks are installed and recorded for the agent, so that removing another agent keeps them
+func TestInstallReferencedTaskDependencies(t *testing.T) {
+	sourceDir, _ := prepareInstall(t)
+	writeTree(t, sourceDir, map[string]string{
+		"tasks/tester/plan-tests.md":          "---\ndependencies:\n  templates:\n    - tester/plan-template.md\n  tasks:\n    - shared/review.md\n---\n# Plan tests\n",
+		"tasks/shared/review.md":              "---\ndependencies:\n  templates:\n    - shared/review-template.md\n  tasks:\n    - shared/sign-off.md\n---\n# Review\n",
+		"tasks/shared/sign-off.md":            "---\ndependencies:\n  data:\n    - shared/sign-off-guide.md\n---\n# Sign off\n",
+		"templates/shared/review-template.md": "# Review\n",
+		"data/shared/sign-off-guide.md":       "# Sign off\n",
+	})
+
+	tests := []struct {
+		name    string
+		install func(installer *Installer) error
+	}{
+		{
+			name:    "all agents",
+			install: func(installer *Installer) error { return installer.Install() },
+		},
+		{
+			name:    "selected agents",
+			install: func(installer *Installer) error { return installer.InstallSelective([]string{"tester"}) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projectDir := t.TempDir()
+			installer := NewInstallerFromSource(projectDir, OSFileSystem{}, sourceDir, NewDiscovery(sourceDir))
+			require.NoError(t, tt.install(installer))
+			require.NoError(t, installer.UpdateLockfile(nil))
+
+			lock, err := lockfile.Load(installer.GetFrameworkPath())
+			require.NoError(t, err)
+			for _, path := range []string{
+				".krci-ai/tasks/shared/review.md",
+				".krci-ai/templates/shared/review-template.md",
+				".krci-ai/tasks/shared/sign-off.md",
+				".krci-ai/data/shared/sign-off-guide.md",
+			} {
+				assert.FileExists(t, filepath.Join(projectDir, filepath.FromSlash(path)))
+				file, ok := lock.GetFile(path)
+				require.True(t, ok, path)
+				assert.Equal(t, []string{"tester"}, file.Agents, path)
+			}
+		})
+	}
+}
+
+// TestUpdateLockfileSource verifies which source the lockfile records for the installed framework
+func TestUpdateLockfileSource(t *testing.T) {
+	pack := &lockfile.Source{Type: lockfile.SourceGit, URL: "https://example.com/agents.git", Revision: "abc"}
+
+	tests := []struct {
+		name     string
+		previous *lockfile.Source
+		install  func(installer *Installer) error
+		want     *lockfile.Source
+	}{
+		{
+			name:    "new installation",
+			install: func(installer *Installer) error { return installer.InstallSelective([]string{"tester"}) },
+			want:    &lockfile.Source{Type: lockfile.SourceEmbedded},
+		},
+		{
+			name:     "agent added to a pack installation",
+			previous: pack,
+			install:  func(installer *Installer) error { return installer.InstallSelective([]string{"tester"}) },
+			want:     pack,
+		},
+		{
+			name:     "framework reinstalled over a pack installation",
+			previous: pack,
+			install:  func(installer *Installer) error { return installer.Install() },
+			want:     &lockfile.Source{Type: lockfile.SourceEmbedded},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sourceDir, projectDir := prepareInstall(t)
+			frameworkDir := filepath.Join(projectDir, KrciAIDir)
+			if tt.previous != nil {
+				lock := lockfile.New()
+				lock.Source = tt.previous
+				require.NoError(t, lock.Save(frameworkDir))
+			}
+
+			installer := NewInstallerFromSource(projectDir, OSFileSystem{}, sourceDir, NewDiscovery(sourceDir))
+			require.NoError(t, tt.install(installer))
+			require.NoError(t, installer.UpdateLockfile(nil))
+
+			lock, err := lockfile.Load(frameworkDir)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, lock.Source)
+		})
+	}
+}
+
+// TestUpdateLockfilePrunesObjects verifies that only the originals of the installed files are kept
+func TestUpdateLockfilePrunesObjects(t *testing.T) {
+	sourceDir, projectDir := prepareInstall(t)
+
+	installer := NewInstallerFromSource(projectDir, OSFileSystem{}, sourceDir, NewDiscovery(sourceDir))
+	require.NoError(t, installer.Install())
+	require.NoError(t, installer.UpdateLockfile(nil))
+	frameworkDir := installer.GetFrameworkPath()
+	previous, err := os.ReadFile(filepath.Join(frameworkDir, "tasks", "tester", "plan-tests.md"))
+	require.NoError(t, err)
+
+	writeTree(t, sourceDir, map[string]string{"tasks/tester/plan-tests.md": "---\ndependencies:\n  templates:\n    - tester/plan-template.md\n---\n# Plan tests v2\n"})
+	reinstalled := NewInstallerFromSource(projectDir, OSFileSystem{}, sourceDir, NewDiscovery(sourceDir))
+	require.NoError(t, reinstalled.Begin())
+	require.NoError(t, reinstalled.Install())
+	require.NoError(t, reinstalled.UpdateLockfile(nil))
+	require.NoError(t, reinstalled.Commit())
+
+	lock, err := lockfile.Load(frameworkDir)
+	require.NoError(t, err)
+	objects, err := filepath.Glob(lockfile.ObjectsPattern(frameworkDir))
+	require.NoError(t, err)
+	assert.Len(t, objects, len(lock.Files))
+	assert.Empty(t, lock.UnreferencedObjects(objects))
+
+	_, err = lockfile.LoadObject(frameworkDir, lockfile.Hash(previous))
+	assert.ErrorIs(t, err, lockfile.ErrObjectNotFound)
+	file, ok := lock.GetFile(".krci-ai/tasks/tester/plan-tests.md")
+	require.True(t, ok)
+	_, err = lockfile.LoadObject(frameworkDir, file.SHA256)
+	assert.NoError(t, err)
+}
+
+// TestPlanInstallReferencedTaskDependencies verifies that the dry-run plan lists the files of referenced tasks
+func TestPlanInstallReferencedTaskDependencies(t *testing.T) {
+	sourceDir, projectDir := prepareInstall(t)
+	writeTree(t, sourceDir, map[string]string{
+		"tasks/tester/plan-tests.md":          "---\ndependencies:\n  templates:\n    - tester/plan-template.md\n  tasks:\n    - shared/review.md\n---\n# Plan tests\n",
+		"tasks/shared/review.md":              "---\ndependencies:\n  templates:\n    - shared/review-template.md\n---\n# Review\n",
+		"templates/shared/review-template.md": "# Review\n",
+	})
+
+	tests := []struct {
+		name string
+		opts PlanOptions
+	}{
+		{name: "all agents"},
+		{name: "selected agents", opts: PlanOptions{Agents: []string{"tester"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			installer := NewInstallerFromSource(projectDir, OSFileSystem{}, sourceDir, NewDiscovery(sourceDir))
+			plan, err := installer.PlanInstall(tt.opts)
+			require.NoError(t, err)
+
+			paths := make([]string, 0, len(plan.Files))
+			for _, file := range plan.Files {
+				paths = append(paths, file.Path)
+			}
+			assert.Contains(t, paths, ".krci-ai/tasks/shared/review.md")
+			assert.Contains(t, paths, ".krci-ai/templates/shared/review-template.md")
+		})
+	}
+}
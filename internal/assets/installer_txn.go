@@ -0,0 +1,224 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package assets
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/KubeRocketCI/kuberocketai/internal/transaction"
+)
+
+// ErrRolledBack is returned when writing files of an installation that was rolled back
+var ErrRolledBack = errors.New("installation was rolled back")
+
+// Begin stages all files written by the installer, including IDE files and the lockfile,
+// until Commit moves them into the project at once. The installer reads staged files
+// in place of the project files so that IDE files are generated from the staged agents.
+func (i *Installer) Begin() error {
+	i.txnMu.Lock()
+	defer i.txnMu.Unlock()
+
+	if i.txn != nil {
+		return fmt.Errorf("installation already in progress")
+	}
+
+	txn, err := transaction.Begin(i.projectDir)
+	if err != nil {
+		return err
+	}
+	i.txn = txn
+	i.rolledBack = false
+
+	return nil
+}
+
+// Join stages the files written by the installer in the installation in progress of owner, e.g. to
+// install several packs at once. They are committed or rolled back with the installation of owner,
+// and locally modified files are backed up with the files of owner.
+func (i *Installer) Join(owner *Installer) error {
+	owner.txnMu.RLock()
+	txn := owner.txn
+	owner.txnMu.RUnlock()
+	if txn == nil {
+		return fmt.Errorf("no installation in progress to join")
+	}
+
+	i.txnMu.Lock()
+	defer i.txnMu.Unlock()
+
+	if i.txn != nil {
+		return fmt.Errorf("installation already in progress")
+	}
+	i.txn = txn
+	i.joined = true
+	i.rolledBack = false
+	i.backup = owner.backup
+
+	return nil
+}
+
+// Commit moves the files staged since Begin into the project. When moving any file fails,
+// the project is restored as it was before the installation.
+func (i *Installer) Commit() error {
+	i.txnMu.Lock()
+	defer i.txnMu.Unlock()
+
+	if i.rolledBack {
+		return ErrRolledBack
+	}
+	if i.txn == nil {
+		return nil
+	}
+
+	txn := i.txn
+	i.txn = nil
+	if i.joined {
+		// The owner of the installation commits the staged files
+		i.joined = false
+		return nil
+	}
+	if err := txn.Commit(); err != nil {
+		i.rolledBack = true
+		// The overwritten files were restored so their backup is not needed, unless restoring failed
+		if !errors.Is(err, transaction.ErrRollbackFailed) {
+			_ = i.backup.Discard()
+		} else if len(i.backup.Files()) > 0 {
+			return fmt.Errorf("failed to commit installation: %w; locally modified files are also backed up in %s", err, i.backup.Path())
+		}
+		return fmt.Errorf("failed to commit installation: %w", err)
+	}
+
+	return nil
+}
+
+// Rollback discards the files staged since Begin and the backup of files they would
+// overwrite. It reports whether an installation in progress was rolled back.
+// Writes in progress finish first, later writes fail with ErrRolledBack.
+func (i *Installer) Rollback() (bool, error) {
+	i.txnMu.Lock()
+	defer i.txnMu.Unlock()
+
+	if i.txn == nil {
+		return false, nil
+	}
+
+	txn := i.txn
+	i.txn = nil
+	i.rolledBack = true
+	if i.joined {
+		// The owner of the installation rolls back the staged files
+		i.joined = false
+		return false, nil
+	}
+
+	return true, errors.Join(txn.Rollback(), i.backup.Discard())
+}
+
+// stage runs write with the path a file of the project is written to, staged during an installation
+func (i *Installer) stage(target string, write func(path string) error) error {
+	i.txnMu.RLock()
+	defer i.txnMu.RUnlock()
+
+	if i.rolledBack {
+		return ErrRolledBack
+	}
+	if i.txn == nil {
+		return write(target)
+	}
+
+	path, err := i.txn.Path(target)
+	if err != nil {
+		return err
+	}
+
+	return write(path)
+}
+
+// Stage runs write with the path a file of the project is written to, staged during an installation,
+// e.g. for files written by an upgrade
+func (i *Installer) Stage(target string, write func(path string) error) error {
+	return i.stage(target, write)
+}
+
+// writeFile writes a file of the project, creating its parent directories
+func (i *Installer) writeFile(target string, data []byte) error {
+	return i.stage(target, func(path string) error {
+		if err := os.MkdirAll(filepath.Dir(path), DirectoryPermissions); err != nil {
+			return fmt.Errorf("failed to create parent directory for %s: %w", target, err)
+		}
+
+		if err := os.WriteFile(path, data, FilePermissions); err != nil {
+			return fmt.Errorf("failed to write file to %s: %w", target, err)
+		}
+
+		return nil
+	})
+}
+
+// removeFile removes a file of the project, staged during an installation
+func (i *Installer) removeFile(target string) error {
+	i.txnMu.RLock()
+	defer i.txnMu.RUnlock()
+
+	if i.rolledBack {
+		return ErrRolledBack
+	}
+	if i.txn == nil {
+		if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove %s: %w", target, err)
+		}
+		return nil
+	}
+
+	return i.txn.Remove(target)
+}
+
+// readFile reads a file of the project, as staged during an installation
+func (i *Installer) readFile(path string) ([]byte, error) {
+	i.txnMu.RLock()
+	defer i.txnMu.RUnlock()
+
+	if i.txn == nil {
+		return os.ReadFile(path)
+	}
+	return i.txn.ReadFile(path)
+}
+
+// glob returns the files of the project matching the pattern, including files staged during an installation
+func (i *Installer) glob(pattern string) ([]string, error) {
+	i.txnMu.RLock()
+	defer i.txnMu.RUnlock()
+
+	if i.txn == nil {
+		return filepath.Glob(pattern)
+	}
+	return i.txn.Glob(pattern)
+}
+
+// exists reports whether a path of the project exists, including files staged during an installation
+func (i *Installer) exists(path string) bool {
+	i.txnMu.RLock()
+	defer i.txnMu.RUnlock()
+
+	if i.txn == nil {
+		_, err := os.Stat(path)
+		return err == nil
+	}
+	return i.txn.Exists(path)
+}
@@ -0,0 +1,201 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package assets
+
+import (
+	"errors"
+	"io/fs"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KubeRocketCI/kuberocketai/internal/lockfile"
+)
+
+const testerAgent = `agent:
+  identity:
+    name: "Tester"
+    id: tester-v1
+    version: "1.0.0"
+    description: "Agent for installation tests"
+    role: "Tester"
+    goal: "Test installations"
+    icon: "🧪"
+
+  commands:
+    help: "Show available commands"
+    plan: "Plan tests by executing task plan-tests"
+    report: "Report results by executing task report-results"
+
+  tasks:
+    - ./.krci-ai/tasks/tester/plan-tests.md
+    - ./.krci-ai/tasks/tester/report-results.md
+`
+
+// failingFileSystem fails reading one file of the source
+type failingFileSystem struct {
+	OSFileSystem
+	path string
+}
+
+func (f failingFileSystem) ReadFile(name string) ([]byte, error) {
+	if name == f.path {
+		return nil, errors.New("injected failure")
+	}
+	return f.OSFileSystem.ReadFile(name)
+}
+
+// writeTree writes files given by slash separated paths relative to the root
+func writeTree(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+
+	for path, content := range files {
+		target := filepath.Join(root, filepath.FromSlash(path))
+		require.NoError(t, os.MkdirAll(filepath.Dir(target), 0755))
+		require.NoError(t, os.WriteFile(target, []byte(content), 0644))
+	}
+}
+
+// snapshotTree returns the content of the files and the directories of a tree
+func snapshotTree(t *testing.T, root string) map[string]string {
+	t.Helper()
+
+	tree := make(map[string]string)
+	require.NoError(t, filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(root, path)
+		if entry.IsDir() {
+			tree[rel+"/"] = ""
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		tree[rel] = string(data)
+		return err
+	}))
+
+	return tree
+}
+
+// prepareInstall creates a framework source and a project with a locally modified installation
+func prepareInstall(t *testing.T) (string, string) {
+	t.Helper()
+
+	sourceDir := t.TempDir()
+	writeTree(t, sourceDir, map[string]string{
+		"agents/tester.yaml":                "# new\n" + testerAgent,
+		"tasks/tester/plan-tests.md":        "---\ndependencies:\n  templates:\n    - tester/plan-template.md\n---\n# Plan tests\n",
+		"tasks/tester/report-results.md":    "---\ndependencies:\n  data:\n    - tester/report-guide.md\n---\n# Report results\n",
+		"templates/tester/plan-template.md": "# Plan\n",
+		"data/tester/report-guide.md":       "# Guide\n",
+	})
+
+	projectDir := t.TempDir()
+	writeTree(t, projectDir, map[string]string{
+		".krci-ai/agents/tester.yaml":         testerAgent,
+		".krci-ai/tasks/tester/plan-tests.md": "# Old plan tests\n",
+		".claude/commands/krci-ai/tester.md":  "custom command\n",
+		"README.md":                           "project\n",
+	})
+
+	return sourceDir, projectDir
+}
+
+func TestInstallerCommit(t *testing.T) {
+	sourceDir, projectDir := prepareInstall(t)
+
+	installer := NewInstallerFromSource(projectDir, OSFileSystem{}, sourceDir, NewDiscovery(sourceDir))
+	require.NoError(t, installer.Begin())
+	require.NoError(t, installer.Install())
+	require.NoError(t, installer.ValidateInstallation())
+	require.NoError(t, installer.InstallIDE("claude"))
+	require.NoError(t, installer.UpdateLockfile([]string{"claude"}))
+
+	// Nothing changes in the project before the commit
+	data, err := os.ReadFile(filepath.Join(projectDir, ".krci-ai", "agents", "tester.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, testerAgent, string(data))
+	assert.NoFileExists(t, lockfile.GetPath(installer.GetFrameworkPath()))
+
+	require.NoError(t, installer.Commit())
+
+	data, err = os.ReadFile(filepath.Join(projectDir, ".krci-ai", "agents", "tester.yaml"))
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(data), "# new\n"))
+	assert.FileExists(t, filepath.Join(projectDir, ".krci-ai", "data", "tester", "report-guide.md"))
+
+	data, err = os.ReadFile(filepath.Join(projectDir, ".claude", "commands", "krci-ai", "tester.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "# new")
+
+	lock, err := lockfile.Load(installer.GetFrameworkPath())
+	require.NoError(t, err)
+	checks, err := lock.Modified(projectDir)
+	require.NoError(t, err)
+	assert.Empty(t, checks)
+	assert.Contains(t, installer.Backup().Files(), ".claude/commands/krci-ai/tester.md")
+
+	matches, err := filepath.Glob(filepath.Join(projectDir, ".krci-ai-staging-*"))
+	require.NoError(t, err)
+	assert.Empty(t, matches)
+}
+
+// TestInstallerRollback fails reading each source file, in random order, and checks
+// the project is left exactly as it was once the installation is rolled back
+func TestInstallerRollback(t *testing.T) {
+	sourceDir, _ := prepareInstall(t)
+
+	var sources []string
+	require.NoError(t, filepath.WalkDir(sourceDir, func(path string, entry fs.DirEntry, err error) error {
+		if err == nil && !entry.IsDir() {
+			sources = append(sources, path)
+		}
+		return err
+	}))
+
+	random := rand.New(rand.NewSource(7))
+	random.Shuffle(len(sources), func(a, b int) { sources[a], sources[b] = sources[b], sources[a] })
+
+	for _, failing := range sources {
+		t.Run(filepath.Base(failing), func(t *testing.T) {
+			_, projectDir := prepareInstall(t)
+			before := snapshotTree(t, projectDir)
+
+			installer := NewInstallerFromSource(projectDir, failingFileSystem{path: failing}, sourceDir, NewDiscovery(sourceDir))
+			require.NoError(t, installer.Begin())
+
+			err := installer.Install()
+			if err == nil {
+				err = installer.InstallIDE("claude")
+			}
+			require.Error(t, err)
+
+			rolledBack, err := installer.Rollback()
+			require.NoError(t, err)
+			assert.True(t, rolledBack)
+			assert.Equal(t, before, snapshotTree(t, projectDir))
+
+			assert.ErrorIs(t, installer.UpdateLockfile(nil), ErrRolledBack)
+			assert.ErrorIs(t, installer.Commit(), ErrRolledBack)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package assets
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// tomlString quotes s as a TOML basic string, or as a multi-line basic string keeping its lines readable.
+// Backslashes, control characters and quotes that would end the string are escaped.
+func tomlString(s string) (string, error) {
+	if !utf8.ValidString(s) {
+		return "", fmt.Errorf("failed to encode TOML string: strings must be valid UTF-8")
+	}
+
+	multiline := strings.Contains(s, "\n")
+	delimiter := `"`
+	if multiline {
+		delimiter = `"""`
+	}
+
+	var b strings.Builder
+	b.WriteString(delimiter)
+	if multiline {
+		// A line break right after the opening delimiter is not part of the string
+		b.WriteByte('\n')
+	}
+
+	quotes := 0
+	for _, r := range s {
+		if r == '"' {
+			if !multiline {
+				b.WriteString(`\"`)
+				continue
+			}
+			quotes++
+			if quotes == 3 {
+				b.WriteString(`""\"`)
+				quotes = 0
+			}
+			continue
+		}
+		b.WriteString(strings.Repeat(`"`, quotes))
+		quotes = 0
+
+		switch {
+		case r == '\\':
+			b.WriteString(`\\`)
+		case r == '\n' || r == '\t':
+			b.WriteRune(r)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r < 0x20 || r == 0x7f || r == utf8.RuneError:
+			// The TOML decoder takes a literal replacement character for invalid UTF-8
+			fmt.Fprintf(&b, `\u%04X`, r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteString(strings.Repeat(`\"`, quotes))
+	if multiline && quotes == 0 && strings.HasSuffix(s, `\`) {
+		// The TOML decoder misreads an escaped backslash right before the closing delimiter,
+		// a line ending backslash moves the delimiter to the next line without changing the string
+		b.WriteString("\\\n")
+	}
+	b.WriteString(delimiter)
+
+	return b.String(), nil
+}
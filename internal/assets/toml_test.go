@@ -0,0 +1,96 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package assets
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTOMLStrings(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{name: "empty", value: ""},
+		{name: "plain", value: "Activate the dev role"},
+		{name: "lines", value: "line one\nline two\n"},
+		{name: "leading line break", value: "\nafter a blank line"},
+		{name: "quotes", value: `say "hi" and ""twice""`},
+		{name: "triple quotes", value: `a """ b """" c """"""`},
+		{name: "trailing quotes", value: `ends with ""`},
+		{name: "leading quotes", value: `"""starts quoted`},
+		{name: "backslashes", value: `C:\path\to\file \n \u0041 \`},
+		{name: "line continuation", value: "ends with backslash \\\nnext line"},
+		{name: "trailing backslash", value: "line one\nends with backslash \\"},
+		{name: "trailing quote after backslash", value: "ends with \\\""},
+		{name: "control characters", value: "tab\there\r\nbell\a null\x00 del\x7f"},
+		{name: "unicode", value: "icon: 🧪 — ünïcödé \ufffd"},
+		{name: "yaml", value: "agent:\n  identity:\n    name: \"Tester\"\n  commands:\n    run: 'Run \"task\" \\ now'\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := tomlString(tt.value)
+			require.NoError(t, err)
+
+			var parsed struct {
+				Value string `toml:"value"`
+			}
+			_, err = toml.Decode("value = "+value+"\n", &parsed)
+			require.NoError(t, err, value)
+			assert.Equal(t, tt.value, parsed.Value)
+		})
+	}
+}
+
+func TestTOMLStrings_InvalidUTF8(t *testing.T) {
+	_, err := tomlString("bad \xff")
+	assert.ErrorContains(t, err, "valid UTF-8")
+}
+
+func TestGeminiIntegration(t *testing.T) {
+	sourceDir, projectDir := prepareInstall(t)
+
+	installer := NewInstallerFromSource(projectDir, OSFileSystem{}, sourceDir, NewDiscovery(sourceDir))
+	require.NoError(t, installer.Install())
+	require.NoError(t, installer.InstallIDE("gemini"))
+	assert.True(t, installer.HasIDE("gemini"))
+
+	var command struct {
+		Description string `toml:"description"`
+		Prompt      string `toml:"prompt"`
+	}
+	meta, err := toml.DecodeFile(filepath.Join(installer.IDEPath("gemini"), "tester.toml"), &command)
+	require.NoError(t, err)
+	assert.Empty(t, meta.Undecoded())
+
+	agent, err := os.ReadFile(filepath.Join(installer.GetAgentsPath(), "tester.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "Activate Tester role for specialized development assistance", command.Description)
+	assert.Contains(t, command.Prompt, "# /krci-ai:tester Command\n")
+	assert.Contains(t, command.Prompt, "```yaml\n"+string(agent)+"```\n")
+
+	// The prompt keeps its lines
+	data, err := os.ReadFile(filepath.Join(installer.IDEPath("gemini"), "tester.toml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "prompt = \"\"\"\n# /krci-ai:tester Command\n")
+}
@@ -0,0 +1,333 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package backup
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/KubeRocketCI/kuberocketai/internal/lockfile"
+)
+
+const (
+	// Dir holds the backups inside the framework directory
+	Dir = ".backup"
+	// ManifestFile lists the files of a backup
+	ManifestFile = "backup.json"
+
+	timestampFormat      = "20060102-150405"
+	filePermissions      = 0644
+	directoryPermissions = 0755
+)
+
+// ErrNotFound is returned when a backup does not exist
+var ErrNotFound = errors.New("backup not found")
+
+// Manifest describes a backup
+type Manifest struct {
+	ID        string    `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	// Reason is the command that overwrote the files
+	Reason string `json:"reason,omitempty"`
+	// Files are slash separated and relative to the project root
+	Files []string `json:"files"`
+}
+
+// Session backs up locally modified files before they are overwritten.
+// The backup directory is created on the first modified file only.
+type Session struct {
+	projectDir   string
+	frameworkDir string
+	reason       string
+	now          func() time.Time
+
+	mu       sync.Mutex
+	loaded   bool
+	lock     *lockfile.Lockfile
+	manifest *Manifest
+}
+
+// NewSession creates a backup session for files of the given project
+func NewSession(projectDir, frameworkDir, reason string) *Session {
+	return &Session{
+		projectDir:   projectDir,
+		frameworkDir: frameworkDir,
+		reason:       reason,
+		now:          time.Now,
+	}
+}
+
+// Protect backs up the file at target when it was modified locally and would change by writing content.
+// A file is modified when it differs from the hash recorded in the lockfile, or from the new
+// content when it is not tracked. It reports whether a backup was made.
+func (s *Session) Protect(target string, content []byte) (bool, error) {
+	current, err := os.ReadFile(target)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s: %w", target, err)
+	}
+	if bytes.Equal(current, content) {
+		return false, nil
+	}
+
+	rel, err := filepath.Rel(s.projectDir, target)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return false, fmt.Errorf("file %s is outside of the project %s", target, s.projectDir)
+	}
+	rel = filepath.ToSlash(rel)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.loadLock(); err != nil {
+		return false, err
+	}
+	if s.lock != nil {
+		if entry, ok := s.lock.GetFile(rel); ok && entry.Matches(current) {
+			return false, nil
+		}
+	}
+
+	if err := s.start(); err != nil {
+		return false, err
+	}
+
+	backupPath := filepath.Join(s.path(), filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(backupPath), directoryPermissions); err != nil {
+		return false, fmt.Errorf("failed to create backup directory for %s: %w", rel, err)
+	}
+	if err := os.WriteFile(backupPath, current, filePermissions); err != nil {
+		return false, fmt.Errorf("failed to back up %s: %w", rel, err)
+	}
+
+	s.manifest.Files = append(s.manifest.Files, rel)
+	slices.Sort(s.manifest.Files)
+	if err := writeManifest(s.path(), s.manifest); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ID returns the backup identifier, empty when nothing was backed up
+func (s *Session) ID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.manifest == nil {
+		return ""
+	}
+	return s.manifest.ID
+}
+
+// Files returns the backed up files relative to the project root
+func (s *Session) Files() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.manifest == nil {
+		return nil
+	}
+	return slices.Clone(s.manifest.Files)
+}
+
+// Path returns the backup directory, empty when nothing was backed up
+func (s *Session) Path() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.path()
+}
+
+// Discard removes the backup, e.g. when the files it protects were never overwritten
+func (s *Session) Discard() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.manifest == nil {
+		return nil
+	}
+	if err := os.RemoveAll(s.path()); err != nil {
+		return fmt.Errorf("failed to remove backup %s: %w", s.manifest.ID, err)
+	}
+	s.manifest = nil
+
+	// Leave no empty directories behind in projects the framework was never installed in
+	for _, dir := range []string{filepath.Join(s.frameworkDir, Dir), s.frameworkDir} {
+		if err := os.Remove(dir); err != nil {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (s *Session) path() string {
+	if s.manifest == nil {
+		return ""
+	}
+	return filepath.Join(s.frameworkDir, Dir, s.manifest.ID)
+}
+
+// loadLock reads the lockfile of the installation being overwritten once
+func (s *Session) loadLock() error {
+	if s.loaded {
+		return nil
+	}
+
+	lock, err := lockfile.Load(s.frameworkDir)
+	switch {
+	case errors.Is(err, lockfile.ErrNotFound):
+	case err != nil:
+		return err
+	default:
+		s.lock = lock
+	}
+	s.loaded = true
+
+	return nil
+}
+
+// start allocates a new timestamped backup directory
+func (s *Session) start() error {
+	if s.manifest != nil {
+		return nil
+	}
+
+	createdAt := s.now().UTC()
+	id := createdAt.Format(timestampFormat)
+	for n := 2; ; n++ {
+		if _, err := os.Stat(filepath.Join(s.frameworkDir, Dir, id)); os.IsNotExist(err) {
+			break
+		}
+		id = fmt.Sprintf("%s-%d", createdAt.Format(timestampFormat), n)
+	}
+
+	if err := os.MkdirAll(filepath.Join(s.frameworkDir, Dir, id), directoryPermissions); err != nil {
+		return fmt.Errorf("failed to create backup directory: %w", err)
+	}
+	s.manifest = &Manifest{ID: id, CreatedAt: createdAt, Reason: s.reason}
+
+	return nil
+}
+
+// List returns the backups of the framework directory, newest first
+func List(frameworkDir string) ([]Manifest, error) {
+	entries, err := os.ReadDir(filepath.Join(frameworkDir, Dir))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backups: %w", err)
+	}
+
+	var manifests []Manifest
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		manifest, err := Load(frameworkDir, entry.Name())
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		manifests = append(manifests, *manifest)
+	}
+
+	slices.SortFunc(manifests, func(a, b Manifest) int {
+		return strings.Compare(b.ID, a.ID)
+	})
+
+	return manifests, nil
+}
+
+// Load reads the manifest of a backup
+func Load(frameworkDir, id string) (*Manifest, error) {
+	if id == "" || id != filepath.Base(id) || strings.HasPrefix(id, ".") {
+		return nil, fmt.Errorf("invalid backup id %q", id)
+	}
+
+	data, err := os.ReadFile(filepath.Join(frameworkDir, Dir, id, ManifestFile))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backup %s: %w", id, err)
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse backup %s: %w", id, err)
+	}
+
+	return &manifest, nil
+}
+
+// Restore copies the files of a backup back into the project and returns them
+func Restore(projectDir, frameworkDir, id string) ([]string, error) {
+	manifest, err := Load(frameworkDir, id)
+	if err != nil {
+		return nil, err
+	}
+
+	backupDir := filepath.Join(frameworkDir, Dir, id)
+	for _, rel := range manifest.Files {
+		if !filepath.IsLocal(filepath.FromSlash(rel)) {
+			return nil, fmt.Errorf("backup %s contains invalid path %q", id, rel)
+		}
+
+		data, err := os.ReadFile(filepath.Join(backupDir, filepath.FromSlash(rel)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read backed up %s: %w", rel, err)
+		}
+
+		target := filepath.Join(projectDir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(target), directoryPermissions); err != nil {
+			return nil, fmt.Errorf("failed to create directory for %s: %w", rel, err)
+		}
+		if err := os.WriteFile(target, data, filePermissions); err != nil {
+			return nil, fmt.Errorf("failed to restore %s: %w", rel, err)
+		}
+	}
+
+	return manifest.Files, nil
+}
+
+func writeManifest(dir string, manifest *Manifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal backup manifest: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, ManifestFile), append(data, '\n'), filePermissions); err != nil {
+		return fmt.Errorf("failed to write backup manifest: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,158 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package backup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KubeRocketCI/kuberocketai/internal/lockfile"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func TestSessionProtect(t *testing.T) {
+	projectDir := t.TempDir()
+	frameworkDir := filepath.Join(projectDir, ".krci-ai")
+
+	lock := lockfile.New()
+	lock.SetFile(".krci-ai/agents/pristine.yaml", lockfile.Hash([]byte("original\n")))
+	lock.SetFile(".krci-ai/agents/edited.yaml", lockfile.Hash([]byte("original\n")))
+	writeFile(t, filepath.Join(frameworkDir, "agents", "pristine.yaml"), "original\n")
+	writeFile(t, filepath.Join(frameworkDir, "agents", "edited.yaml"), "edited\n")
+	writeFile(t, filepath.Join(frameworkDir, "agents", "untracked.yaml"), "untracked\n")
+	writeFile(t, filepath.Join(frameworkDir, "agents", "same.yaml"), "new\n")
+	writeFile(t, filepath.Join(projectDir, ".claude", "commands", "krci-ai", "pm.md"), "custom\n")
+	require.NoError(t, lock.Save(frameworkDir))
+
+	session := NewSession(projectDir, frameworkDir, "install --force")
+	session.now = func() time.Time { return time.Date(2025, 3, 4, 5, 6, 7, 0, time.UTC) }
+
+	tests := []struct {
+		name     string
+		target   string
+		backedUp bool
+	}{
+		{"matches lockfile", filepath.Join(frameworkDir, "agents", "pristine.yaml"), false},
+		{"modified since install", filepath.Join(frameworkDir, "agents", "edited.yaml"), true},
+		{"untracked and different", filepath.Join(frameworkDir, "agents", "untracked.yaml"), true},
+		{"already the new content", filepath.Join(frameworkDir, "agents", "same.yaml"), false},
+		{"missing file", filepath.Join(frameworkDir, "agents", "missing.yaml"), false},
+		{"ide file", filepath.Join(projectDir, ".claude", "commands", "krci-ai", "pm.md"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backedUp, err := session.Protect(tt.target, []byte("new\n"))
+			require.NoError(t, err)
+			assert.Equal(t, tt.backedUp, backedUp)
+		})
+	}
+
+	assert.Equal(t, "20250304-050607", session.ID())
+	assert.Equal(t, []string{
+		".claude/commands/krci-ai/pm.md",
+		".krci-ai/agents/edited.yaml",
+		".krci-ai/agents/untracked.yaml",
+	}, session.Files())
+
+	data, err := os.ReadFile(filepath.Join(session.Path(), ".krci-ai", "agents", "edited.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "edited\n", string(data))
+
+	outside := filepath.Join(t.TempDir(), "outside.md")
+	writeFile(t, outside, "outside\n")
+	_, err = session.Protect(outside, []byte("new\n"))
+	assert.Error(t, err)
+}
+
+func TestSessionWithoutChanges(t *testing.T) {
+	projectDir := t.TempDir()
+	frameworkDir := filepath.Join(projectDir, ".krci-ai")
+
+	session := NewSession(projectDir, frameworkDir, "unbundle")
+	backedUp, err := session.Protect(filepath.Join(frameworkDir, "agents", "pm.yaml"), []byte("new\n"))
+	require.NoError(t, err)
+	assert.False(t, backedUp)
+	assert.Empty(t, session.ID())
+	assert.NoDirExists(t, filepath.Join(frameworkDir, Dir))
+}
+
+func TestSessionDiscard(t *testing.T) {
+	projectDir := t.TempDir()
+	frameworkDir := filepath.Join(projectDir, ".krci-ai")
+	target := filepath.Join(projectDir, ".claude", "commands", "krci-ai", "pm.md")
+	writeFile(t, target, "custom\n")
+
+	session := NewSession(projectDir, frameworkDir, "install")
+	backedUp, err := session.Protect(target, []byte("new\n"))
+	require.NoError(t, err)
+	require.True(t, backedUp)
+
+	require.NoError(t, session.Discard())
+	assert.Empty(t, session.ID())
+	assert.Empty(t, session.Files())
+	assert.NoDirExists(t, frameworkDir)
+	assert.FileExists(t, target)
+
+	require.NoError(t, session.Discard())
+}
+
+func TestListAndRestore(t *testing.T) {
+	projectDir := t.TempDir()
+	frameworkDir := filepath.Join(projectDir, ".krci-ai")
+	target := filepath.Join(frameworkDir, "tasks", "create-prd.md")
+
+	for i, content := range []string{"first\n", "second\n"} {
+		writeFile(t, target, content)
+		session := NewSession(projectDir, frameworkDir, "unbundle")
+		session.now = func() time.Time { return time.Date(2025, 3, 4, 5, 6, 7+i, 0, time.UTC) }
+		_, err := session.Protect(target, []byte("overwritten\n"))
+		require.NoError(t, err)
+	}
+	writeFile(t, target, "overwritten\n")
+	require.NoError(t, os.MkdirAll(filepath.Join(frameworkDir, Dir, "stray"), 0755))
+
+	backups, err := List(frameworkDir)
+	require.NoError(t, err)
+	require.Len(t, backups, 2)
+	assert.Equal(t, "20250304-050608", backups[0].ID)
+	assert.Equal(t, "20250304-050607", backups[1].ID)
+	assert.Equal(t, "unbundle", backups[0].Reason)
+
+	files, err := Restore(projectDir, frameworkDir, "20250304-050607")
+	require.NoError(t, err)
+	assert.Equal(t, []string{".krci-ai/tasks/create-prd.md"}, files)
+
+	data, err := os.ReadFile(target)
+	require.NoError(t, err)
+	assert.Equal(t, "first\n", string(data))
+
+	_, err = Restore(projectDir, frameworkDir, "20990101-000000")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = Restore(projectDir, frameworkDir, "../agents")
+	assert.Error(t, err)
+}
@@ -19,6 +19,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+
+	"github.com/KubeRocketCI/kuberocketai/internal/backup"
 )
 
 // ExtractorStats holds statistics about the extraction process
@@ -26,17 +28,22 @@ type ExtractorStats struct {
 	FilesExtracted   int
 	FilesOverwritten int
 	DirsCreated      int
+	// FilesBackedUp counts locally modified files saved to BackupID before being overwritten
+	FilesBackedUp int
+	BackupID      string
 }
 
 // Extractor handles extracting files from a bundle to the filesystem
 type Extractor struct {
 	projectRoot string
+	backup      *backup.Session
 }
 
-// NewExtractor creates a new bundle extractor
-func NewExtractor(projectRoot string) *Extractor {
+// NewExtractor creates a new bundle extractor keeping backups in the given framework directory
+func NewExtractor(projectRoot, frameworkDir string) *Extractor {
 	return &Extractor{
 		projectRoot: projectRoot,
+		backup:      backup.NewSession(projectRoot, frameworkDir, "unbundle"),
 	}
 }
 
@@ -68,6 +75,16 @@ func (e *Extractor) Extract(files []ExtractedFile) (*ExtractorStats, error) {
 			stats.FilesOverwritten++
 		}
 
+		// Keep local modifications before overwriting them
+		backedUp, err := e.backup.Protect(fullPath, []byte(file.Content))
+		if err != nil {
+			return stats, err
+		}
+		if backedUp {
+			stats.FilesBackedUp++
+			stats.BackupID = e.backup.ID()
+		}
+
 		// Write file
 		if err := os.WriteFile(fullPath, []byte(file.Content), FilePermissions); err != nil {
 			return stats, fmt.Errorf("failed to write file %s: %w", fullPath, err)
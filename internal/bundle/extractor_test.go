@@ -28,7 +28,7 @@ func TestExtractor_Extract_Success(t *testing.T) {
 	// Create temp directory
 	tempDir := t.TempDir()
 
-	extractor := NewExtractor(tempDir)
+	extractor := NewExtractor(tempDir, filepath.Join(tempDir, ".krci-ai"))
 
 	files := []ExtractedFile{
 		{
@@ -76,7 +76,7 @@ func TestExtractor_Extract_Overwrite(t *testing.T) {
 		t.Fatalf("Failed to create existing file: %v", err)
 	}
 
-	extractor := NewExtractor(tempDir)
+	extractor := NewExtractor(tempDir, filepath.Join(tempDir, ".krci-ai"))
 
 	files := []ExtractedFile{
 		{
@@ -91,18 +91,30 @@ func TestExtractor_Extract_Overwrite(t *testing.T) {
 	// Verify stats - file should be counted as overwritten
 	assert.Equal(t, 0, stats.FilesExtracted, "Should not extract new files")
 	assert.Equal(t, 1, stats.FilesOverwritten, "Should overwrite 1 file")
+	assert.Equal(t, 1, stats.FilesBackedUp, "Should back up the modified file")
+	require.NotEmpty(t, stats.BackupID, "Should report the backup id")
 
 	// Verify file content was updated
 	content, err := os.ReadFile(existingFile)
 	require.NoError(t, err, "Should be able to read file")
 	assert.Equal(t, "new content", string(content), "File content should be updated")
+
+	// Verify the previous content was backed up
+	content, err = os.ReadFile(filepath.Join(tempDir, ".krci-ai", ".backup", stats.BackupID, ".krci-ai", "agents", "pm.yaml"))
+	require.NoError(t, err, "Should be able to read backup")
+	assert.Equal(t, "old content", string(content), "Backup should keep the previous content")
+
+	// Unchanged content is not backed up again
+	stats, err = NewExtractor(tempDir, filepath.Join(tempDir, ".krci-ai")).Extract(files)
+	require.NoError(t, err, "Extract should not return an error")
+	assert.Equal(t, 0, stats.FilesBackedUp, "Should not back up unchanged files")
 }
 
 func TestExtractor_Extract_NestedDirectories(t *testing.T) {
 	// Create temp directory
 	tempDir := t.TempDir()
 
-	extractor := NewExtractor(tempDir)
+	extractor := NewExtractor(tempDir, filepath.Join(tempDir, ".krci-ai"))
 
 	files := []ExtractedFile{
 		{
@@ -128,7 +140,7 @@ func TestExtractor_DryRun(t *testing.T) {
 	// Create temp directory
 	tempDir := t.TempDir()
 
-	extractor := NewExtractor(tempDir)
+	extractor := NewExtractor(tempDir, filepath.Join(tempDir, ".krci-ai"))
 
 	files := []ExtractedFile{
 		{
@@ -164,7 +176,7 @@ func TestExtractor_Extract_EmptyContent(t *testing.T) {
 	// Create temp directory
 	tempDir := t.TempDir()
 
-	extractor := NewExtractor(tempDir)
+	extractor := NewExtractor(tempDir, filepath.Join(tempDir, ".krci-ai"))
 
 	files := []ExtractedFile{
 		{
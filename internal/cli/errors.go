@@ -18,6 +18,8 @@ package cli
 import (
 	"fmt"
 	"os"
+	"slices"
+	"sync"
 
 	"github.com/KubeRocketCI/kuberocketai/internal/cli/style"
 )
@@ -28,10 +30,51 @@ type ErrorHandler struct{}
 // NewErrorHandler creates a new error handler with color support
 func NewErrorHandler() *ErrorHandler { return &ErrorHandler{} }
 
+var (
+	exitMu    sync.Mutex
+	exitHooks = make(map[int]func())
+	nextHook  int
+)
+
+// OnExit registers a function run before HandleError exits the process, e.g. to roll back
+// an installation in progress. The returned function unregisters it.
+func OnExit(fn func()) func() {
+	exitMu.Lock()
+	defer exitMu.Unlock()
+
+	id := nextHook
+	nextHook++
+	exitHooks[id] = fn
+
+	return func() {
+		exitMu.Lock()
+		defer exitMu.Unlock()
+		delete(exitHooks, id)
+	}
+}
+
+// RunExitHooks runs and unregisters the functions registered with OnExit, latest first
+func RunExitHooks() {
+	exitMu.Lock()
+	ids := make([]int, 0, len(exitHooks))
+	for id := range exitHooks {
+		ids = append(ids, id)
+	}
+	hooks := exitHooks
+	exitHooks = make(map[int]func())
+	exitMu.Unlock()
+
+	slices.Sort(ids)
+	for _, id := range slices.Backward(ids) {
+		hooks[id]()
+	}
+}
+
 // HandleError prints a colorized error message and exits with code 1
 func (e *ErrorHandler) HandleError(err error, message string) {
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "%s %s: %v\n", style.Error("❌"), message, err)
+		RunExitHooks()
 		os.Exit(1)
 	}
 }
@@ -40,6 +83,7 @@ func (e *ErrorHandler) HandleError(err error, message string) {
 func (e *ErrorHandler) HandleErrorWithCode(err error, message string, code int) {
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "%s %s: %v\n", style.Error("❌"), message, err)
+		RunExitHooks()
 		os.Exit(code)
 	}
 }
@@ -310,3 +310,18 @@ func BenchmarkPrintInstallationError(b *testing.B) {
 		handler.PrintInstallationError(testErr, "test phase")
 	}
 }
+
+// TestExitHooks tests that exit hooks run latest first and can be unregistered
+func TestExitHooks(t *testing.T) {
+	var calls []string
+	OnExit(func() { calls = append(calls, "first") })
+	unregister := OnExit(func() { calls = append(calls, "removed") })
+	OnExit(func() { calls = append(calls, "last") })
+	unregister()
+
+	RunExitHooks()
+	assert.Equal(t, []string{"last", "first"}, calls)
+
+	RunExitHooks()
+	assert.Len(t, calls, 2, "hooks run once")
+}
@@ -17,6 +17,7 @@ package cli
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/lipgloss/table"
@@ -37,38 +38,65 @@ const (
 )
 
 // OutputHandler provides colorized output functions for CLI commands
-type OutputHandler struct{}
+type OutputHandler struct {
+	// quiet suppresses informational output and moves warnings and errors to stderr
+	quiet bool
+}
 
 // NewOutputHandler creates a new output handler with color support
 func NewOutputHandler() *OutputHandler { return &OutputHandler{} }
 
+// NewQuietOutputHandler creates an output handler for commands writing machine readable
+// output to stdout: informational messages are suppressed, warnings and errors go to stderr
+func NewQuietOutputHandler() *OutputHandler { return &OutputHandler{quiet: true} }
+
 // PrintSuccess prints a success message with green color
 func (o *OutputHandler) PrintSuccess(message string) {
+	if o.quiet {
+		return
+	}
 	fmt.Printf("%s %s\n", style.Success("✅"), message)
 }
 
 // PrintInfo prints an info message with blue color
 func (o *OutputHandler) PrintInfo(message string) {
+	if o.quiet {
+		return
+	}
 	fmt.Printf("%s %s\n", style.Info("ℹ️"), message)
 }
 
 // PrintProgress prints a progress message with cyan color
 func (o *OutputHandler) PrintProgress(message string) {
+	if o.quiet {
+		return
+	}
 	fmt.Printf("%s %s\n", style.Progress("🔄"), message)
 }
 
 // PrintWarning prints a warning message with yellow color
 func (o *OutputHandler) PrintWarning(message string) {
+	if o.quiet {
+		fmt.Fprintf(os.Stderr, "%s %s\n", style.Warn("⚠️"), message)
+		return
+	}
 	fmt.Printf("%s %s\n", style.Warn("⚠️"), message)
 }
 
 // PrintError prints an error message with red color
 func (o *OutputHandler) PrintError(message string) {
+	if o.quiet {
+		fmt.Fprintf(os.Stderr, "%s %s\n", style.Error("❌"), message)
+		return
+	}
 	fmt.Printf("%s %s\n", style.Error("❌"), message)
 }
 
 // PrintBold prints text in bold
 func (o *OutputHandler) PrintBold(message string) {
+	if o.quiet {
+		return
+	}
 	fmt.Println(style.Bold(message))
 }
 
@@ -104,11 +132,17 @@ func (o *OutputHandler) PrintGreenBold(text string) string {
 
 // Printf provides formatted printing with style support
 func (o *OutputHandler) Printf(format string, args ...any) {
+	if o.quiet {
+		return
+	}
 	fmt.Printf(format, args...)
 }
 
 // Newline prints a newline character
 func (o *OutputHandler) Newline() {
+	if o.quiet {
+		return
+	}
 	fmt.Println()
 }
 
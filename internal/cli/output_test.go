@@ -17,9 +17,12 @@ package cli
 
 import (
 	"bytes"
+	"io"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewOutputHandler(t *testing.T) {
@@ -75,6 +78,27 @@ func TestOutputHandlerMessages(t *testing.T) {
 	_ = buf.String() // Consume any captured output
 }
 
+func TestQuietOutputHandler(t *testing.T) {
+	stdout := os.Stdout
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	os.Stdout = w
+
+	handler := NewQuietOutputHandler()
+	handler.PrintProgress("progress")
+	handler.PrintInfo("info")
+	handler.PrintSuccess("success")
+	handler.Printf("formatted %d\n", 1)
+	handler.Newline()
+
+	os.Stdout = stdout
+	require.NoError(t, w.Close())
+
+	captured, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Empty(t, string(captured), "quiet handler must keep stdout free for machine readable output")
+}
+
 func TestErrorHandler(t *testing.T) {
 	// Test that error handler can be created
 	handler := NewErrorHandler()
@@ -0,0 +1,377 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// UserConfigFile is the user level configuration file name located in the home directory
+	UserConfigFile = ".krci-ai.yaml"
+	// ProjectConfigFile is the project level configuration file name located in the framework directory
+	ProjectConfigFile = "config.yaml"
+
+	// ConfigPathEnv overrides the location of the user configuration file
+	ConfigPathEnv = "KRCI_AI_CONFIG"
+	envPrefix     = "KRCI_AI_"
+
+	filePermissions      = 0644
+	directoryPermissions = 0755
+)
+
+// Source identifies where an effective configuration value came from
+type Source string
+
+const (
+	SourceDefault Source = "default"
+	SourceUser    Source = "user"
+	SourceProject Source = "project"
+	SourceEnv     Source = "env"
+	SourceFlag    Source = "flag"
+)
+
+// Value is an effective configuration value together with its origin
+type Value struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Source Source `json:"source"`
+	// Origin is the file path, environment variable or flag that provided the value
+	Origin string `json:"origin,omitempty"`
+}
+
+// Config holds layered configuration values
+type Config struct {
+	values map[string]Value
+}
+
+// LoadOptions controls which configuration layers are read
+type LoadOptions struct {
+	// UserFile is the user configuration file, $HOME/.krci-ai.yaml when empty
+	UserFile string
+	// ProjectFile is the project configuration file, skipped when empty
+	ProjectFile string
+	// Getenv looks up environment variables, os.Getenv when nil
+	Getenv func(string) string
+}
+
+// GetProjectConfigPath returns the project configuration file path for the given framework directory
+func GetProjectConfigPath(frameworkDir string) string {
+	return filepath.Join(frameworkDir, ProjectConfigFile)
+}
+
+// GetUserConfigPath returns the user configuration file path.
+// KRCI_AI_CONFIG takes precedence over the default $HOME/.krci-ai.yaml location.
+func GetUserConfigPath() (string, error) {
+	if path := os.Getenv(ConfigPathEnv); path != "" {
+		return path, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user home directory: %w", err)
+	}
+
+	return filepath.Join(home, UserConfigFile), nil
+}
+
+// Load builds the effective configuration from built-in defaults, the user
+// configuration file, the project configuration file and environment variables.
+// Flags are applied afterwards by commands using BindFlag.
+func Load(opts LoadOptions) (*Config, error) {
+	getenv := opts.Getenv
+	if getenv == nil {
+		getenv = os.Getenv
+	}
+
+	cfg := &Config{values: make(map[string]Value, len(Keys))}
+	for _, key := range Keys {
+		cfg.values[key.Name] = Value{Key: key.Name, Value: key.Default, Source: SourceDefault}
+	}
+
+	userFile := opts.UserFile
+	if userFile == "" {
+		path, err := GetUserConfigPath()
+		if err != nil {
+			return nil, err
+		}
+		userFile = path
+	}
+
+	if err := cfg.loadFile(userFile, SourceUser); err != nil {
+		return nil, err
+	}
+
+	if opts.ProjectFile != "" {
+		if err := cfg.loadFile(opts.ProjectFile, SourceProject); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, key := range Keys {
+		envName := key.EnvName()
+		if raw := getenv(envName); raw != "" {
+			if err := cfg.set(key, raw, SourceEnv, envName); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return cfg, nil
+}
+
+// loadFile applies values from a configuration file, a missing file is skipped
+func (c *Config) loadFile(path string, source Source) error {
+	settings, err := readFile(path)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range Keys {
+		raw, ok := settings[key.Name]
+		if !ok {
+			continue
+		}
+
+		if err := c.set(key, raw, source, path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// set validates and stores a value for the key
+func (c *Config) set(key Key, raw string, source Source, origin string) error {
+	normalized, err := key.Normalize(raw)
+	if err != nil {
+		return fmt.Errorf("invalid value for %s from %s: %w", key.Name, origin, err)
+	}
+
+	c.values[key.Name] = Value{Key: key.Name, Value: normalized, Source: source, Origin: origin}
+	return nil
+}
+
+// BindFlag overrides the key with the flag value when the flag was set explicitly
+func (c *Config) BindFlag(name string, flags *pflag.FlagSet, flagName string) error {
+	key, err := LookupKey(name)
+	if err != nil {
+		return err
+	}
+
+	flag := flags.Lookup(flagName)
+	if flag == nil || !flag.Changed {
+		return nil
+	}
+
+	return c.set(key, flag.Value.String(), SourceFlag, "--"+flagName)
+}
+
+// Get returns the effective value for the key
+func (c *Config) Get(name string) (Value, error) {
+	if _, err := LookupKey(name); err != nil {
+		return Value{}, err
+	}
+
+	return c.values[name], nil
+}
+
+// Values returns all effective values in key order
+func (c *Config) Values() []Value {
+	result := make([]Value, 0, len(Keys))
+	for _, key := range Keys {
+		result = append(result, c.values[key.Name])
+	}
+
+	return result
+}
+
+// String returns the value for the key as a string
+func (c *Config) String(name string) string {
+	return c.values[name].Value
+}
+
+// List returns the value for the key as a list of strings
+func (c *Config) List(name string) []string {
+	return splitList(c.values[name].Value)
+}
+
+// Int returns the value for the key as an integer
+func (c *Config) Int(name string) int {
+	// Values are validated when they are set, so parsing cannot fail here
+	value, _ := strconv.Atoi(c.values[name].Value)
+	return value
+}
+
+// Bool returns the value for the key as a boolean
+func (c *Config) Bool(name string) bool {
+	value, _ := strconv.ParseBool(c.values[name].Value)
+	return value
+}
+
+// Duration returns the value for the key as a duration
+func (c *Config) Duration(name string) time.Duration {
+	value, _ := time.ParseDuration(c.values[name].Value)
+	return value
+}
+
+// SetInFile writes the key into the configuration file, creating the file if needed
+func SetInFile(path, name, raw string) error {
+	key, err := LookupKey(name)
+	if err != nil {
+		return err
+	}
+
+	normalized, err := key.Normalize(raw)
+	if err != nil {
+		return fmt.Errorf("invalid value for %s: %w", name, err)
+	}
+
+	document, err := readDocument(path)
+	if err != nil {
+		return err
+	}
+
+	setNested(document, strings.Split(name, "."), key.Encode(normalized))
+
+	data, err := yaml.Marshal(document)
+	if err != nil {
+		return fmt.Errorf("failed to encode configuration: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), directoryPermissions); err != nil {
+		return fmt.Errorf("failed to create configuration directory: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, filePermissions); err != nil {
+		return fmt.Errorf("failed to write configuration file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// readFile reads a configuration file and flattens it to dotted keys
+func readFile(path string) (map[string]string, error) {
+	document, err := readDocument(path)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := make(map[string]string)
+	flatten("", document, settings)
+
+	return settings, nil
+}
+
+// readDocument reads a YAML configuration document, a missing file yields an empty document
+func readDocument(path string) (map[string]any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]any{}, nil
+		}
+		return nil, fmt.Errorf("failed to read configuration file %s: %w", path, err)
+	}
+
+	document := map[string]any{}
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse configuration file %s: %w", path, err)
+	}
+
+	return document, nil
+}
+
+// flatten converts nested YAML maps into dotted keys
+func flatten(prefix string, node map[string]any, settings map[string]string) {
+	for name, value := range node {
+		key := name
+		if prefix != "" {
+			key = prefix + "." + name
+		}
+
+		switch typed := value.(type) {
+		case map[string]any:
+			flatten(key, typed, settings)
+		case []any:
+			items := make([]string, 0, len(typed))
+			for _, item := range typed {
+				items = append(items, fmt.Sprint(item))
+			}
+			settings[key] = strings.Join(items, ",")
+		case nil:
+			settings[key] = ""
+		default:
+			settings[key] = fmt.Sprint(typed)
+		}
+	}
+}
+
+// setNested sets a value in nested YAML maps, creating intermediate maps as needed
+func setNested(node map[string]any, path []string, value any) {
+	if len(path) == 1 {
+		node[path[0]] = value
+		return
+	}
+
+	child, ok := node[path[0]].(map[string]any)
+	if !ok {
+		child = map[string]any{}
+		node[path[0]] = child
+	}
+
+	setNested(child, path[1:], value)
+}
+
+// splitList splits a comma separated list, skipping empty items
+func splitList(raw string) []string {
+	var items []string
+	for item := range strings.SplitSeq(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// KeyNames returns the names of all supported keys
+func KeyNames() []string {
+	names := make([]string, 0, len(Keys))
+	for _, key := range Keys {
+		names = append(names, key.Name)
+	}
+
+	return names
+}
+
+// LookupKey returns the key definition by name
+func LookupKey(name string) (Key, error) {
+	index := slices.IndexFunc(Keys, func(key Key) bool { return key.Name == name })
+	if index == -1 {
+		return Key{}, fmt.Errorf("unknown configuration key %q (supported keys: %s)", name, strings.Join(KeyNames(), ", "))
+	}
+
+	return Keys[index], nil
+}
@@ -0,0 +1,211 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfig(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func noEnv(string) string { return "" }
+
+func TestLoad_Defaults(t *testing.T) {
+	dir := t.TempDir()
+
+	cfg, err := Load(LoadOptions{UserFile: filepath.Join(dir, "missing.yaml"), Getenv: noEnv})
+	require.NoError(t, err)
+
+	assert.Equal(t, ".krci-ai/bundle", cfg.String(KeyBundleOutputDir))
+	assert.Equal(t, "gpt-4", cfg.String(KeyTokensModel))
+	assert.Equal(t, 30*time.Second, cfg.Duration(KeyTokensTimeout))
+	assert.Equal(t, 2, cfg.Int(KeyUpdateRetries))
+	assert.Empty(t, cfg.List(KeyInstallAgents))
+
+	value, err := cfg.Get(KeyTokensModel)
+	require.NoError(t, err)
+	assert.Equal(t, SourceDefault, value.Source)
+	assert.Len(t, cfg.Values(), len(Keys))
+}
+
+func TestLoad_LayerPrecedence(t *testing.T) {
+	dir := t.TempDir()
+	userFile := filepath.Join(dir, "user.yaml")
+	projectFile := filepath.Join(dir, ".krci-ai", ProjectConfigFile)
+
+	writeConfig(t, userFile, `
+install:
+  ide: cursor
+  agents: [pm, architect]
+tokens:
+  model: gpt-4o
+  budget: 1000
+`)
+	writeConfig(t, projectFile, `
+install:
+  ide: claude
+tokens:
+  budget: 2000
+`)
+
+	env := map[string]string{"KRCI_AI_TOKENS_BUDGET": "3000"}
+	cfg, err := Load(LoadOptions{
+		UserFile:    userFile,
+		ProjectFile: projectFile,
+		Getenv:      func(name string) string { return env[name] },
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		key    string
+		value  string
+		source Source
+		origin string
+	}{
+		{KeyInstallAgents, "pm,architect", SourceUser, userFile},
+		{KeyTokensModel, "gpt-4o", SourceUser, userFile},
+		{KeyInstallIDE, "claude", SourceProject, projectFile},
+		{KeyTokensBudget, "3000", SourceEnv, "KRCI_AI_TOKENS_BUDGET"},
+		{KeyUpdateRetries, "2", SourceDefault, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			value, err := cfg.Get(tt.key)
+			require.NoError(t, err)
+			assert.Equal(t, tt.value, value.Value)
+			assert.Equal(t, tt.source, value.Source)
+			assert.Equal(t, tt.origin, value.Origin)
+		})
+	}
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.String("ide", "", "")
+	flags.Int("budget", 0, "")
+	require.NoError(t, flags.Parse([]string{"--ide", "windsurf"}))
+
+	require.NoError(t, cfg.BindFlag(KeyInstallIDE, flags, "ide"))
+	require.NoError(t, cfg.BindFlag(KeyTokensBudget, flags, "budget"))
+
+	value, err := cfg.Get(KeyInstallIDE)
+	require.NoError(t, err)
+	assert.Equal(t, "windsurf", value.Value)
+	assert.Equal(t, SourceFlag, value.Source)
+	assert.Equal(t, "--ide", value.Origin)
+
+	// Unchanged flags must not override lower layers
+	assert.Equal(t, 3000, cfg.Int(KeyTokensBudget))
+}
+
+func TestLoad_InvalidValues(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		content string
+		env     map[string]string
+		errMsg  string
+	}{
+		{
+			name:    "invalid integer in file",
+			content: "tokens:\n  budget: lots\n",
+			errMsg:  "invalid value for tokens.budget",
+		},
+		{
+			name:    "invalid duration in file",
+			content: "update:\n  timeout: forever\n",
+			errMsg:  "invalid value for update.timeout",
+		},
+		{
+			name:    "malformed yaml",
+			content: "tokens: [",
+			errMsg:  "failed to parse configuration file",
+		},
+		{
+			name:   "invalid integer in environment",
+			env:    map[string]string{"KRCI_AI_UPDATE_RETRIES": "-1"},
+			errMsg: "invalid value for update.retries",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userFile := filepath.Join(dir, tt.name+".yaml")
+			writeConfig(t, userFile, tt.content)
+
+			_, err := Load(LoadOptions{
+				UserFile: userFile,
+				Getenv:   func(name string) string { return tt.env[name] },
+			})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestSetInFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".krci-ai", ProjectConfigFile)
+
+	require.NoError(t, SetInFile(path, KeyInstallIDE, "claude"))
+	require.NoError(t, SetInFile(path, KeyInstallAgents, "pm, dev"))
+	require.NoError(t, SetInFile(path, KeyTokensBudget, "5000"))
+	require.NoError(t, SetInFile(path, KeyTokensTimeout, "1m"))
+
+	cfg, err := Load(LoadOptions{UserFile: filepath.Join(dir, "missing.yaml"), ProjectFile: path, Getenv: noEnv})
+	require.NoError(t, err)
+
+	assert.Equal(t, "claude", cfg.String(KeyInstallIDE))
+	assert.Equal(t, []string{"pm", "dev"}, cfg.List(KeyInstallAgents))
+	assert.Equal(t, 5000, cfg.Int(KeyTokensBudget))
+	assert.Equal(t, time.Minute, cfg.Duration(KeyTokensTimeout))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "budget: 5000")
+
+	t.Run("unknown key", func(t *testing.T) {
+		err := SetInFile(path, "install.editor", "vim")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown configuration key")
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		err := SetInFile(path, KeyUpdateRetries, "three")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid value for update.retries")
+	})
+}
+
+func TestKeyEnvName(t *testing.T) {
+	key, err := LookupKey(KeyBundleOutputDir)
+	require.NoError(t, err)
+	assert.Equal(t, "KRCI_AI_BUNDLE_OUTPUT_DIR", key.EnvName())
+
+	_, err = LookupKey("unknown")
+	assert.Error(t, err)
+}
@@ -0,0 +1,131 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Supported configuration keys
+const (
+	KeyInstallIDE      = "install.ide"
+	KeyInstallAgents   = "install.agents"
+	KeyInlineMaxTokens = "install.inline_max_tokens"
+	KeyBundleOutputDir = "bundle.output_dir"
+	KeyTokensModel     = "tokens.model"
+	KeyTokensBudget    = "tokens.budget"
+	KeyTokensTimeout   = "tokens.timeout"
+	KeyUpdateRetries   = "update.retries"
+	KeyUpdateTimeout   = "update.timeout"
+	KeyRegistryURLs    = "registry.urls"
+	KeyRegistryTTL     = "registry.cache_ttl"
+)
+
+// ValueType describes how a configuration value is parsed
+type ValueType string
+
+const (
+	TypeString   ValueType = "string"
+	TypeList     ValueType = "list"
+	TypeInt      ValueType = "int"
+	TypeBool     ValueType = "bool"
+	TypeDuration ValueType = "duration"
+)
+
+// Key describes a supported configuration key
+type Key struct {
+	Name        string
+	Type        ValueType
+	Default     string
+	Description string
+}
+
+// Keys lists all supported configuration keys with their built-in defaults
+var Keys = []Key{
+	{Name: KeyInstallIDE, Type: TypeString, Default: "", Description: "Default IDE integration for 'krci-ai install'"},
+	{Name: KeyInstallAgents, Type: TypeList, Default: "", Description: "Default agents for 'krci-ai install' (all agents when empty)"},
+	{Name: KeyInlineMaxTokens, Type: TypeInt, Default: "32000", Description: "Warn when an IDE file installed with --inline exceeds this many tokens, 0 disables the warning"},
+	{Name: KeyBundleOutputDir, Type: TypeString, Default: ".krci-ai/bundle", Description: "Bundle output directory relative to the project root"},
+	{Name: KeyTokensModel, Type: TypeString, Default: "gpt-4", Description: "Model used for token counting"},
+	{Name: KeyTokensBudget, Type: TypeInt, Default: "0", Description: "Token budget per agent, 0 disables the budget check"},
+	{Name: KeyTokensTimeout, Type: TypeDuration, Default: "30s", Description: "Timeout for token analysis"},
+	{Name: KeyUpdateRetries, Type: TypeInt, Default: "2", Description: "Retry attempts for update checks"},
+	{Name: KeyUpdateTimeout, Type: TypeDuration, Default: "10s", Description: "HTTP timeout for update checks"},
+	{Name: KeyRegistryURLs, Type: TypeList, Default: "", Description: "Pack registry URLs (http, https or file) serving index.json"},
+	{Name: KeyRegistryTTL, Type: TypeDuration, Default: "1h", Description: "How long cached registry indexes are used before refreshing"},
+}
+
+// EnvName returns the environment variable that overrides the key (e.g. KRCI_AI_INSTALL_IDE)
+func (k Key) EnvName() string {
+	replacer := strings.NewReplacer(".", "_", "-", "_")
+	return envPrefix + strings.ToUpper(replacer.Replace(k.Name))
+}
+
+// Normalize validates a raw value against the key type and returns its canonical form
+func (k Key) Normalize(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+
+	switch k.Type {
+	case TypeList:
+		return strings.Join(splitList(raw), ","), nil
+	case TypeInt:
+		value, err := strconv.Atoi(raw)
+		if err != nil {
+			return "", fmt.Errorf("expected integer, got %q", raw)
+		}
+		if value < 0 {
+			return "", fmt.Errorf("expected non-negative integer, got %d", value)
+		}
+		return strconv.Itoa(value), nil
+	case TypeBool:
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			return "", fmt.Errorf("expected boolean, got %q", raw)
+		}
+		return strconv.FormatBool(value), nil
+	case TypeDuration:
+		value, err := time.ParseDuration(raw)
+		if err != nil {
+			return "", fmt.Errorf("expected duration (e.g. 30s), got %q", raw)
+		}
+		return value.String(), nil
+	default:
+		return raw, nil
+	}
+}
+
+// Encode converts a normalized value into its YAML representation
+func (k Key) Encode(normalized string) any {
+	switch k.Type {
+	case TypeList:
+		items := splitList(normalized)
+		if items == nil {
+			return []string{}
+		}
+		return items
+	case TypeInt:
+		value, _ := strconv.Atoi(normalized)
+		return value
+	case TypeBool:
+		value, _ := strconv.ParseBool(normalized)
+		return value
+	default:
+		return normalized
+	}
+}
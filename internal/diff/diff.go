@@ -0,0 +1,256 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package diff
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+
+	"github.com/KubeRocketCI/kuberocketai/internal/assets"
+)
+
+// Status is how an installed file differs from the base version
+type Status string
+
+const (
+	// StatusAdded is a file that exists only in the installation
+	StatusAdded Status = "added"
+	// StatusRemoved is a file that exists only in the base version
+	StatusRemoved Status = "removed"
+	// StatusModified is a file whose content differs
+	StatusModified Status = "modified"
+)
+
+// Components are the framework directories that are compared
+var Components = []string{"agents", "tasks", "templates", "data"}
+
+// contextLines is the number of unchanged lines around each change in unified diffs
+const contextLines = 3
+
+// Tree is a framework directory in a filesystem
+type Tree struct {
+	FS assets.FileSystem
+	// Dir is the framework directory containing agents, tasks, templates and data
+	Dir string
+	// Label names the tree in diff headers, e.g. "embedded" or "installed"
+	Label string
+}
+
+// FileDiff describes a file that differs between two trees
+type FileDiff struct {
+	// Path is slash separated and relative to the framework directory
+	Path      string `json:"path"`
+	Component string `json:"component"`
+	Status    Status `json:"status"`
+	Additions int    `json:"additions"`
+	Deletions int    `json:"deletions"`
+	// Unified is the unified diff of the file
+	Unified string `json:"diff,omitempty"`
+}
+
+// Result lists the files that differ, sorted by path
+type Result struct {
+	Base      string     `json:"base"`
+	Installed string     `json:"installed"`
+	Files     []FileDiff `json:"files"`
+}
+
+// Summary counts the differing files per component and status
+func (r *Result) Summary() map[string]map[Status]int {
+	summary := make(map[string]map[Status]int, len(Components))
+	for _, component := range Components {
+		summary[component] = map[Status]int{StatusAdded: 0, StatusRemoved: 0, StatusModified: 0}
+	}
+	for _, file := range r.Files {
+		summary[file.Component][file.Status]++
+	}
+
+	return summary
+}
+
+// Agents returns the names of the agents with the given status
+func (r *Result) Agents(status Status) []string {
+	var names []string
+	for _, file := range r.Files {
+		if file.Component == "agents" && file.Status == status && path.Ext(file.Path) == ".yaml" {
+			names = append(names, strings.TrimSuffix(path.Base(file.Path), ".yaml"))
+		}
+	}
+
+	return names
+}
+
+// Compare diffs the installed tree against the base tree. When paths is not nil
+// only those files, relative to the framework directory, are compared.
+func Compare(base, installed Tree, paths map[string]struct{}) (*Result, error) {
+	baseFiles, err := listFiles(base)
+	if err != nil {
+		return nil, err
+	}
+	installedFiles, err := listFiles(installed)
+	if err != nil {
+		return nil, err
+	}
+
+	all := make(map[string]struct{}, len(baseFiles)+len(installedFiles))
+	maps.Copy(all, baseFiles)
+	maps.Copy(all, installedFiles)
+
+	result := &Result{Base: base.Label, Installed: installed.Label}
+	for _, rel := range slices.Sorted(maps.Keys(all)) {
+		if paths != nil {
+			if _, ok := paths[rel]; !ok {
+				continue
+			}
+		}
+
+		_, inBase := baseFiles[rel]
+		_, inInstalled := installedFiles[rel]
+
+		var before, after []byte
+		if inBase {
+			if before, err = readFile(base, rel); err != nil {
+				return nil, err
+			}
+		}
+		if inInstalled {
+			if after, err = readFile(installed, rel); err != nil {
+				return nil, err
+			}
+		}
+
+		file := FileDiff{Path: rel, Component: strings.SplitN(rel, "/", 2)[0]}
+		switch {
+		case !inBase:
+			file.Status = StatusAdded
+		case !inInstalled:
+			file.Status = StatusRemoved
+		case bytes.Equal(before, after):
+			continue
+		default:
+			file.Status = StatusModified
+		}
+
+		file.Unified, file.Additions, file.Deletions, err = unified(rel, before, after, base.Label, installed.Label)
+		if err != nil {
+			return nil, err
+		}
+		result.Files = append(result.Files, file)
+	}
+
+	return result, nil
+}
+
+// listFiles returns the files of the compared components relative to the framework directory
+func listFiles(tree Tree) (map[string]struct{}, error) {
+	files := make(map[string]struct{})
+
+	for _, component := range Components {
+		root := filepath.Join(tree.Dir, component)
+		err := tree.FS.WalkDir(root, func(p string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				if p == root && errors.Is(err, fs.ErrNotExist) {
+					return fs.SkipDir
+				}
+				return err
+			}
+			if entry.IsDir() {
+				return nil
+			}
+
+			rel, err := filepath.Rel(tree.Dir, filepath.FromSlash(p))
+			if err != nil {
+				return err
+			}
+			files[filepath.ToSlash(rel)] = struct{}{}
+
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s files of %s: %w", component, tree.Label, err)
+		}
+	}
+
+	return files, nil
+}
+
+func readFile(tree Tree, rel string) ([]byte, error) {
+	data, err := tree.FS.ReadFile(filepath.Join(tree.Dir, filepath.FromSlash(rel)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s of %s: %w", rel, tree.Label, err)
+	}
+
+	return data, nil
+}
+
+// unified returns the unified diff of a file and its added and deleted line counts
+func unified(rel string, before, after []byte, baseLabel, installedLabel string) (string, int, int, error) {
+	a := splitLines(before)
+	b := splitLines(after)
+
+	additions, deletions := 0, 0
+	for _, op := range difflib.NewMatcher(a, b).GetOpCodes() {
+		switch op.Tag {
+		case 'r':
+			deletions += op.I2 - op.I1
+			additions += op.J2 - op.J1
+		case 'd':
+			deletions += op.I2 - op.I1
+		case 'i':
+			additions += op.J2 - op.J1
+		}
+	}
+
+	text, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        a,
+		B:        b,
+		FromFile: "a/" + assets.KrciAIDir + "/" + rel,
+		FromDate: baseLabel,
+		ToFile:   "b/" + assets.KrciAIDir + "/" + rel,
+		ToDate:   installedLabel,
+		Context:  contextLines,
+	})
+	if err != nil {
+		return "", 0, 0, fmt.Errorf("failed to diff %s: %w", rel, err)
+	}
+
+	return text, additions, deletions, nil
+}
+
+// splitLines splits content into lines keeping line endings, terminating the last line
+func splitLines(content []byte) []string {
+	if len(content) == 0 {
+		return nil
+	}
+
+	lines := strings.SplitAfter(string(content), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	} else {
+		lines[len(lines)-1] += "\n"
+	}
+
+	return lines
+}
@@ -0,0 +1,112 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package diff
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KubeRocketCI/kuberocketai/internal/assets"
+)
+
+func writeTree(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for path, content := range files {
+		target := filepath.Join(dir, filepath.FromSlash(path))
+		require.NoError(t, os.MkdirAll(filepath.Dir(target), 0755))
+		require.NoError(t, os.WriteFile(target, []byte(content), 0644))
+	}
+
+	return dir
+}
+
+func TestCompare(t *testing.T) {
+	base := Tree{FS: assets.OSFileSystem{}, Label: "embedded", Dir: writeTree(t, map[string]string{
+		"agents/pm.yaml":        "name: pm\nrole: PM\n",
+		"agents/qa.yaml":        "name: qa\n",
+		"tasks/create-prd.md":   "one\ntwo\nthree\n",
+		"templates/prd.md":      "same\n",
+		"data/standards.md":     "data\n",
+		"krci-ai.lock":          "ignored",
+		"bundle/all.md":         "ignored",
+		"templates/nested/x.md": "x",
+	})}
+	installed := Tree{FS: assets.OSFileSystem{}, Label: "installed", Dir: writeTree(t, map[string]string{
+		"agents/pm.yaml":        "name: pm\nrole: Product Manager\n",
+		"agents/custom.yaml":    "name: custom\n",
+		"tasks/create-prd.md":   "one\ntwo\nthree\n",
+		"templates/prd.md":      "same\n",
+		"templates/nested/x.md": "y",
+		"krci-ai.lock":          "different",
+	})}
+
+	result, err := Compare(base, installed, nil)
+	require.NoError(t, err)
+
+	statuses := make(map[string]Status)
+	for _, file := range result.Files {
+		statuses[file.Path] = file.Status
+	}
+	assert.Equal(t, map[string]Status{
+		"agents/custom.yaml":    StatusAdded,
+		"agents/pm.yaml":        StatusModified,
+		"agents/qa.yaml":        StatusRemoved,
+		"data/standards.md":     StatusRemoved,
+		"templates/nested/x.md": StatusModified,
+	}, statuses)
+
+	assert.Equal(t, []string{"custom"}, result.Agents(StatusAdded))
+	assert.Equal(t, []string{"qa"}, result.Agents(StatusRemoved))
+	assert.Equal(t, []string{"pm"}, result.Agents(StatusModified))
+
+	summary := result.Summary()
+	assert.Equal(t, 1, summary["agents"][StatusAdded])
+	assert.Equal(t, 1, summary["data"][StatusRemoved])
+	assert.Equal(t, 0, summary["tasks"][StatusModified])
+
+	pm := result.Files[1]
+	require.Equal(t, "agents/pm.yaml", pm.Path)
+	assert.Equal(t, 1, pm.Additions)
+	assert.Equal(t, 1, pm.Deletions)
+	assert.Contains(t, pm.Unified, "--- a/.krci-ai/agents/pm.yaml\tembedded\n")
+	assert.Contains(t, pm.Unified, "+++ b/.krci-ai/agents/pm.yaml\tinstalled\n")
+	assert.Contains(t, pm.Unified, "-role: PM\n+role: Product Manager\n")
+
+	// The nested template has no trailing newline on either side
+	assert.Contains(t, result.Files[4].Unified, "-x\n+y\n")
+}
+
+func TestCompare_Paths(t *testing.T) {
+	base := Tree{FS: assets.OSFileSystem{}, Label: "embedded", Dir: writeTree(t, map[string]string{
+		"agents/pm.yaml": "a\n",
+		"agents/qa.yaml": "a\n",
+	})}
+	installed := Tree{FS: assets.OSFileSystem{}, Label: "installed", Dir: writeTree(t, map[string]string{
+		"agents/pm.yaml": "b\n",
+		"agents/qa.yaml": "b\n",
+	})}
+
+	result, err := Compare(base, installed, map[string]struct{}{"agents/pm.yaml": {}})
+	require.NoError(t, err)
+	require.Len(t, result.Files, 1)
+	assert.Equal(t, "agents/pm.yaml", result.Files[0].Path)
+}
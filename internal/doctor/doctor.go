@@ -0,0 +1,340 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package doctor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/KubeRocketCI/kuberocketai/internal/assets"
+	"github.com/KubeRocketCI/kuberocketai/internal/lockfile"
+	"github.com/KubeRocketCI/kuberocketai/internal/version"
+)
+
+// Status is the outcome of a check
+type Status string
+
+const (
+	StatusPass Status = "pass"
+	StatusWarn Status = "warn"
+	StatusFail Status = "fail"
+)
+
+// Check is the result of a single diagnostic
+type Check struct {
+	Name    string `json:"name"`
+	Status  Status `json:"status"`
+	Message string `json:"message"`
+	// Hint tells how to fix a warning or failure
+	Hint string `json:"hint,omitempty"`
+}
+
+// Report lists the results of all diagnostics
+type Report struct {
+	Checks []Check `json:"checks"`
+}
+
+// Count returns the number of checks with the given status
+func (r *Report) Count(status Status) int {
+	count := 0
+	for _, check := range r.Checks {
+		if check.Status == status {
+			count++
+		}
+	}
+
+	return count
+}
+
+func (r *Report) add(name string, status Status, message, hint string) {
+	r.Checks = append(r.Checks, Check{Name: name, Status: status, Message: message, Hint: hint})
+}
+
+// Options describes the environment to diagnose
+type Options struct {
+	ProjectDir string
+	// ProjectDirEnv is the value of KRCI_AI_PROJECT_DIR, empty when not set
+	ProjectDirEnv string
+	// WorkingDir is the current working directory
+	WorkingDir string
+	// HomeDir is used to find user level MCP server configurations, skipped when empty
+	HomeDir    string
+	CLIVersion string
+}
+
+// Run performs all diagnostics
+func Run(opts Options) *Report {
+	report := &Report{}
+
+	if !checkProjectRoot(report, opts) {
+		return report
+	}
+
+	frameworkDir := assets.GetKrciPath(opts.ProjectDir)
+	installer := assets.NewInstallerFromSource(opts.ProjectDir, assets.OSFileSystem{}, frameworkDir, assets.NewDiscovery(frameworkDir))
+
+	if !installer.IsInstalled() {
+		report.add("framework", StatusFail, fmt.Sprintf("framework is not installed in %s", opts.ProjectDir), "run 'krci-ai install'")
+		return report
+	}
+
+	agents, err := assets.NewDiscovery(frameworkDir).GetAgents(context.Background())
+	switch {
+	case err != nil:
+		report.add("framework", StatusFail, fmt.Sprintf("failed to load agents: %v", err), "run 'krci-ai validate' for details")
+	case len(agents) == 0:
+		report.add("framework", StatusFail, "framework is installed but contains no agents", "run 'krci-ai install --force'")
+	default:
+		report.add("framework", StatusPass, fmt.Sprintf("%d agents installed in %s", len(agents), frameworkDir), "")
+	}
+
+	lock := checkLockfile(report, frameworkDir, opts)
+	checkIDEs(report, installer, lock)
+	checkMCPServers(report, agents, opts)
+
+	return report
+}
+
+// checkProjectRoot verifies the resolved project root and reports whether the other checks can run
+func checkProjectRoot(report *Report, opts Options) bool {
+	if opts.ProjectDirEnv != "" {
+		info, err := os.Stat(opts.ProjectDirEnv)
+		if err != nil || !info.IsDir() {
+			report.add("project-root", StatusFail, fmt.Sprintf("KRCI_AI_PROJECT_DIR=%s is not a directory", opts.ProjectDirEnv),
+				"point KRCI_AI_PROJECT_DIR to the project root or unset it")
+			return false
+		}
+		report.add("project-root", StatusPass, fmt.Sprintf("%s (from KRCI_AI_PROJECT_DIR)", opts.ProjectDir), "")
+		return true
+	}
+
+	if _, err := os.Stat(assets.GetKrciPath(opts.ProjectDir)); os.IsNotExist(err) {
+		if parent := findInstalledParent(opts.WorkingDir); parent != "" {
+			report.add("project-root", StatusWarn, fmt.Sprintf("%s has no %s directory, but %s has", opts.ProjectDir, assets.KrciAIDir, parent),
+				fmt.Sprintf("run krci-ai from %s or set KRCI_AI_PROJECT_DIR=%s", parent, parent))
+			return true
+		}
+	}
+
+	report.add("project-root", StatusPass, fmt.Sprintf("%s (current directory)", opts.ProjectDir), "")
+	return true
+}
+
+// findInstalledParent returns the closest parent directory containing a framework installation
+func findInstalledParent(dir string) string {
+	if dir == "" {
+		return ""
+	}
+
+	for parent := filepath.Dir(dir); parent != dir; dir, parent = parent, filepath.Dir(parent) {
+		if info, err := os.Stat(assets.GetKrciPath(parent)); err == nil && info.IsDir() {
+			return parent
+		}
+	}
+
+	return ""
+}
+
+// checkLockfile compares the lockfile with the running CLI and the installed files
+func checkLockfile(report *Report, frameworkDir string, opts Options) *lockfile.Lockfile {
+	lock, err := lockfile.Load(frameworkDir)
+	if errors.Is(err, lockfile.ErrNotFound) {
+		report.add("lockfile", StatusWarn, "no lockfile, installed files are not tracked", "run 'krci-ai install --force' to record the installation")
+		return nil
+	}
+	if err != nil {
+		report.add("lockfile", StatusFail, err.Error(), "run 'krci-ai install --force' to rewrite the lockfile")
+		return nil
+	}
+	report.add("lockfile", StatusPass, fmt.Sprintf("%d files tracked", len(lock.Files)), "")
+
+	checkCLIVersion(report, lock.CLI.Version, opts.CLIVersion)
+
+	modified, err := lock.Modified(opts.ProjectDir)
+	switch {
+	case err != nil:
+		report.add("local-changes", StatusFail, err.Error(), "")
+	case len(modified) > 0:
+		report.add("local-changes", StatusWarn, fmt.Sprintf("%d installed files were changed or removed locally", len(modified)),
+			"run 'krci-ai diff' to review them; 'krci-ai upgrade' keeps them when upgrading")
+	default:
+		report.add("local-changes", StatusPass, "installed files match the lockfile", "")
+	}
+
+	return lock
+}
+
+// checkCLIVersion compares the CLI version recorded at install time with the running one
+func checkCLIVersion(report *Report, installed, running string) {
+	if installed == running {
+		report.add("cli-version", StatusPass, fmt.Sprintf("installed with the running version %s", running), "")
+		return
+	}
+
+	newer, err := version.CompareVersions(installed, running)
+	switch {
+	case err != nil:
+		report.add("cli-version", StatusWarn, fmt.Sprintf("installed with krci-ai %s, running %s", installed, running),
+			"run 'krci-ai upgrade' to align the framework with the running CLI")
+	case newer:
+		report.add("cli-version", StatusWarn, fmt.Sprintf("installed with older krci-ai %s, running %s", installed, running),
+			"run 'krci-ai upgrade' to get the framework shipped with this CLI")
+	default:
+		report.add("cli-version", StatusWarn, fmt.Sprintf("installed with newer krci-ai %s, running %s", installed, running),
+			"run 'krci-ai check-updates' and update the CLI")
+	}
+}
+
+// checkIDEs verifies that IDE integrations exist and match the installed agents
+func checkIDEs(report *Report, installer *assets.Installer, lock *lockfile.Lockfile) {
+	registry, err := installer.IDERegistry()
+	if err != nil {
+		report.add("ide", StatusFail, fmt.Sprintf("failed to load IDE integrations: %v", err),
+			"fix or remove the descriptor in .krci-ai/ide")
+		return
+	}
+
+	// IDEs recorded in the lockfile whose custom descriptor was removed
+	if lock != nil {
+		for _, ide := range lock.IDEs {
+			if _, ok := registry.Get(ide); !ok {
+				report.add("ide-"+ide, StatusFail, fmt.Sprintf("%s integration is recorded in the lockfile but not registered", ide),
+					"restore its descriptor in .krci-ai/ide or run 'krci-ai uninstall --ide "+ide+"'")
+			}
+		}
+	}
+
+	checked := 0
+	for _, ide := range registry.Names() {
+		name := "ide-" + ide
+		if !installer.HasIDE(ide) {
+			continue
+		}
+		checked++
+		if _, err := os.Stat(installer.IDEPath(ide)); err != nil {
+			report.add(name, StatusFail, fmt.Sprintf("%s integration is recorded in the lockfile but its directory is missing", ide),
+				fmt.Sprintf("run 'krci-ai install --ide %s'", ide))
+			continue
+		}
+
+		plan, err := installer.PlanInstall(assets.PlanOptions{SkipFramework: true, IDEs: []string{ide}})
+		if err != nil {
+			report.add(name, StatusFail, fmt.Sprintf("failed to render %s files: %v", ide, err), "run 'krci-ai validate' for details")
+			continue
+		}
+
+		outOfSync := plan.Count(assets.PlanCreate) + plan.Count(assets.PlanOverwrite) + plan.Count(assets.PlanRemove)
+		if outOfSync > 0 {
+			report.add(name, StatusWarn, fmt.Sprintf("%d of %d %s files are out of sync with the installed agents", outOfSync, len(plan.Files), ide),
+				"run 'krci-ai install --sync-ide'")
+			continue
+		}
+		report.add(name, StatusPass, fmt.Sprintf("%d %s files in sync", len(plan.Files), ide), "")
+	}
+
+	if checked == 0 {
+		report.add("ide", StatusWarn, "no IDE integration installed", "run 'krci-ai list ides' and 'krci-ai install --ide <name>'")
+	}
+}
+
+// checkMCPServers verifies that MCP servers required by installed tasks are configured
+func checkMCPServers(report *Report, agents []assets.Agent, opts Options) {
+	required := make(map[string][]string)
+	for _, agent := range agents {
+		for _, task := range agent.Tasks {
+			for _, server := range task.Dependencies.McpServers {
+				if !slices.Contains(required[server], agent.ShortName) {
+					required[server] = append(required[server], agent.ShortName)
+				}
+			}
+		}
+	}
+
+	if len(required) == 0 {
+		report.add("mcp-servers", StatusPass, "no MCP servers required by installed tasks", "")
+		return
+	}
+
+	configured := configuredMCPServers(mcpConfigPaths(opts))
+	var missing []string
+	for _, server := range slices.Sorted(maps.Keys(required)) {
+		if !configured[server] {
+			missing = append(missing, fmt.Sprintf("%s (used by %s)", server, strings.Join(required[server], ", ")))
+		}
+	}
+
+	if len(missing) > 0 {
+		report.add("mcp-servers", StatusWarn, fmt.Sprintf("MCP servers not configured: %s", strings.Join(missing, "; ")),
+			"add them to .mcp.json, .cursor/mcp.json or .vscode/mcp.json, or to your IDE's MCP settings")
+		return
+	}
+	report.add("mcp-servers", StatusPass, fmt.Sprintf("%d required MCP servers configured", len(required)), "")
+}
+
+// mcpConfigPaths returns the known project and user level MCP configuration files
+func mcpConfigPaths(opts Options) []string {
+	paths := []string{
+		filepath.Join(opts.ProjectDir, ".mcp.json"),
+		filepath.Join(opts.ProjectDir, ".cursor", "mcp.json"),
+		filepath.Join(opts.ProjectDir, ".vscode", "mcp.json"),
+	}
+
+	if opts.HomeDir != "" {
+		paths = append(paths,
+			filepath.Join(opts.HomeDir, ".claude.json"),
+			filepath.Join(opts.HomeDir, ".cursor", "mcp.json"),
+			filepath.Join(opts.HomeDir, ".codeium", "windsurf", "mcp_config.json"),
+		)
+	}
+
+	return paths
+}
+
+// configuredMCPServers returns the server names defined in the given MCP configuration files.
+// Missing or unreadable files are ignored.
+func configuredMCPServers(paths []string) map[string]bool {
+	servers := make(map[string]bool)
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+
+		var config struct {
+			MCPServers map[string]json.RawMessage `json:"mcpServers"`
+			Servers    map[string]json.RawMessage `json:"servers"`
+		}
+		if err := json.Unmarshal(data, &config); err != nil {
+			continue
+		}
+
+		for name := range config.MCPServers {
+			servers[name] = true
+		}
+		for name := range config.Servers {
+			servers[name] = true
+		}
+	}
+
+	return servers
+}
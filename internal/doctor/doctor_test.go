@@ -0,0 +1,160 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KubeRocketCI/kuberocketai/internal/lockfile"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+
+	for path, content := range files {
+		target := filepath.Join(dir, filepath.FromSlash(path))
+		require.NoError(t, os.MkdirAll(filepath.Dir(target), 0755))
+		require.NoError(t, os.WriteFile(target, []byte(content), 0644))
+	}
+}
+
+// project creates a framework with an agent whose task requires an MCP server
+func project(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		".krci-ai/agents/tw.yaml":  "agent:\n  identity:\n    name: Writer\n  tasks:\n    - ./.krci-ai/tasks/review.md\n",
+		".krci-ai/tasks/review.md": "---\ndependencies:\n  mcp_servers:\n    - office-powerpoint\n---\n# Review\n",
+	})
+
+	return dir
+}
+
+func statuses(report *Report) map[string]Status {
+	statuses := make(map[string]Status)
+	for _, check := range report.Checks {
+		statuses[check.Name] = check.Status
+	}
+	return statuses
+}
+
+func TestRun(t *testing.T) {
+	t.Run("not installed", func(t *testing.T) {
+		report := Run(Options{ProjectDir: t.TempDir(), CLIVersion: "1.0.0"})
+		assert.Equal(t, StatusFail, statuses(report)["framework"])
+		assert.Equal(t, 1, report.Count(StatusFail))
+	})
+
+	t.Run("missing project dir from environment", func(t *testing.T) {
+		missing := filepath.Join(t.TempDir(), "missing")
+		report := Run(Options{ProjectDir: missing, ProjectDirEnv: missing})
+		require.Len(t, report.Checks, 1)
+		assert.Equal(t, StatusFail, report.Checks[0].Status)
+		assert.NotEmpty(t, report.Checks[0].Hint)
+	})
+
+	t.Run("installed without lockfile, IDE or MCP servers", func(t *testing.T) {
+		report := Run(Options{ProjectDir: project(t), CLIVersion: "1.0.0"})
+		assert.Equal(t, map[string]Status{
+			"project-root": StatusPass,
+			"framework":    StatusPass,
+			"lockfile":     StatusWarn,
+			"ide":          StatusWarn,
+			"mcp-servers":  StatusWarn,
+		}, statuses(report))
+		assert.Zero(t, report.Count(StatusFail))
+	})
+
+	t.Run("recorded IDE integration missing", func(t *testing.T) {
+		dir := project(t)
+		writeFiles(t, dir, map[string]string{".github/prompts/release.prompt.md": "# Release\n"})
+		lock := lockfile.New()
+		lock.AddIDEs("claude")
+		require.NoError(t, lock.Save(filepath.Join(dir, ".krci-ai")))
+
+		checks := statuses(Run(Options{ProjectDir: dir, CLIVersion: "1.0.0"}))
+		assert.Equal(t, StatusFail, checks["ide-claude"])
+		assert.NotContains(t, checks, "ide-copilot")
+	})
+
+	t.Run("MCP server configured in project", func(t *testing.T) {
+		dir := project(t)
+		writeFiles(t, dir, map[string]string{".vscode/mcp.json": `{"servers": {"office-powerpoint": {}}}`})
+
+		report := Run(Options{ProjectDir: dir, CLIVersion: "1.0.0"})
+		assert.Equal(t, StatusPass, statuses(report)["mcp-servers"])
+	})
+}
+
+func TestCheckProjectRoot(t *testing.T) {
+	root := project(t)
+	nested := filepath.Join(root, "services", "api")
+	require.NoError(t, os.MkdirAll(nested, 0755))
+
+	report := &Report{}
+	assert.True(t, checkProjectRoot(report, Options{ProjectDir: nested, WorkingDir: nested}))
+	require.Len(t, report.Checks, 1)
+	assert.Equal(t, StatusWarn, report.Checks[0].Status)
+	assert.Contains(t, report.Checks[0].Hint, root)
+}
+
+func TestCheckCLIVersion(t *testing.T) {
+	tests := []struct {
+		name      string
+		installed string
+		running   string
+		want      Status
+		hint      string
+	}{
+		{name: "same", installed: "1.2.0", running: "1.2.0", want: StatusPass},
+		{name: "installed with older CLI", installed: "1.1.0", running: "1.2.0", want: StatusWarn, hint: "upgrade"},
+		{name: "installed with newer CLI", installed: "1.3.0", running: "1.2.0", want: StatusWarn, hint: "check-updates"},
+		{name: "development build", installed: "1.2.0", running: "dev", want: StatusWarn, hint: "upgrade"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := &Report{}
+			checkCLIVersion(report, tt.installed, tt.running)
+			require.Len(t, report.Checks, 1)
+			assert.Equal(t, tt.want, report.Checks[0].Status)
+			assert.Contains(t, report.Checks[0].Hint, tt.hint)
+		})
+	}
+}
+
+func TestConfiguredMCPServers(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		".mcp.json":        `{"mcpServers": {"github": {"command": "gh"}}}`,
+		".vscode/mcp.json": `{"servers": {"jira": {}}}`,
+		"broken.json":      `{`,
+	})
+
+	servers := configuredMCPServers([]string{
+		filepath.Join(dir, ".mcp.json"),
+		filepath.Join(dir, ".vscode", "mcp.json"),
+		filepath.Join(dir, "broken.json"),
+		filepath.Join(dir, "missing.json"),
+	})
+	assert.Equal(t, map[string]bool{"github": true, "jira": true}, servers)
+}
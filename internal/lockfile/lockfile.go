@@ -0,0 +1,464 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package lockfile
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/KubeRocketCI/kuberocketai/internal/roomodes"
+	"github.com/KubeRocketCI/kuberocketai/internal/section"
+	"github.com/KubeRocketCI/kuberocketai/internal/version"
+)
+
+const (
+	// FileName is the lockfile name inside the framework directory
+	FileName = "krci-ai.lock"
+	// FormatVersion is the current lockfile format version
+	FormatVersion = 1
+
+	filePermissions = 0644
+)
+
+// ErrNotFound is returned when the project has no lockfile
+var ErrNotFound = errors.New("lockfile not found")
+
+// FileStatus describes the state of an installed file compared to the lockfile
+type FileStatus string
+
+const (
+	StatusUnchanged FileStatus = "unchanged"
+	StatusModified  FileStatus = "modified"
+	StatusMissing   FileStatus = "missing"
+)
+
+// CLIInfo records the CLI build that produced the installation
+type CLIInfo struct {
+	Version   string `yaml:"version" json:"version"`
+	Commit    string `yaml:"commit" json:"commit"`
+	Framework string `yaml:"framework" json:"framework"`
+}
+
+// Source types of installed framework files
+const (
+	SourceEmbedded = "embedded"
+	SourceGit      = "git"
+	SourceTarball  = "tarball"
+	SourceLocal    = "local"
+)
+
+// Source records where the installed framework files came from
+type Source struct {
+	Type string `yaml:"type" json:"type"`
+	URL  string `yaml:"url,omitempty" json:"url,omitempty"`
+	Ref  string `yaml:"ref,omitempty" json:"ref,omitempty"`
+	// Revision pins the fetched content: a commit for git sources, an archive SHA-256 for tarballs
+	Revision string `yaml:"revision,omitempty" json:"revision,omitempty"`
+}
+
+// Pack records an installed agent pack and where it was fetched from
+type Pack struct {
+	Name    string `yaml:"name" json:"name"`
+	Version string `yaml:"version" json:"version"`
+	Source  Source `yaml:"source" json:"source"`
+	// SHA256 is the checksum published by the registry the pack was verified against
+	SHA256 string `yaml:"sha256,omitempty" json:"sha256,omitempty"`
+}
+
+// File records an installed file and the SHA-256 of its original content
+type File struct {
+	// Path is slash separated and relative to the project root
+	Path   string `yaml:"path" json:"path"`
+	SHA256 string `yaml:"sha256" json:"sha256"`
+	// IDE is set for files generated by an IDE integration
+	IDE string `yaml:"ide,omitempty" json:"ide,omitempty"`
+	// Agents are the installed agents that need the file
+	Agents []string `yaml:"agents,omitempty" json:"agents,omitempty"`
+	// Section is set for files shared with the user where only the krci-ai managed
+	// section is installed, SHA256 is then the hash of that section
+	Section bool `yaml:"section,omitempty" json:"section,omitempty"`
+	// Modes is set for the Roo Code modes file shared with the user where only the krci-ai
+	// modes are installed, SHA256 is then the hash of those modes
+	Modes bool `yaml:"modes,omitempty" json:"modes,omitempty"`
+}
+
+// Matches reports whether the installed part of data is the one recorded: the managed section
+// of section files, the krci-ai modes of the modes file and the whole content otherwise
+func (f File) Matches(data []byte) bool {
+	switch {
+	case f.Section:
+		block, ok := section.Extract(data)
+		return ok && Hash(block) == f.SHA256
+	case f.Modes:
+		modes, ok := roomodes.Extract(data)
+		return ok && Hash(modes) == f.SHA256
+	default:
+		return Hash(data) == f.SHA256
+	}
+}
+
+// Lockfile records which components were installed and their original content hashes
+type Lockfile struct {
+	LockfileVersion int      `yaml:"lockfileVersion" json:"lockfileVersion"`
+	CLI             CLIInfo  `yaml:"cli" json:"cli"`
+	Source          *Source  `yaml:"source,omitempty" json:"source,omitempty"`
+	Packs           []Pack   `yaml:"packs,omitempty" json:"packs,omitempty"`
+	Agents          []string `yaml:"agents" json:"agents"`
+	IDEs            []string `yaml:"ides,omitempty" json:"ides,omitempty"`
+	Files           []File   `yaml:"files" json:"files"`
+	// Tasks lists the selected tasks of agents installed with --task, other agents have all their tasks
+	Tasks map[string][]string `yaml:"tasks,omitempty" json:"tasks,omitempty"`
+	// AgentSources records where agents installed from packs or other sources came from,
+	// agents of the framework embedded in the CLI have no entry
+	AgentSources map[string]Source `yaml:"agentSources,omitempty" json:"agentSources,omitempty"`
+}
+
+// FileCheck is the result of comparing an installed file with its recorded hash
+type FileCheck struct {
+	Path   string     `json:"path"`
+	Status FileStatus `json:"status"`
+}
+
+// New creates an empty lockfile for the running CLI version
+func New() *Lockfile {
+	info := version.GetVersionInfo()
+
+	return &Lockfile{
+		LockfileVersion: FormatVersion,
+		CLI: CLIInfo{
+			Version:   info.Version,
+			Commit:    info.Commit,
+			Framework: info.Framework,
+		},
+	}
+}
+
+// GetPath returns the lockfile path for the given framework directory
+func GetPath(frameworkDir string) string {
+	return filepath.Join(frameworkDir, FileName)
+}
+
+// Load reads the lockfile from the framework directory.
+// ErrNotFound is returned when the lockfile does not exist.
+func Load(frameworkDir string) (*Lockfile, error) {
+	path := GetPath(frameworkDir)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, path)
+		}
+		return nil, fmt.Errorf("failed to read lockfile %s: %w", path, err)
+	}
+
+	return Parse(path, data)
+}
+
+// Parse decodes the lockfile read from path
+func Parse(path string, data []byte) (*Lockfile, error) {
+	lock := &Lockfile{}
+	if err := yaml.Unmarshal(data, lock); err != nil {
+		return nil, fmt.Errorf("failed to parse lockfile %s: %w", path, err)
+	}
+
+	if lock.LockfileVersion > FormatVersion {
+		return nil, fmt.Errorf("lockfile %s has unsupported version %d (max supported: %d)", path, lock.LockfileVersion, FormatVersion)
+	}
+
+	return lock, nil
+}
+
+// Save writes the lockfile to the framework directory in a stable order
+func (l *Lockfile) Save(frameworkDir string) error {
+	l.normalize()
+
+	data, err := yaml.Marshal(l)
+	if err != nil {
+		return fmt.Errorf("failed to encode lockfile: %w", err)
+	}
+
+	path := GetPath(frameworkDir)
+	if err := os.WriteFile(path, data, filePermissions); err != nil {
+		return fmt.Errorf("failed to write lockfile %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// AddAgents records the given agents as installed
+func (l *Lockfile) AddAgents(names ...string) {
+	l.Agents = append(l.Agents, names...)
+	l.normalize()
+}
+
+// AddIDEs records the given IDE integrations as installed
+func (l *Lockfile) AddIDEs(names ...string) {
+	l.IDEs = append(l.IDEs, names...)
+	l.normalize()
+}
+
+// SetPack records or replaces an installed pack
+func (l *Lockfile) SetPack(pack Pack) {
+	for idx := range l.Packs {
+		if l.Packs[idx].Name == pack.Name {
+			l.Packs[idx] = pack
+			return
+		}
+	}
+
+	l.Packs = append(l.Packs, pack)
+	l.normalize()
+}
+
+// SetFile records or replaces the hash of an installed file
+func (l *Lockfile) SetFile(path, sha string) {
+	path = filepath.ToSlash(path)
+
+	for idx := range l.Files {
+		if l.Files[idx].Path == path {
+			l.Files[idx].SHA256 = sha
+			return
+		}
+	}
+
+	l.Files = append(l.Files, File{Path: path, SHA256: sha})
+}
+
+// AddFileOwners records the IDE integration and agents a file was installed for
+func (l *Lockfile) AddFileOwners(path, ide string, agents ...string) {
+	path = filepath.ToSlash(path)
+
+	for idx := range l.Files {
+		if l.Files[idx].Path != path {
+			continue
+		}
+
+		if ide != "" {
+			l.Files[idx].IDE = ide
+		}
+		owners := slices.Concat(l.Files[idx].Agents, agents)
+		slices.Sort(owners)
+		l.Files[idx].Agents = slices.Compact(owners)
+		return
+	}
+}
+
+// MarkSection records that only the managed section of a file is installed
+func (l *Lockfile) MarkSection(path string) {
+	path = filepath.ToSlash(path)
+
+	for idx := range l.Files {
+		if l.Files[idx].Path == path {
+			l.Files[idx].Section = true
+			return
+		}
+	}
+}
+
+// MarkModes records that only the krci-ai modes of the Roo Code modes file are installed
+func (l *Lockfile) MarkModes(path string) {
+	path = filepath.ToSlash(path)
+
+	for idx := range l.Files {
+		if l.Files[idx].Path == path {
+			l.Files[idx].Modes = true
+			return
+		}
+	}
+}
+
+// SetFileAgents replaces the agents that need a file
+func (l *Lockfile) SetFileAgents(path string, agents []string) {
+	path = filepath.ToSlash(path)
+
+	for idx := range l.Files {
+		if l.Files[idx].Path == path {
+			l.Files[idx].Agents = agents
+			return
+		}
+	}
+}
+
+// SetAgentTasks records the selected tasks of an agent, empty when all its tasks are installed
+func (l *Lockfile) SetAgentTasks(agent string, tasks []string) {
+	if len(tasks) == 0 {
+		delete(l.Tasks, agent)
+		return
+	}
+
+	if l.Tasks == nil {
+		l.Tasks = make(map[string][]string)
+	}
+	tasks = slices.Sorted(slices.Values(tasks))
+	l.Tasks[agent] = slices.Compact(tasks)
+}
+
+// SetAgentSource records where an agent was installed from, nil for the embedded framework
+func (l *Lockfile) SetAgentSource(agent string, source *Source) {
+	if source == nil {
+		delete(l.AgentSources, agent)
+		return
+	}
+
+	if l.AgentSources == nil {
+		l.AgentSources = make(map[string]Source)
+	}
+	l.AgentSources[agent] = *source
+}
+
+// RemoveAgents drops the given agents from the installed agents
+func (l *Lockfile) RemoveAgents(names ...string) {
+	l.Agents = slices.DeleteFunc(l.Agents, func(name string) bool {
+		return slices.Contains(names, name)
+	})
+	for _, name := range names {
+		delete(l.Tasks, name)
+		delete(l.AgentSources, name)
+	}
+}
+
+// RemoveIDEs drops the given IDE integrations from the installed IDEs
+func (l *Lockfile) RemoveIDEs(names ...string) {
+	l.IDEs = slices.DeleteFunc(l.IDEs, func(name string) bool {
+		return slices.Contains(names, name)
+	})
+}
+
+// GetFile returns the recorded entry for the path
+func (l *Lockfile) GetFile(path string) (File, bool) {
+	path = filepath.ToSlash(path)
+
+	for _, file := range l.Files {
+		if file.Path == path {
+			return file, true
+		}
+	}
+
+	return File{}, false
+}
+
+// RemoveFile drops the entry for the path from the lockfile
+func (l *Lockfile) RemoveFile(path string) {
+	path = filepath.ToSlash(path)
+
+	l.Files = slices.DeleteFunc(l.Files, func(file File) bool {
+		return file.Path == path
+	})
+}
+
+// Check compares every recorded file under projectDir with its recorded hash
+func (l *Lockfile) Check(projectDir string) ([]FileCheck, error) {
+	checks := make([]FileCheck, 0, len(l.Files))
+	for _, file := range l.Files {
+		status, err := CheckEntry(projectDir, file)
+		if err != nil {
+			return nil, err
+		}
+
+		checks = append(checks, FileCheck{Path: file.Path, Status: status})
+	}
+
+	return checks, nil
+}
+
+// Modified returns the recorded files that were changed or removed locally
+func (l *Lockfile) Modified(projectDir string) ([]FileCheck, error) {
+	checks, err := l.Check(projectDir)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.DeleteFunc(checks, func(check FileCheck) bool {
+		return check.Status == StatusUnchanged
+	}), nil
+}
+
+// CheckEntry compares a recorded file under projectDir with its recorded hash, only the managed
+// section counts for section files and only the krci-ai modes for the modes file
+func CheckEntry(projectDir string, file File) (FileStatus, error) {
+	path := filepath.Join(projectDir, filepath.FromSlash(file.Path))
+	if !file.Section && !file.Modes {
+		return CheckFile(path, file.SHA256)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return StatusMissing, nil
+		}
+		return "", fmt.Errorf("failed to read file %s: %w", path, err)
+	}
+
+	var found bool
+	if file.Section {
+		_, found = section.Extract(data)
+	} else {
+		_, found = roomodes.Extract(data)
+	}
+	switch {
+	case !found:
+		return StatusMissing, nil
+	case !file.Matches(data):
+		return StatusModified, nil
+	}
+
+	return StatusUnchanged, nil
+}
+
+// CheckFile compares a file on disk with the expected hash
+func CheckFile(path, expected string) (FileStatus, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return StatusMissing, nil
+		}
+		return "", fmt.Errorf("failed to read file %s: %w", path, err)
+	}
+
+	if Hash(data) != expected {
+		return StatusModified, nil
+	}
+
+	return StatusUnchanged, nil
+}
+
+// Hash returns the hex encoded SHA-256 of data
+func Hash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// normalize sorts and deduplicates entries so that the lockfile is reproducible
+func (l *Lockfile) normalize() {
+	slices.Sort(l.Agents)
+	l.Agents = slices.Compact(l.Agents)
+	slices.Sort(l.IDEs)
+	l.IDEs = slices.Compact(l.IDEs)
+	slices.SortFunc(l.Packs, func(a, b Pack) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	slices.SortFunc(l.Files, func(a, b File) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+}
@@ -0,0 +1,281 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package lockfile
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KubeRocketCI/kuberocketai/internal/section"
+	"github.com/KubeRocketCI/kuberocketai/internal/version"
+)
+
+func TestNew(t *testing.T) {
+	lock := New()
+
+	assert.Equal(t, FormatVersion, lock.LockfileVersion)
+	assert.Equal(t, version.Version, lock.CLI.Version)
+	assert.Equal(t, version.Commit, lock.CLI.Commit)
+	assert.Equal(t, version.Framework, lock.CLI.Framework)
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	lock := New()
+	lock.AddAgents("pm", "architect", "pm")
+	lock.AddIDEs("cursor")
+	lock.SetFile(".krci-ai/tasks/b.md", Hash([]byte("b")))
+	lock.SetFile(".krci-ai/agents/a.yaml", Hash([]byte("a")))
+	lock.SetFile(".krci-ai/tasks/b.md", Hash([]byte("b2")))
+	lock.SetPack(Pack{Name: "shared-standards", Version: "1.2.0", Source: Source{Type: SourceTarball}})
+	lock.SetPack(Pack{Name: "golden-agents", Version: "1.0.0", Source: Source{Type: SourceTarball}})
+	lock.SetPack(Pack{Name: "shared-standards", Version: "1.3.0", Source: Source{Type: SourceTarball}})
+	require.NoError(t, lock.Save(dir))
+
+	loaded, err := Load(dir)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"architect", "pm"}, loaded.Agents)
+	assert.Equal(t, []string{"cursor"}, loaded.IDEs)
+	require.Len(t, loaded.Packs, 2)
+	assert.Equal(t, "golden-agents", loaded.Packs[0].Name)
+	assert.Equal(t, "1.3.0", loaded.Packs[1].Version)
+	require.Len(t, loaded.Files, 2)
+	assert.Equal(t, ".krci-ai/agents/a.yaml", loaded.Files[0].Path)
+
+	file, ok := loaded.GetFile(".krci-ai/tasks/b.md")
+	require.True(t, ok)
+	assert.Equal(t, Hash([]byte("b2")), file.SHA256)
+
+	loaded.RemoveFile(".krci-ai/tasks/b.md")
+	_, ok = loaded.GetFile(".krci-ai/tasks/b.md")
+	assert.False(t, ok)
+}
+
+func TestLoad_Errors(t *testing.T) {
+	t.Run("missing lockfile", func(t *testing.T) {
+		_, err := Load(t.TempDir())
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrNotFound))
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(GetPath(dir), []byte("lockfileVersion: 99\n"), 0644))
+
+		_, err := Load(dir)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported version")
+	})
+
+	t.Run("malformed lockfile", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(GetPath(dir), []byte("files: ["), 0644))
+
+		_, err := Load(dir)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to parse lockfile")
+	})
+}
+
+func TestCheck(t *testing.T) {
+	projectDir := t.TempDir()
+
+	files := map[string]string{
+		".krci-ai/agents/pm.yaml": "original",
+		".krci-ai/tasks/task.md":  "original",
+		".krci-ai/data/data.md":   "original",
+	}
+
+	lock := New()
+	for path, content := range files {
+		fullPath := filepath.Join(projectDir, filepath.FromSlash(path))
+		require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0755))
+		require.NoError(t, os.WriteFile(fullPath, []byte(content), 0644))
+		lock.SetFile(path, Hash([]byte(content)))
+	}
+
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, ".krci-ai", "tasks", "task.md"), []byte("changed"), 0644))
+	require.NoError(t, os.Remove(filepath.Join(projectDir, ".krci-ai", "data", "data.md")))
+
+	checks, err := lock.Check(projectDir)
+	require.NoError(t, err)
+	assert.Len(t, checks, 3)
+
+	modified, err := lock.Modified(projectDir)
+	require.NoError(t, err)
+
+	statuses := make(map[string]FileStatus)
+	for _, check := range modified {
+		statuses[check.Path] = check.Status
+	}
+	assert.Equal(t, map[string]FileStatus{
+		".krci-ai/tasks/task.md": StatusModified,
+		".krci-ai/data/data.md":  StatusMissing,
+	}, statuses)
+}
+
+func TestCheckSection(t *testing.T) {
+	projectDir := t.TempDir()
+	path := filepath.Join(projectDir, "instructions.md")
+	block := section.Block([]byte("## Agents\n"))
+
+	lock := New()
+	lock.SetFile("instructions.md", Hash(block))
+	lock.MarkSection("instructions.md")
+	file, _ := lock.GetFile("instructions.md")
+
+	tests := []struct {
+		name    string
+		content string
+		want    FileStatus
+	}{
+		{name: "user content changed", content: "# Rules\n\n" + string(block) + "More rules\n", want: StatusUnchanged},
+		{name: "section changed", content: "# Rules\n\n" + string(section.Block([]byte("## Mine\n"))), want: StatusModified},
+		{name: "section removed", content: "# Rules\n", want: StatusMissing},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0644))
+
+			status, err := CheckEntry(projectDir, file)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, status)
+		})
+	}
+}
+
+func TestFileOwners(t *testing.T) {
+	lock := New()
+	lock.SetFile(".krci-ai/tasks/shared.md", "hash")
+	lock.SetFile(".claude/commands/krci-ai/pm.md", "hash")
+	lock.AddAgents("pm", "dev")
+	lock.AddIDEs("claude", "cursor")
+
+	lock.AddFileOwners(".krci-ai/tasks/shared.md", "", "pm")
+	lock.AddFileOwners(".krci-ai/tasks/shared.md", "", "dev", "pm")
+	lock.AddFileOwners(".claude/commands/krci-ai/pm.md", "claude", "pm")
+	lock.AddFileOwners(".krci-ai/missing.md", "", "pm")
+
+	file, ok := lock.GetFile(".krci-ai/tasks/shared.md")
+	require.True(t, ok)
+	assert.Equal(t, []string{"dev", "pm"}, file.Agents)
+	assert.Empty(t, file.IDE)
+
+	file, ok = lock.GetFile(".claude/commands/krci-ai/pm.md")
+	require.True(t, ok)
+	assert.Equal(t, "claude", file.IDE)
+	assert.Equal(t, []string{"pm"}, file.Agents)
+
+	lock.SetFileAgents(".krci-ai/tasks/shared.md", []string{"dev"})
+	file, _ = lock.GetFile(".krci-ai/tasks/shared.md")
+	assert.Equal(t, []string{"dev"}, file.Agents)
+
+	lock.RemoveAgents("pm")
+	lock.RemoveIDEs("cursor")
+	assert.Equal(t, []string{"dev"}, lock.Agents)
+	assert.Equal(t, []string{"claude"}, lock.IDEs)
+}
+
+func TestSetAgentTasks(t *testing.T) {
+	dir := t.TempDir()
+	lock := New()
+	lock.AddAgents("dev", "pm")
+	lock.SetAgentTasks("pm", []string{"update-prd", "create-prd", "create-prd"})
+	lock.SetAgentTasks("dev", nil)
+	require.NoError(t, lock.Save(dir))
+
+	loaded, err := Load(dir)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{"pm": {"create-prd", "update-prd"}}, loaded.Tasks)
+
+	// Installing all tasks of an agent or removing it clears the selection
+	loaded.SetAgentTasks("pm", nil)
+	assert.Empty(t, loaded.Tasks)
+	loaded.SetAgentTasks("pm", []string{"create-prd"})
+	loaded.RemoveAgents("pm")
+	assert.Empty(t, loaded.Tasks)
+}
+
+func TestSetAgentSource(t *testing.T) {
+	dir := t.TempDir()
+	lock := New()
+	lock.AddAgents("dev", "pm")
+	lock.SetAgentSource("dev", &Source{Type: SourceLocal, URL: "../golden-agents"})
+	lock.SetAgentSource("pm", nil)
+	require.NoError(t, lock.Save(dir))
+
+	loaded, err := Load(dir)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]Source{"dev": {Type: SourceLocal, URL: "../golden-agents"}}, loaded.AgentSources)
+
+	// Reinstalling an agent from the embedded framework or removing it clears its source
+	loaded.SetAgentSource("dev", nil)
+	assert.Empty(t, loaded.AgentSources)
+	loaded.SetAgentSource("pm", &Source{Type: SourceGit, URL: "https://example.com/packs.git"})
+	loaded.RemoveAgents("pm")
+	assert.Empty(t, loaded.AgentSources)
+}
+
+func TestObjects(t *testing.T) {
+	dir := t.TempDir()
+
+	hash, err := StoreObject(dir, []byte("original"))
+	require.NoError(t, err)
+	assert.Equal(t, Hash([]byte("original")), hash)
+
+	data, err := LoadObject(dir, hash)
+	require.NoError(t, err)
+	assert.Equal(t, "original", string(data))
+
+	_, err = LoadObject(dir, Hash([]byte("unknown")))
+	assert.ErrorIs(t, err, ErrObjectNotFound)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ObjectsDir, hash[:2], hash), []byte("tampered"), 0644))
+	_, err = LoadObject(dir, hash)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "corrupted")
+}
+
+func TestPruneObjects(t *testing.T) {
+	dir := t.TempDir()
+	kept, err := StoreObject(dir, []byte("installed"))
+	require.NoError(t, err)
+	pruned, err := StoreObject(dir, []byte("upgraded away"))
+	require.NoError(t, err)
+
+	lock := New()
+	lock.SetFile(".krci-ai/agents/pm.yaml", kept)
+	lock.SetFile(".krci-ai/agents/copy.yaml", kept)
+	lock.SetFile(".krci-ai/agents/untracked.yaml", Hash([]byte("never stored")))
+
+	require.NoError(t, PruneObjects(dir, lock))
+
+	_, err = LoadObject(dir, kept)
+	require.NoError(t, err)
+	_, err = LoadObject(dir, pruned)
+	require.ErrorIs(t, err, ErrObjectNotFound)
+	assert.NoDirExists(t, filepath.Join(dir, ObjectsDir, pruned[:2]))
+
+	require.NoError(t, PruneObjects(t.TempDir(), lock))
+}
@@ -0,0 +1,120 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package lockfile
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ObjectsDir holds the original content of installed files inside the framework directory,
+// addressed by the SHA-256 recorded in the lockfile. It is the merge base for upgrades.
+const ObjectsDir = ".objects"
+
+// ErrObjectNotFound is returned when the original content of a hash was not stored
+var ErrObjectNotFound = errors.New("original content not found")
+
+const directoryPermissions = 0755
+
+// objectPath returns the path of the object with the given hash
+func objectPath(frameworkDir, hash string) string {
+	if len(hash) < 2 {
+		return filepath.Join(frameworkDir, ObjectsDir, hash)
+	}
+
+	return filepath.Join(frameworkDir, ObjectsDir, hash[:2], hash)
+}
+
+// StoreObject saves data in the object store of the framework directory and returns its hash
+func StoreObject(frameworkDir string, data []byte) (string, error) {
+	hash := Hash(data)
+	path := objectPath(frameworkDir, hash)
+
+	if _, err := os.Stat(path); err == nil {
+		return hash, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), directoryPermissions); err != nil {
+		return "", fmt.Errorf("failed to create object directory: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, filePermissions); err != nil {
+		return "", fmt.Errorf("failed to write object %s: %w", hash, err)
+	}
+
+	return hash, nil
+}
+
+// LoadObject returns the stored content for the hash.
+// ErrObjectNotFound is returned when it was never stored, e.g. for installs made by older CLI versions.
+func LoadObject(frameworkDir, hash string) ([]byte, error) {
+	data, err := os.ReadFile(objectPath(frameworkDir, hash))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s", ErrObjectNotFound, hash)
+		}
+		return nil, fmt.Errorf("failed to read object %s: %w", hash, err)
+	}
+
+	if Hash(data) != hash {
+		return nil, fmt.Errorf("object %s is corrupted", hash)
+	}
+
+	return data, nil
+}
+
+// ObjectsPattern returns the glob pattern matching the stored objects of the framework directory
+func ObjectsPattern(frameworkDir string) string {
+	return filepath.Join(frameworkDir, ObjectsDir, "*", "*")
+}
+
+// UnreferencedObjects returns the objects among paths whose hash no file of the lockfile records
+func (l *Lockfile) UnreferencedObjects(paths []string) []string {
+	referenced := make(map[string]bool, len(l.Files))
+	for _, file := range l.Files {
+		referenced[file.SHA256] = true
+	}
+
+	var unreferenced []string
+	for _, path := range paths {
+		if !referenced[filepath.Base(path)] {
+			unreferenced = append(unreferenced, path)
+		}
+	}
+
+	return unreferenced
+}
+
+// PruneObjects removes the stored objects no file of the lockfile references anymore,
+// e.g. the originals of upgraded or removed files
+func PruneObjects(frameworkDir string, lock *Lockfile) error {
+	paths, err := filepath.Glob(ObjectsPattern(frameworkDir))
+	if err != nil {
+		return fmt.Errorf("failed to find objects: %w", err)
+	}
+
+	for _, path := range lock.UnreferencedObjects(paths) {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove object %s: %w", filepath.Base(path), err)
+		}
+		// Fails while the directory holds other objects
+		_ = os.Remove(filepath.Dir(path))
+	}
+
+	return nil
+}
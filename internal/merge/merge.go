@@ -0,0 +1,190 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package merge
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// Conflict markers written around conflicting regions, compatible with git
+const (
+	MarkerLocal  = "<<<<<<<"
+	MarkerSplit  = "======="
+	MarkerRemote = ">>>>>>>"
+)
+
+// Labels name the sides of a merge in conflict markers
+type Labels struct {
+	Local  string
+	Remote string
+}
+
+// Result is the outcome of a three-way merge
+type Result struct {
+	Content []byte
+	// Conflicts is the number of conflicting regions written with markers
+	Conflicts int
+}
+
+// hunk is a change of one side against the base: base[baseStart:baseEnd] became side[sideStart:sideEnd]
+type hunk struct {
+	baseStart, baseEnd int
+	sideStart, sideEnd int
+	remote             bool
+}
+
+// Three merges the changes made in local and remote since base line by line.
+// Changes to different regions are combined; identical changes are applied once;
+// overlapping different changes are written between conflict markers.
+func Three(base, local, remote []byte, labels Labels) Result {
+	baseLines := splitLines(base)
+	localLines := splitLines(local)
+	remoteLines := splitLines(remote)
+
+	hunks := append(diff(baseLines, localLines, false), diff(baseLines, remoteLines, true)...)
+	slices.SortStableFunc(hunks, func(a, b hunk) int {
+		if a.baseStart != b.baseStart {
+			return a.baseStart - b.baseStart
+		}
+		return a.baseEnd - b.baseEnd
+	})
+
+	var (
+		out       bytes.Buffer
+		conflicts int
+		basePos   int
+		// localDelta and remoteDelta map base positions to side positions outside of changes
+		localDelta, remoteDelta int
+	)
+
+	for idx := 0; idx < len(hunks); {
+		// Group hunks whose base ranges overlap or touch
+		lo, hi := hunks[idx].baseStart, hunks[idx].baseEnd
+		end := idx + 1
+		for end < len(hunks) && hunks[end].baseStart <= hi {
+			hi = max(hi, hunks[end].baseEnd)
+			end++
+		}
+		group := hunks[idx:end]
+		idx = end
+
+		writeLines(&out, baseLines[basePos:lo])
+		basePos = hi
+
+		localStart, remoteStart := lo+localDelta, lo+remoteDelta
+		hasLocal, hasRemote := false, false
+		for _, h := range group {
+			size := (h.sideEnd - h.sideStart) - (h.baseEnd - h.baseStart)
+			if h.remote {
+				remoteDelta += size
+				hasRemote = true
+			} else {
+				localDelta += size
+				hasLocal = true
+			}
+		}
+		localPart := localLines[localStart : hi+localDelta]
+		remotePart := remoteLines[remoteStart : hi+remoteDelta]
+
+		switch {
+		case !hasRemote:
+			writeLines(&out, localPart)
+		case !hasLocal || slices.Equal(localPart, remotePart):
+			writeLines(&out, remotePart)
+		default:
+			conflicts++
+			writeConflict(&out, localPart, remotePart, labels)
+		}
+	}
+
+	writeLines(&out, baseLines[basePos:])
+
+	return Result{Content: out.Bytes(), Conflicts: conflicts}
+}
+
+// HasConflictMarkers reports whether content contains unresolved conflict markers
+func HasConflictMarkers(content []byte) bool {
+	for _, line := range splitLines(content) {
+		if strings.HasPrefix(line, MarkerLocal+" ") || strings.HasPrefix(line, MarkerRemote+" ") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// diff returns the changed regions of side compared to base
+func diff(base, side []string, remote bool) []hunk {
+	matcher := difflib.NewMatcherWithJunk(base, side, false, nil)
+
+	var hunks []hunk
+	for _, op := range matcher.GetOpCodes() {
+		if op.Tag == 'e' {
+			continue
+		}
+		hunks = append(hunks, hunk{baseStart: op.I1, baseEnd: op.I2, sideStart: op.J1, sideEnd: op.J2, remote: remote})
+	}
+
+	return hunks
+}
+
+// splitLines splits content into lines that keep their line endings
+func splitLines(content []byte) []string {
+	if len(content) == 0 {
+		return nil
+	}
+
+	lines := strings.SplitAfter(string(content), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
+
+// writeLines writes lines unchanged
+func writeLines(out *bytes.Buffer, lines []string) {
+	for _, line := range lines {
+		out.WriteString(line)
+	}
+}
+
+// writeConflict writes both sides of a conflicting region between markers
+func writeConflict(out *bytes.Buffer, local, remote []string, labels Labels) {
+	writeMarker(out, MarkerLocal+" "+labels.Local)
+	writeTerminated(out, local)
+	writeMarker(out, MarkerSplit)
+	writeTerminated(out, remote)
+	writeMarker(out, MarkerRemote+" "+labels.Remote)
+}
+
+// writeMarker writes a marker on its own line
+func writeMarker(out *bytes.Buffer, marker string) {
+	out.WriteString(strings.TrimSpace(marker))
+	out.WriteByte('\n')
+}
+
+// writeTerminated writes lines making sure the last one ends with a newline
+func writeTerminated(out *bytes.Buffer, lines []string) {
+	writeLines(out, lines)
+	if len(lines) > 0 && !strings.HasSuffix(lines[len(lines)-1], "\n") {
+		out.WriteByte('\n')
+	}
+}
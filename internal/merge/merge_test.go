@@ -0,0 +1,114 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package merge
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const base = `name: pm
+role: Product Manager
+goal: Ship great products
+principles:
+  - Be concise
+  - Ask questions
+commands:
+  help: Show help
+`
+
+func TestThree(t *testing.T) {
+	labels := Labels{Local: "local", Remote: "krci-ai 1.2.0"}
+
+	tests := []struct {
+		name          string
+		base          string
+		local         string
+		remote        string
+		want          string
+		wantConflicts int
+	}{
+		{
+			name:   "no changes",
+			base:   base,
+			local:  base,
+			remote: base,
+			want:   base,
+		},
+		{
+			name:   "only local changes",
+			base:   base,
+			local:  "name: pm\nrole: Lead Product Manager\ngoal: Ship great products\nprinciples:\n  - Be concise\n  - Ask questions\ncommands:\n  help: Show help\n",
+			remote: base,
+			want:   "name: pm\nrole: Lead Product Manager\ngoal: Ship great products\nprinciples:\n  - Be concise\n  - Ask questions\ncommands:\n  help: Show help\n",
+		},
+		{
+			name:   "only remote changes",
+			base:   base,
+			local:  base,
+			remote: "name: pm\nrole: Product Manager\ngoal: Ship great products\nprinciples:\n  - Be concise\n  - Ask questions\ncommands:\n  help: Show help\n  exit: Exit\n",
+			want:   "name: pm\nrole: Product Manager\ngoal: Ship great products\nprinciples:\n  - Be concise\n  - Ask questions\ncommands:\n  help: Show help\n  exit: Exit\n",
+		},
+		{
+			name:   "changes to different regions are combined",
+			base:   base,
+			local:  "name: pm\nrole: Lead Product Manager\ngoal: Ship great products\nprinciples:\n  - Be concise\n  - Ask questions\ncommands:\n  help: Show help\n",
+			remote: "name: pm\nrole: Product Manager\ngoal: Ship great products\nprinciples:\n  - Be concise\n  - Ask questions\ncommands:\n  help: Show help\n  exit: Exit\n",
+			want:   "name: pm\nrole: Lead Product Manager\ngoal: Ship great products\nprinciples:\n  - Be concise\n  - Ask questions\ncommands:\n  help: Show help\n  exit: Exit\n",
+		},
+		{
+			name:   "identical changes are applied once",
+			base:   base,
+			local:  "name: pm\nrole: Product Owner\ngoal: Ship great products\nprinciples:\n  - Be concise\n  - Ask questions\ncommands:\n  help: Show help\n",
+			remote: "name: pm\nrole: Product Owner\ngoal: Ship great products\nprinciples:\n  - Be concise\n  - Ask questions\ncommands:\n  help: Show help\n",
+			want:   "name: pm\nrole: Product Owner\ngoal: Ship great products\nprinciples:\n  - Be concise\n  - Ask questions\ncommands:\n  help: Show help\n",
+		},
+		{
+			name:          "overlapping changes conflict",
+			base:          base,
+			local:         "name: pm\nrole: Product Manager\ngoal: Ship great products\nprinciples:\n  - Be brief\n  - Ask questions\ncommands:\n  help: Show help\n",
+			remote:        "name: pm\nrole: Product Manager\ngoal: Ship great products\nprinciples:\n  - Be concise and precise\n  - Ask questions\ncommands:\n  help: Show help\n",
+			want:          "name: pm\nrole: Product Manager\ngoal: Ship great products\nprinciples:\n<<<<<<< local\n  - Be brief\n=======\n  - Be concise and precise\n>>>>>>> krci-ai 1.2.0\n  - Ask questions\ncommands:\n  help: Show help\n",
+			wantConflicts: 1,
+		},
+		{
+			name:          "missing trailing newline inside conflict",
+			base:          "a\nb",
+			local:         "a\nc",
+			remote:        "a\nd",
+			want:          "a\n<<<<<<< local\nc\n=======\nd\n>>>>>>> krci-ai 1.2.0\n",
+			wantConflicts: 1,
+		},
+		{
+			name:          "empty base conflicts on different content",
+			base:          "",
+			local:         "local\n",
+			remote:        "remote\n",
+			want:          "<<<<<<< local\nlocal\n=======\nremote\n>>>>>>> krci-ai 1.2.0\n",
+			wantConflicts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Three([]byte(tt.base), []byte(tt.local), []byte(tt.remote), labels)
+			assert.Equal(t, tt.want, string(result.Content))
+			assert.Equal(t, tt.wantConflicts, result.Conflicts)
+			assert.Equal(t, tt.wantConflicts > 0, HasConflictMarkers(result.Content))
+		})
+	}
+}
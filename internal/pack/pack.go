@@ -0,0 +1,260 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pack
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"gopkg.in/yaml.v3"
+
+	"github.com/KubeRocketCI/kuberocketai/internal/lockfile"
+	"github.com/KubeRocketCI/kuberocketai/internal/version"
+)
+
+const (
+	// ManifestFile is the pack manifest name at the archive root
+	ManifestFile = "pack.yaml"
+	// ArchiveExtension is the extension of pack archives
+	ArchiveExtension = ".tar.gz"
+	// ChecksumExtension is appended to the archive name for the checksum file
+	ChecksumExtension = ".sha256"
+
+	// maxManifestSize limits how much of pack.yaml is read from an archive
+	maxManifestSize = 1 << 20
+
+	filePermissions      = 0644
+	directoryPermissions = 0755
+)
+
+// namePattern restricts pack names to values that are safe in file names and URLs
+var namePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+
+// IsValidName reports whether name can be used as a pack name
+func IsValidName(name string) bool {
+	return namePattern.MatchString(name)
+}
+
+// Manifest describes a pack, stored as pack.yaml at the archive root
+type Manifest struct {
+	Name          string   `yaml:"name" json:"name"`
+	Version       string   `yaml:"version" json:"version"`
+	Description   string   `yaml:"description,omitempty" json:"description,omitempty"`
+	MinCLIVersion string   `yaml:"min_cli_version,omitempty" json:"min_cli_version,omitempty"`
+	Agents        []string `yaml:"agents" json:"agents"`
+	// Dependencies maps required pack names to semantic version constraints, e.g. shared-standards: ^1.2
+	Dependencies map[string]string `yaml:"dependencies,omitempty" json:"dependencies,omitempty"`
+}
+
+// Validate checks the manifest fields
+func (m *Manifest) Validate() error {
+	if !namePattern.MatchString(m.Name) {
+		return fmt.Errorf("invalid pack name %q: use lowercase letters, digits, '.', '_' or '-'", m.Name)
+	}
+
+	if _, err := version.ParseVersion(m.Version); err != nil {
+		return fmt.Errorf("invalid pack version %q: %w", m.Version, err)
+	}
+
+	if m.MinCLIVersion != "" {
+		if _, err := version.ParseVersion(m.MinCLIVersion); err != nil {
+			return fmt.Errorf("invalid minimum CLI version %q: %w", m.MinCLIVersion, err)
+		}
+	}
+
+	if len(m.Agents) == 0 {
+		return fmt.Errorf("pack %s contains no agents", m.Name)
+	}
+
+	for name, constraint := range m.Dependencies {
+		if !namePattern.MatchString(name) {
+			return fmt.Errorf("invalid dependency name %q", name)
+		}
+		if name == m.Name {
+			return fmt.Errorf("pack %s must not depend on itself", m.Name)
+		}
+		if _, err := semver.NewConstraint(constraint); err != nil {
+			return fmt.Errorf("invalid version constraint %q for dependency %s: %w", constraint, name, err)
+		}
+	}
+
+	return nil
+}
+
+// ArchiveName returns the archive file name for the manifest, e.g. golden-agents-1.2.0.tar.gz
+func (m *Manifest) ArchiveName() string {
+	return fmt.Sprintf("%s-%s%s", m.Name, m.Version, ArchiveExtension)
+}
+
+// ParseManifest parses pack.yaml content
+func ParseManifest(data []byte) (*Manifest, error) {
+	manifest := &Manifest{}
+	if err := yaml.Unmarshal(data, manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", ManifestFile, err)
+	}
+
+	return manifest, nil
+}
+
+// ReadArchiveManifest reads pack.yaml from a pack archive without extracting it
+func ReadArchiveManifest(path string) (*Manifest, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open pack %s: %w", path, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pack %s: %w", path, err)
+	}
+	defer func() { _ = gz.Close() }()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil, fmt.Errorf("pack %s does not contain %s", path, ManifestFile)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read pack %s: %w", path, err)
+		}
+
+		if header.Typeflag == tar.TypeReg && strings.TrimPrefix(header.Name, "./") == ManifestFile {
+			data, err := io.ReadAll(io.LimitReader(tr, maxManifestSize))
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s from %s: %w", ManifestFile, path, err)
+			}
+			return ParseManifest(data)
+		}
+	}
+}
+
+// LoadManifest reads pack.yaml from a pack directory
+func LoadManifest(dir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", ManifestFile, err)
+	}
+
+	return ParseManifest(data)
+}
+
+// Write writes a deterministic gzipped tar archive with pack.yaml followed by the files
+// in path order. Timestamps, ownership and permissions are normalized so that the same
+// input always yields byte-identical output.
+func Write(w io.Writer, manifest *Manifest, files map[string][]byte) error {
+	if err := manifest.Validate(); err != nil {
+		return err
+	}
+
+	manifestData, err := yaml.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", ManifestFile, err)
+	}
+
+	gz, err := gzip.NewWriterLevel(w, gzip.BestCompression)
+	if err != nil {
+		return fmt.Errorf("failed to create gzip writer: %w", err)
+	}
+	tw := tar.NewWriter(gz)
+
+	if err := writeEntry(tw, ManifestFile, manifestData); err != nil {
+		return err
+	}
+
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+
+	for _, path := range paths {
+		if path == ManifestFile {
+			return fmt.Errorf("pack files must not contain %s", ManifestFile)
+		}
+		if err := writeEntry(tw, path, files[path]); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to finalize archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("failed to finalize archive: %w", err)
+	}
+
+	return nil
+}
+
+// writeEntry writes a single regular file with normalized metadata
+func writeEntry(tw *tar.Writer, path string, data []byte) error {
+	header := &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     filepath.ToSlash(path),
+		Mode:     filePermissions,
+		Size:     int64(len(data)),
+		ModTime:  time.Unix(0, 0).UTC(),
+		Format:   tar.FormatUSTAR,
+	}
+
+	if err := tw.WriteHeader(header); err != nil {
+		return fmt.Errorf("failed to write archive header for %s: %w", path, err)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return fmt.Errorf("failed to write archive entry %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// WriteFile writes the pack archive into dir together with a sha256sum compatible checksum file.
+// It returns the archive path and its SHA-256.
+func WriteFile(dir string, manifest *Manifest, files map[string][]byte) (string, string, error) {
+	var buf bytes.Buffer
+	if err := Write(&buf, manifest, files); err != nil {
+		return "", "", err
+	}
+
+	if err := os.MkdirAll(dir, directoryPermissions); err != nil {
+		return "", "", fmt.Errorf("failed to create output directory %s: %w", dir, err)
+	}
+
+	archiveName := manifest.ArchiveName()
+	archivePath := filepath.Join(dir, archiveName)
+	if err := os.WriteFile(archivePath, buf.Bytes(), filePermissions); err != nil {
+		return "", "", fmt.Errorf("failed to write pack %s: %w", archivePath, err)
+	}
+
+	checksum := lockfile.Hash(buf.Bytes())
+	checksumLine := fmt.Sprintf("%s  %s\n", checksum, archiveName)
+	if err := os.WriteFile(archivePath+ChecksumExtension, []byte(checksumLine), filePermissions); err != nil {
+		return "", "", fmt.Errorf("failed to write checksum file: %w", err)
+	}
+
+	return archivePath, checksum, nil
+}
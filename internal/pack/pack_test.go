@@ -0,0 +1,161 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pack
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KubeRocketCI/kuberocketai/internal/lockfile"
+)
+
+func testManifest() *Manifest {
+	return &Manifest{
+		Name:          "golden-agents",
+		Version:       "1.2.0",
+		Description:   "Company golden agents",
+		MinCLIVersion: "0.30.0",
+		Agents:        []string{"pm", "architect"},
+	}
+}
+
+func testFiles() map[string][]byte {
+	return map[string][]byte{
+		"agents/pm.yaml":        []byte("agent: pm\n"),
+		"agents/architect.yaml": []byte("agent: architect\n"),
+		"tasks/create-prd.md":   []byte("# Create PRD\n"),
+		"templates/prd.md":      []byte("# PRD template\n"),
+	}
+}
+
+// readArchive returns the entry names and contents of a gzipped tar archive in order
+func readArchive(t *testing.T, data []byte) ([]string, map[string]string) {
+	t.Helper()
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	tr := tar.NewReader(gz)
+
+	var names []string
+	contents := make(map[string]string)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+
+		content, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		names = append(names, header.Name)
+		contents[header.Name] = string(content)
+	}
+
+	return names, contents
+}
+
+func TestWrite_Deterministic(t *testing.T) {
+	var first, second bytes.Buffer
+	require.NoError(t, Write(&first, testManifest(), testFiles()))
+	require.NoError(t, Write(&second, testManifest(), testFiles()))
+
+	assert.Equal(t, first.Bytes(), second.Bytes())
+
+	names, contents := readArchive(t, first.Bytes())
+	assert.Equal(t, []string{
+		ManifestFile,
+		"agents/architect.yaml",
+		"agents/pm.yaml",
+		"tasks/create-prd.md",
+		"templates/prd.md",
+	}, names)
+
+	manifest, err := ParseManifest([]byte(contents[ManifestFile]))
+	require.NoError(t, err)
+	assert.Equal(t, testManifest(), manifest)
+}
+
+func TestManifest_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(m *Manifest)
+		wantErr string
+	}{
+		{name: "valid", modify: func(m *Manifest) {}},
+		{name: "invalid name", modify: func(m *Manifest) { m.Name = "Golden Agents" }, wantErr: "invalid pack name"},
+		{name: "invalid version", modify: func(m *Manifest) { m.Version = "latest" }, wantErr: "invalid pack version"},
+		{name: "invalid min cli version", modify: func(m *Manifest) { m.MinCLIVersion = "next" }, wantErr: "invalid minimum CLI version"},
+		{name: "no agents", modify: func(m *Manifest) { m.Agents = nil }, wantErr: "contains no agents"},
+		{name: "valid dependency", modify: func(m *Manifest) { m.Dependencies = map[string]string{"shared-standards": "^1.2"} }},
+		{name: "invalid dependency constraint", modify: func(m *Manifest) { m.Dependencies = map[string]string{"shared-standards": "newest"} }, wantErr: "invalid version constraint"},
+		{name: "self dependency", modify: func(m *Manifest) { m.Dependencies = map[string]string{m.Name: "^1"} }, wantErr: "must not depend on itself"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manifest := testManifest()
+			tt.modify(manifest)
+
+			err := manifest.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestWriteFile(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "dist")
+
+	archivePath, checksum, err := WriteFile(dir, testManifest(), testFiles())
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "golden-agents-1.2.0.tar.gz"), archivePath)
+
+	data, err := os.ReadFile(archivePath)
+	require.NoError(t, err)
+	assert.Equal(t, lockfile.Hash(data), checksum)
+
+	checksumFile, err := os.ReadFile(archivePath + ChecksumExtension)
+	require.NoError(t, err)
+	assert.Equal(t, checksum+"  golden-agents-1.2.0.tar.gz", strings.TrimSpace(string(checksumFile)))
+}
+
+func TestReadArchiveManifest(t *testing.T) {
+	manifest := testManifest()
+	manifest.Dependencies = map[string]string{"shared-standards": "^1.2"}
+
+	archivePath, _, err := WriteFile(t.TempDir(), manifest, testFiles())
+	require.NoError(t, err)
+
+	got, err := ReadArchiveManifest(archivePath)
+	require.NoError(t, err)
+	assert.Equal(t, manifest, got)
+
+	_, err = ReadArchiveManifest(filepath.Join(t.TempDir(), "missing.tar.gz"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to open pack")
+}
@@ -0,0 +1,33 @@
+package processor
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// AgentCommand is a command of an agent definition
+type AgentCommand struct {
+	Name        string
+	Description string
+}
+
+// AgentCommands returns the commands of an agent definition in the order they are defined
+func AgentCommands(data []byte) ([]AgentCommand, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal agent file: %w", err)
+	}
+
+	commands := mappingValue(mappingValue(documentRoot(&doc), "agent"), "commands")
+	if commands == nil || commands.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+
+	result := make([]AgentCommand, 0, len(commands.Content)/2)
+	for idx := 0; idx+1 < len(commands.Content); idx += 2 {
+		result = append(result, AgentCommand{Name: commands.Content[idx].Value, Description: commands.Content[idx+1].Value})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,32 @@
+package processor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAgentCommands(t *testing.T) {
+	commands, err := AgentCommands([]byte(`agent:
+  identity:
+    name: "Tester"
+  commands:
+    help: "Show available commands"
+    run: Run tests
+    exit: "Exit Tester persona"
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []AgentCommand{
+		{Name: "help", Description: "Show available commands"},
+		{Name: "run", Description: "Run tests"},
+		{Name: "exit", Description: "Exit Tester persona"},
+	}, commands)
+
+	commands, err = AgentCommands([]byte("agent:\n  identity:\n    name: Tester\n"))
+	require.NoError(t, err)
+	assert.Empty(t, commands)
+
+	_, err = AgentCommands([]byte("agent: ["))
+	assert.Error(t, err)
+}
@@ -9,13 +9,26 @@ type AgentIdentityYamlRepresentation struct {
 	Role        string `yaml:"role"`
 	Goal        string `yaml:"goal"`
 	Icon        string `yaml:"icon"`
+	// MinCLIVersion is the oldest krci-ai version able to install the agent
+	MinCLIVersion string `yaml:"min_cli_version,omitempty"`
+}
+
+// AgentSubagentYamlRepresentation represents the optional Claude Code subagent settings of an agent YAML.
+type AgentSubagentYamlRepresentation struct {
+	// Tools limits the tools of the subagent, all tools when empty
+	Tools []string `yaml:"tools,omitempty"`
+	Model string   `yaml:"model,omitempty"`
 }
 
 // AgentYamlRepresentation represents the structure of an agent YAML file.
 type AgentYamlRepresentation struct {
 	Agent struct {
-		Identity AgentIdentityYamlRepresentation `yaml:"identity"`
-		Tasks    []string                        `yaml:"tasks"`
+		Identity         AgentIdentityYamlRepresentation `yaml:"identity"`
+		ActivationPrompt []string                        `yaml:"activation_prompt"`
+		Principles       []string                        `yaml:"principles"`
+		Customization    string                          `yaml:"customization"`
+		Subagent         AgentSubagentYamlRepresentation `yaml:"subagent,omitempty"`
+		Tasks            []string                        `yaml:"tasks"`
 	} `yaml:"agent"`
 }
 
@@ -0,0 +1,203 @@
+package processor
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TrimAgentTasks removes the tasks whose names are not listed in keep, e.g. create-prd for
+// ./.krci-ai/tasks/pm/create-prd.md, from an agent definition together with the commands
+// that run them. Only the removed lines change so comments and formatting of the rest of
+// the file are preserved.
+func TrimAgentTasks(data []byte, keep []string) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal agent file: %w", err)
+	}
+
+	agent := mappingValue(documentRoot(&doc), "agent")
+	if agent == nil {
+		return nil, fmt.Errorf("agent file has no agent section")
+	}
+
+	drop := make(map[int]struct{})
+	// block is the first and last line of the commands
+	var block [2]int
+
+	var kept, dropped []string
+	if tasks := mappingValue(agent, "tasks"); tasks != nil {
+		if tasks.Kind != yaml.SequenceNode || tasks.Style&yaml.FlowStyle != 0 {
+			return nil, fmt.Errorf("agent tasks must be a block sequence to be trimmed")
+		}
+		for _, item := range tasks.Content {
+			name := taskName(item.Value)
+			if slices.Contains(keep, name) {
+				kept = append(kept, name)
+				continue
+			}
+			if !singleLine(item) {
+				return nil, fmt.Errorf("agent task %s must be written on a single line to be trimmed", item.Value)
+			}
+			dropped = append(dropped, name)
+			drop[item.Line] = struct{}{}
+		}
+	}
+
+	if commands := mappingValue(agent, "commands"); commands != nil && commands.Kind == yaml.MappingNode {
+		if commands.Style&yaml.FlowStyle != 0 {
+			return nil, fmt.Errorf("agent commands must be a block mapping to be trimmed")
+		}
+		for idx := 0; idx+1 < len(commands.Content); idx += 2 {
+			key, value := commands.Content[idx], commands.Content[idx+1]
+			if !commandRunsOnly(key.Value, value.Value, dropped, kept) {
+				continue
+			}
+			if value.Line != key.Line || !singleLine(value) {
+				return nil, fmt.Errorf("agent command %s must be written on a single line to be trimmed", key.Value)
+			}
+			drop[key.Line] = struct{}{}
+		}
+
+		if len(commands.Content) > 0 {
+			first := commands.Content[0]
+			block = [2]int{first.Line - strings.Count(first.HeadComment, "\n") - 1, commands.Content[len(commands.Content)-1].Line}
+			if first.HeadComment == "" {
+				block[0] = first.Line
+			}
+		}
+	}
+
+	lines := strings.SplitAfter(string(data), "\n")
+	dropOrphanedLines(lines, block, drop)
+	var out strings.Builder
+	for idx, line := range lines {
+		if _, ok := drop[idx+1]; !ok {
+			out.WriteString(line)
+		}
+	}
+
+	return []byte(out.String()), nil
+}
+
+// dropOrphanedLines drops comments in the block of lines that no longer head any line and
+// blank lines that would repeat once the dropped lines are removed. Lines are numbered from 1.
+func dropOrphanedLines(lines []string, block [2]int, drop map[int]struct{}) {
+	if block[0] == 0 {
+		return
+	}
+
+	// next returns the first kept line after the given one within the block, empty at its end
+	next := func(line int) string {
+		for line++; line <= block[1] && line <= len(lines); line++ {
+			if _, ok := drop[line]; !ok {
+				return strings.TrimSpace(lines[line-1])
+			}
+		}
+		return ""
+	}
+
+	for line := block[1]; line >= block[0]; line-- {
+		if _, ok := drop[line]; ok || !strings.HasPrefix(strings.TrimSpace(lines[line-1]), "#") {
+			continue
+		}
+		if next(line) == "" {
+			drop[line] = struct{}{}
+		}
+	}
+
+	for line := block[0]; line <= block[1]; line++ {
+		if _, ok := drop[line]; ok || strings.TrimSpace(lines[line-1]) != "" {
+			continue
+		}
+		if next(line) == "" {
+			drop[line] = struct{}{}
+		}
+	}
+}
+
+// documentRoot returns the top level node of a parsed document
+func documentRoot(doc *yaml.Node) *yaml.Node {
+	if doc.Kind == yaml.DocumentNode && len(doc.Content) > 0 {
+		return doc.Content[0]
+	}
+	return doc
+}
+
+// mappingValue returns the value of a key in a mapping node, nil when not found
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for idx := 0; idx+1 < len(node.Content); idx += 2 {
+		if node.Content[idx].Value == key {
+			return node.Content[idx+1]
+		}
+	}
+	return nil
+}
+
+// singleLine reports whether a scalar node is written on one line
+func singleLine(node *yaml.Node) bool {
+	return node.Kind == yaml.ScalarNode &&
+		node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) == 0 &&
+		!strings.Contains(node.Value, "\n")
+}
+
+// taskName returns the task name of a task reference, e.g. create-prd for ./.krci-ai/tasks/pm/create-prd.md
+func taskName(ref string) string {
+	return strings.TrimSuffix(path.Base(ref), path.Ext(ref))
+}
+
+// commandRunsOnly reports whether a command runs one of the dropped tasks and none of the kept ones
+func commandRunsOnly(command, description string, dropped, kept []string) bool {
+	if len(dropped) == 0 {
+		return false
+	}
+
+	tasks := CommandTasks(command, description, append(slices.Clone(dropped), kept...))
+	if len(tasks) == 0 {
+		return false
+	}
+	for _, task := range tasks {
+		if slices.Contains(kept, task) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// CommandTasks returns the tasks an agent command runs. Commands name their task in the
+// description ("by executing task create-prd"), share its name, or use a shortened
+// name whose words all appear in the task name (validate-problem for validate-problem-statement).
+// Commands such as help or exit run no task.
+func CommandTasks(command, description string, tasks []string) []string {
+	var described string
+	for _, task := range tasks {
+		if strings.Contains(description, "task "+task) && len(task) > len(described) {
+			described = task
+		}
+	}
+	if described != "" {
+		return []string{described}
+	}
+
+	if slices.Contains(tasks, command) {
+		return []string{command}
+	}
+
+	words := strings.Split(command, "-")
+	var matches []string
+	for _, task := range tasks {
+		taskWords := strings.Split(task, "-")
+		if !slices.ContainsFunc(words, func(word string) bool { return !slices.Contains(taskWords, word) }) {
+			matches = append(matches, task)
+		}
+	}
+
+	return matches
+}
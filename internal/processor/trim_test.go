@@ -0,0 +1,120 @@
+package processor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const agentYAML = `agent:
+  identity:
+    name: "Peter Manager"
+    icon: "📈"
+
+  commands:
+    help: "Show available commands"
+    chat: "(Default) Product management consultation"
+
+    # Project Brief Commands
+    # (standard and enhanced flows)
+    create-project-brief: "Create project brief"
+    gather-context: "Collect inputs by executing task gather-project-context"
+    validate-problem: "Validate problem statement"
+
+    # PRD Commands
+    create-prd: "Create PRD by executing task create-prd"
+    update-prd: "Update PRD by executing task update-prd"
+    exit: "Exit persona"
+
+  tasks:
+    - ./.krci-ai/tasks/pm/create-project-brief.md
+    - ./.krci-ai/tasks/pm/gather-project-context.md
+    - ./.krci-ai/tasks/pm/validate-problem-statement.md
+    - ./.krci-ai/tasks/pm/create-prd.md
+    - ./.krci-ai/tasks/pm/update-prd.md
+`
+
+func TestTrimAgentTasks(t *testing.T) {
+	trimmed, err := TrimAgentTasks([]byte(agentYAML), []string{"create-prd", "validate-problem-statement"})
+	require.NoError(t, err)
+
+	assert.Equal(t, `agent:
+  identity:
+    name: "Peter Manager"
+    icon: "📈"
+
+  commands:
+    help: "Show available commands"
+    chat: "(Default) Product management consultation"
+
+    # Project Brief Commands
+    # (standard and enhanced flows)
+    validate-problem: "Validate problem statement"
+
+    # PRD Commands
+    create-prd: "Create PRD by executing task create-prd"
+    exit: "Exit persona"
+
+  tasks:
+    - ./.krci-ai/tasks/pm/validate-problem-statement.md
+    - ./.krci-ai/tasks/pm/create-prd.md
+`, string(trimmed))
+
+	agent, err := UnmarshalAgent(trimmed)
+	require.NoError(t, err)
+	assert.Len(t, agent.Agent.Tasks, 2)
+
+	// Comments of command groups without remaining commands are dropped
+	trimmed, err = TrimAgentTasks([]byte(agentYAML), []string{"create-prd"})
+	require.NoError(t, err)
+	assert.Contains(t, string(trimmed), `    chat: "(Default) Product management consultation"
+
+    # PRD Commands
+    create-prd: "Create PRD by executing task create-prd"
+    exit: "Exit persona"
+
+  tasks:
+    - ./.krci-ai/tasks/pm/create-prd.md
+`)
+}
+
+func TestTrimAgentTasks_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "no agent section", content: "identity: {}\n"},
+		{name: "flow sequence", content: "agent:\n  tasks: [./.krci-ai/tasks/a.md, ./.krci-ai/tasks/b.md]\n"},
+		{name: "invalid yaml", content: "agent: [\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := TrimAgentTasks([]byte(tt.content), []string{"a"})
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestCommandTasks(t *testing.T) {
+	tasks := []string{"create-project-brief", "create-project-brief-advanced", "gather-project-context", "validate-problem-statement", "review-story-dev"}
+
+	tests := []struct {
+		command     string
+		description string
+		want        []string
+	}{
+		{command: "gather-context", description: "Collect inputs by executing task gather-project-context", want: []string{"gather-project-context"}},
+		{command: "create-project-brief", description: "Create project brief", want: []string{"create-project-brief"}},
+		{command: "validate-problem", description: "Validate problem statement", want: []string{"validate-problem-statement"}},
+		{command: "review", description: "Review story technical requirements", want: []string{"review-story-dev"}},
+		{command: "help", description: "Show available commands", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.command, func(t *testing.T) {
+			assert.Equal(t, tt.want, CommandTasks(tt.command, tt.description, tasks))
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/stretchr/testify/assert/yaml"
 	"github.com/yuin/goldmark"
@@ -22,12 +23,7 @@ func UnmarshalAgentFile(filePath string) (*AgentYamlRepresentation, error) {
 		return nil, err
 	}
 
-	var rawAgent AgentYamlRepresentation
-	if err := yaml.Unmarshal(data, &rawAgent); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal agent file: %w", err)
-	}
-
-	return &rawAgent, nil
+	return UnmarshalAgent(data)
 }
 
 // UnmarshalAgentFileFromFS unmarshals an agent file using a FileReader interface
@@ -37,6 +33,11 @@ func UnmarshalAgentFileFromFS(fs FileReader, filePath string) (*AgentYamlReprese
 		return nil, err
 	}
 
+	return UnmarshalAgent(data)
+}
+
+// UnmarshalAgent unmarshals agent file content
+func UnmarshalAgent(data []byte) (*AgentYamlRepresentation, error) {
 	var rawAgent AgentYamlRepresentation
 	if err := yaml.Unmarshal(data, &rawAgent); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal agent file: %w", err)
@@ -82,6 +83,11 @@ func UnmarshalTaskDependenciesFileFromFS(fs FileReader, filePath string) (*TaskD
 		return nil, fmt.Errorf("failed to read file %q: %w", filePath, err)
 	}
 
+	return UnmarshalTaskDependencies(data, filePath)
+}
+
+// UnmarshalTaskDependencies unmarshals the task dependencies of task file content, filePath is used in errors
+func UnmarshalTaskDependencies(data []byte, filePath string) (*TaskDependenciesYamlRepresentation, error) {
 	md := goldmark.New(
 		goldmark.WithExtensions(&frontmatter.Extender{
 			Mode: frontmatter.SetMetadata,
@@ -106,3 +112,25 @@ func UnmarshalTaskDependenciesFileFromFS(fs FileReader, filePath string) (*TaskD
 
 	return &gotData, nil
 }
+
+// TaskTitle returns the title of task file content from its "# Task: <title>" heading,
+// or from its first top level heading, empty when it has none
+func TaskTitle(data []byte) string {
+	title := ""
+	for line := range strings.Lines(string(data)) {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "# ") {
+			continue
+		}
+
+		heading := strings.TrimSpace(strings.TrimPrefix(line, "# "))
+		if name, ok := strings.CutPrefix(heading, "Task:"); ok {
+			return strings.TrimSpace(name)
+		}
+		if title == "" {
+			title = heading
+		}
+	}
+
+	return title
+}
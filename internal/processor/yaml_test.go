@@ -0,0 +1,26 @@
+package processor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskTitle(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{name: "task heading", content: "---\ndependencies: {}\n---\n\n# Task: Create PRD\n\n## Overview\n", want: "Create PRD"},
+		{name: "task heading after other heading", content: "# Notes\n# Task: Review Code\n", want: "Review Code"},
+		{name: "first heading", content: "Intro\n# Review Code\n## Steps\n", want: "Review Code"},
+		{name: "no heading", content: "## Steps\n", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, TaskTitle([]byte(tt.content)))
+		})
+	}
+}
@@ -0,0 +1,156 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package registry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Cache stores registry indexes on disk, one file per registry URL
+type Cache struct {
+	Dir string
+}
+
+// DefaultCacheDir returns the user cache directory for registry indexes
+func DefaultCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user cache directory: %w", err)
+	}
+
+	return filepath.Join(dir, "krci-ai", "registry"), nil
+}
+
+// path returns the cache file for a registry index URL
+func (c *Cache) path(indexURL string) string {
+	sum := sha256.Sum256([]byte(indexURL))
+	return filepath.Join(c.Dir, hex.EncodeToString(sum[:8])+".json")
+}
+
+// Load returns the cached index and the time it was stored
+func (c *Cache) Load(indexURL string) ([]byte, time.Time, error) {
+	path := c.path(indexURL)
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to read cached index %s: %w", path, err)
+	}
+
+	return data, info.ModTime(), nil
+}
+
+// Store writes the index to the cache
+func (c *Cache) Store(indexURL string, data []byte) error {
+	if err := os.MkdirAll(c.Dir, 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory %s: %w", c.Dir, err)
+	}
+
+	path := c.path(indexURL)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write cached index %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// Options controls how a registry index is loaded
+type Options struct {
+	// Cache stores fetched indexes, caching is disabled when nil
+	Cache *Cache
+	// TTL is how long a cached index is used without refreshing it
+	TTL time.Duration
+	// Offline uses only the cache and never contacts the registry
+	Offline bool
+	// Refresh ignores the TTL and always fetches the index
+	Refresh bool
+}
+
+// Result is a loaded registry index together with how it was obtained
+type Result struct {
+	Client    *Client
+	Index     *Index
+	FromCache bool
+	FetchedAt time.Time
+	// Warning is set when a stale cached index was used because the registry could not be reached
+	Warning string
+}
+
+// Load returns the registry index, using the cache when it is fresh, when offline,
+// or as a fallback when the registry cannot be reached
+func Load(ctx context.Context, client *Client, opts Options) (*Result, error) {
+	indexURL := client.IndexURL()
+
+	var (
+		cached   []byte
+		cachedAt time.Time
+		cacheErr = os.ErrNotExist
+	)
+	if opts.Cache != nil {
+		cached, cachedAt, cacheErr = opts.Cache.Load(indexURL)
+	}
+
+	fromCache := func(warning string) (*Result, error) {
+		index, err := ParseIndex(cached)
+		if err != nil {
+			return nil, err
+		}
+		return &Result{Client: client, Index: index, FromCache: true, FetchedAt: cachedAt, Warning: warning}, nil
+	}
+
+	if opts.Offline {
+		if cacheErr != nil {
+			return nil, fmt.Errorf("registry %s is not cached, run without --offline to fetch it", client.BaseURL)
+		}
+		return fromCache("")
+	}
+
+	if cacheErr == nil && !opts.Refresh && time.Since(cachedAt) < opts.TTL {
+		return fromCache("")
+	}
+
+	data, err := client.FetchIndex(ctx)
+	if err != nil {
+		if cacheErr == nil {
+			return fromCache(fmt.Sprintf("using cached index of %s from %s: %v",
+				client.BaseURL, cachedAt.Format(time.RFC3339), err))
+		}
+		return nil, err
+	}
+
+	index, err := ParseIndex(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Cache != nil {
+		if err := opts.Cache.Store(indexURL, data); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Result{Client: client, Index: index, FetchedAt: time.Now()}, nil
+}
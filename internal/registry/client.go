@@ -0,0 +1,143 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package registry
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// maxIndexSize limits the size of a registry index
+const maxIndexSize = 10 << 20
+
+// Client fetches the index of a pack registry served over HTTP(S) or from a file URL
+type Client struct {
+	BaseURL    string
+	HTTPClient *http.Client
+	UserAgent  string
+}
+
+// NewClient creates a registry client for the given registry URL
+func NewClient(baseURL string) *Client {
+	return NewClientWith(baseURL, DefaultUserAgent, nil)
+}
+
+// NewClientWith allows constructing a client with custom parameters
+func NewClientWith(baseURL, userAgent string, httpClient *http.Client) *Client {
+	c := &Client{BaseURL: baseURL, UserAgent: userAgent}
+	if httpClient != nil {
+		c.HTTPClient = httpClient
+	} else {
+		c.HTTPClient = &http.Client{Timeout: DefaultTimeout}
+	}
+	return c
+}
+
+// IndexURL returns the URL of the registry index.
+// Registry URLs pointing to a .json file are used as is, otherwise index.json is appended.
+func (c *Client) IndexURL() string {
+	if strings.HasSuffix(c.BaseURL, ".json") {
+		return c.BaseURL
+	}
+
+	return strings.TrimSuffix(c.BaseURL, "/") + "/" + IndexFile
+}
+
+// FetchIndex downloads the raw registry index
+func (c *Client) FetchIndex(ctx context.Context) ([]byte, error) {
+	indexURL := c.IndexURL()
+
+	parsed, err := url.Parse(indexURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid registry URL %s: %w", c.BaseURL, err)
+	}
+
+	switch parsed.Scheme {
+	case "file":
+		data, err := os.ReadFile(parsed.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read registry index %s: %w", indexURL, err)
+		}
+		return data, nil
+	case "http", "https":
+	default:
+		return nil, fmt.Errorf("unsupported registry URL %s: use http, https or file URLs", c.BaseURL)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, indexURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("User-Agent", c.UserAgent)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("registry %s returned status %d: %s", c.BaseURL, resp.StatusCode, resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxIndexSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if len(data) > maxIndexSize {
+		return nil, fmt.Errorf("registry index %s exceeds the maximum size of %d bytes", indexURL, maxIndexSize)
+	}
+
+	return data, nil
+}
+
+// GetIndex downloads and parses the registry index
+func (c *Client) GetIndex(ctx context.Context) (*Index, error) {
+	data, err := c.FetchIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseIndex(data)
+}
+
+// ResolveURL resolves a pack archive URL from the index against the index location.
+// Archives of file registries are returned as local paths so they can be passed to 'install --from'.
+func (c *Client) ResolveURL(ref string) string {
+	base, err := url.Parse(c.IndexURL())
+	if err != nil {
+		return ref
+	}
+
+	target, err := url.Parse(ref)
+	if err != nil {
+		return ref
+	}
+
+	resolved := base.ResolveReference(target)
+	if resolved.Scheme == "file" {
+		return resolved.Path
+	}
+
+	return resolved.String()
+}
@@ -0,0 +1,28 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package registry
+
+import "time"
+
+// IndexFile is the registry index file name appended to registry URLs that do not point to a JSON file
+const IndexFile = "index.json"
+
+const DefaultUserAgent = "krci-ai/v1.0.0"
+
+var DefaultTimeout = 10 * time.Second
+
+// DefaultCacheTTL is how long a cached index is used before it is refreshed
+var DefaultCacheTTL = time.Hour
@@ -0,0 +1,158 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/KubeRocketCI/kuberocketai/internal/version"
+)
+
+// IndexVersion is the supported registry index format version
+const IndexVersion = 1
+
+// Index lists the packs published in a registry
+type Index struct {
+	IndexVersion int    `json:"indexVersion"`
+	Packs        []Pack `json:"packs"`
+}
+
+// Pack is a named pack with all of its published versions
+type Pack struct {
+	Name        string        `json:"name"`
+	Description string        `json:"description,omitempty"`
+	Versions    []PackVersion `json:"versions"`
+}
+
+// PackVersion is a single published pack archive
+type PackVersion struct {
+	Version       string   `json:"version"`
+	Description   string   `json:"description,omitempty"`
+	Agents        []string `json:"agents"`
+	MinCLIVersion string   `json:"min_cli_version,omitempty"`
+	// Dependencies maps required pack names to semantic version constraints
+	Dependencies map[string]string `json:"dependencies,omitempty"`
+	// URL points to the pack archive, relative URLs are resolved against the registry URL
+	URL    string `json:"url"`
+	SHA256 string `json:"sha256"`
+}
+
+// ParseIndex parses and validates index.json content
+func ParseIndex(data []byte) (*Index, error) {
+	index := &Index{}
+	if err := json.Unmarshal(data, index); err != nil {
+		return nil, fmt.Errorf("failed to parse registry index: %w", err)
+	}
+
+	if index.IndexVersion > IndexVersion {
+		return nil, fmt.Errorf("unsupported registry index version %d (max supported: %d)", index.IndexVersion, IndexVersion)
+	}
+
+	return index, nil
+}
+
+// Search returns packs whose name, description or agents contain the query (case-insensitive).
+// An empty query matches all packs.
+func (idx *Index) Search(query string) []Pack {
+	query = strings.ToLower(strings.TrimSpace(query))
+
+	var result []Pack
+	for _, pack := range idx.Packs {
+		if query == "" || pack.matches(query) {
+			result = append(result, pack)
+		}
+	}
+
+	return result
+}
+
+// Find returns the pack with the given name
+func (idx *Index) Find(name string) (Pack, bool) {
+	for _, pack := range idx.Packs {
+		if strings.EqualFold(pack.Name, name) {
+			return pack, true
+		}
+	}
+
+	return Pack{}, false
+}
+
+// matches reports whether the lower-cased query occurs in the pack metadata
+func (p Pack) matches(query string) bool {
+	if strings.Contains(strings.ToLower(p.Name), query) || strings.Contains(strings.ToLower(p.Description), query) {
+		return true
+	}
+
+	for _, v := range p.Versions {
+		if strings.Contains(strings.ToLower(v.Description), query) {
+			return true
+		}
+		if slices.ContainsFunc(v.Agents, func(agent string) bool {
+			return strings.Contains(strings.ToLower(agent), query)
+		}) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SortedVersions returns the pack versions ordered from newest to oldest.
+// Versions that are not valid semantic versions are placed last.
+func (p Pack) SortedVersions() []PackVersion {
+	versions := slices.Clone(p.Versions)
+	slices.SortStableFunc(versions, func(a, b PackVersion) int {
+		va, errA := version.ParseVersion(a.Version)
+		vb, errB := version.ParseVersion(b.Version)
+		switch {
+		case errA != nil && errB != nil:
+			return 0
+		case errA != nil:
+			return 1
+		case errB != nil:
+			return -1
+		default:
+			return vb.Compare(va)
+		}
+	})
+
+	return versions
+}
+
+// Latest returns the newest version of the pack
+func (p Pack) Latest() (PackVersion, bool) {
+	versions := p.SortedVersions()
+	if len(versions) == 0 {
+		return PackVersion{}, false
+	}
+
+	return versions[0], true
+}
+
+// Version returns the pack version with the given number
+func (p Pack) Version(number string) (PackVersion, bool) {
+	number = strings.TrimPrefix(number, "v")
+	for _, v := range p.Versions {
+		if strings.TrimPrefix(v.Version, "v") == number {
+			return v, true
+		}
+	}
+
+	return PackVersion{}, false
+}
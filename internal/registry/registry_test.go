@@ -0,0 +1,209 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package registry
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testIndex = `{
+  "indexVersion": 1,
+  "packs": [
+    {
+      "name": "golden-agents",
+      "description": "Company golden agents",
+      "versions": [
+        {"version": "1.0.0", "agents": ["pm"], "url": "golden-agents-1.0.0.tar.gz", "sha256": "aaa"},
+        {"version": "1.10.0", "agents": ["pm", "architect"], "url": "golden-agents-1.10.0.tar.gz", "sha256": "ccc"},
+        {"version": "1.2.0", "agents": ["pm", "architect"], "url": "https://cdn.example.com/golden-agents-1.2.0.tar.gz", "sha256": "bbb"}
+      ]
+    },
+    {
+      "name": "qa-pack",
+      "versions": [
+        {"version": "0.1.0", "description": "Testing helpers", "agents": ["qa"], "url": "qa-pack-0.1.0.tar.gz", "sha256": "ddd"}
+      ]
+    }
+  ]
+}`
+
+func TestIndex_Search(t *testing.T) {
+	index, err := ParseIndex([]byte(testIndex))
+	require.NoError(t, err)
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{query: "", want: []string{"golden-agents", "qa-pack"}},
+		{query: "GOLDEN", want: []string{"golden-agents"}},
+		{query: "architect", want: []string{"golden-agents"}},
+		{query: "testing", want: []string{"qa-pack"}},
+		{query: "missing", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			var names []string
+			for _, pack := range index.Search(tt.query) {
+				names = append(names, pack.Name)
+			}
+			assert.Equal(t, tt.want, names)
+		})
+	}
+}
+
+func TestPack_Versions(t *testing.T) {
+	index, err := ParseIndex([]byte(testIndex))
+	require.NoError(t, err)
+
+	pack, ok := index.Find("Golden-Agents")
+	require.True(t, ok)
+
+	latest, ok := pack.Latest()
+	require.True(t, ok)
+	assert.Equal(t, "1.10.0", latest.Version)
+
+	var order []string
+	for _, v := range pack.SortedVersions() {
+		order = append(order, v.Version)
+	}
+	assert.Equal(t, []string{"1.10.0", "1.2.0", "1.0.0"}, order)
+
+	v, ok := pack.Version("v1.2.0")
+	require.True(t, ok)
+	assert.Equal(t, "bbb", v.SHA256)
+
+	_, ok = index.Find("unknown")
+	assert.False(t, ok)
+}
+
+func TestParseIndex_UnsupportedVersion(t *testing.T) {
+	_, err := ParseIndex([]byte(`{"indexVersion": 5, "packs": []}`))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported registry index version")
+}
+
+func TestClient_HTTP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/packs/index.json", r.URL.Path)
+		assert.Equal(t, DefaultUserAgent, r.Header.Get("User-Agent"))
+		_, _ = w.Write([]byte(testIndex))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL + "/packs/")
+	index, err := client.GetIndex(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, index.Packs, 2)
+
+	assert.Equal(t, server.URL+"/packs/golden-agents-1.0.0.tar.gz", client.ResolveURL("golden-agents-1.0.0.tar.gz"))
+	assert.Equal(t, "https://cdn.example.com/x.tar.gz", client.ResolveURL("https://cdn.example.com/x.tar.gz"))
+}
+
+func TestClient_File(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "custom.json"), []byte(testIndex), 0644))
+
+	client := NewClient("file://" + filepath.ToSlash(dir) + "/custom.json")
+	index, err := client.GetIndex(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, index.Packs, 2)
+
+	assert.Equal(t, filepath.ToSlash(dir)+"/qa-pack-0.1.0.tar.gz", client.ResolveURL("qa-pack-0.1.0.tar.gz"))
+
+	_, err = NewClient("ftp://example.com").GetIndex(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported registry URL")
+}
+
+func TestLoad_Caching(t *testing.T) {
+	var requests atomic.Int32
+	var failing atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if failing.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte(testIndex))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	cache := &Cache{Dir: t.TempDir()}
+	ctx := context.Background()
+
+	t.Run("offline without cache fails", func(t *testing.T) {
+		_, err := Load(ctx, client, Options{Cache: cache, Offline: true})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "is not cached")
+		assert.Equal(t, int32(0), requests.Load())
+	})
+
+	t.Run("first load fetches and caches", func(t *testing.T) {
+		result, err := Load(ctx, client, Options{Cache: cache, TTL: time.Hour})
+		require.NoError(t, err)
+		assert.False(t, result.FromCache)
+		assert.Equal(t, int32(1), requests.Load())
+	})
+
+	t.Run("fresh cache is used", func(t *testing.T) {
+		result, err := Load(ctx, client, Options{Cache: cache, TTL: time.Hour})
+		require.NoError(t, err)
+		assert.True(t, result.FromCache)
+		assert.Equal(t, int32(1), requests.Load())
+	})
+
+	t.Run("refresh bypasses cache", func(t *testing.T) {
+		result, err := Load(ctx, client, Options{Cache: cache, TTL: time.Hour, Refresh: true})
+		require.NoError(t, err)
+		assert.False(t, result.FromCache)
+		assert.Equal(t, int32(2), requests.Load())
+	})
+
+	t.Run("offline uses cache", func(t *testing.T) {
+		result, err := Load(ctx, client, Options{Cache: cache, Offline: true})
+		require.NoError(t, err)
+		assert.True(t, result.FromCache)
+		assert.Len(t, result.Index.Packs, 2)
+		assert.Equal(t, int32(2), requests.Load())
+	})
+
+	t.Run("stale cache is used when registry fails", func(t *testing.T) {
+		failing.Store(true)
+		result, err := Load(ctx, client, Options{Cache: cache, TTL: 0})
+		require.NoError(t, err)
+		assert.True(t, result.FromCache)
+		assert.Contains(t, result.Warning, "using cached index")
+	})
+
+	t.Run("failure without cache", func(t *testing.T) {
+		_, err := Load(ctx, client, Options{Cache: &Cache{Dir: t.TempDir()}})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "returned status 500")
+	})
+}
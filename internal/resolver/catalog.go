@@ -0,0 +1,167 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package resolver
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+
+	"github.com/KubeRocketCI/kuberocketai/internal/pack"
+	"github.com/KubeRocketCI/kuberocketai/internal/registry"
+)
+
+// OriginLocal marks candidates found in a local pack directory
+const OriginLocal = "local"
+
+// Candidate is an installable version of a pack
+type Candidate struct {
+	Name    string
+	Version string
+	// Dependencies maps required pack names to semantic version constraints
+	Dependencies  map[string]string
+	MinCLIVersion string
+	// Location is the archive URL or path that can be passed to the source fetcher
+	Location string
+	SHA256   string
+	// Origin is the registry URL or OriginLocal the candidate was found in
+	Origin string
+
+	version *semver.Version
+}
+
+// ID returns the name@version identifier of the candidate
+func (c Candidate) ID() string {
+	return c.Name + "@" + c.Version
+}
+
+// Catalog holds the known versions of every pack
+type Catalog struct {
+	packs map[string][]Candidate
+}
+
+// NewCatalog creates an empty catalog
+func NewCatalog() *Catalog {
+	return &Catalog{packs: make(map[string][]Candidate)}
+}
+
+// Add registers a pack version. Versions already known from an earlier source are kept,
+// so sources added first take precedence.
+func (c *Catalog) Add(candidate Candidate) error {
+	v, err := semver.NewVersion(candidate.Version)
+	if err != nil {
+		return fmt.Errorf("invalid version %q of pack %s: %w", candidate.Version, candidate.Name, err)
+	}
+
+	candidate.version = v
+	candidate.Version = v.String()
+
+	name := strings.ToLower(candidate.Name)
+	for _, existing := range c.packs[name] {
+		if existing.version.Equal(v) {
+			return nil
+		}
+	}
+
+	c.packs[name] = append(c.packs[name], candidate)
+	slices.SortFunc(c.packs[name], func(a, b Candidate) int {
+		return b.version.Compare(a.version)
+	})
+
+	return nil
+}
+
+// AddIndex registers all pack versions of a registry index.
+// Invalid versions are skipped so that one broken entry does not hide the whole registry.
+func (c *Catalog) AddIndex(client *registry.Client, index *registry.Index) {
+	for _, p := range index.Packs {
+		for _, v := range p.Versions {
+			_ = c.Add(Candidate{
+				Name:          p.Name,
+				Version:       v.Version,
+				Dependencies:  v.Dependencies,
+				MinCLIVersion: v.MinCLIVersion,
+				Location:      client.ResolveURL(v.URL),
+				SHA256:        v.SHA256,
+				Origin:        client.BaseURL,
+			})
+		}
+	}
+}
+
+// AddDirectory registers the pack archives found in a directory, e.g. the output of 'krci-ai pack'.
+// A missing directory is not an error.
+func (c *Catalog) AddDirectory(dir string) error {
+	archives, err := filepath.Glob(filepath.Join(dir, "*"+pack.ArchiveExtension))
+	if err != nil {
+		return fmt.Errorf("failed to list packs in %s: %w", dir, err)
+	}
+
+	for _, archive := range archives {
+		manifest, err := pack.ReadArchiveManifest(archive)
+		if err != nil {
+			return err
+		}
+
+		if err := c.Add(Candidate{
+			Name:          manifest.Name,
+			Version:       manifest.Version,
+			Dependencies:  manifest.Dependencies,
+			MinCLIVersion: manifest.MinCLIVersion,
+			Location:      archive,
+			SHA256:        readChecksum(archive),
+			Origin:        OriginLocal,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Versions returns the known versions of a pack, newest first
+func (c *Catalog) Versions(name string) []Candidate {
+	return c.packs[strings.ToLower(name)]
+}
+
+// Len returns the number of known pack versions
+func (c *Catalog) Len() int {
+	count := 0
+	for _, versions := range c.packs {
+		count += len(versions)
+	}
+
+	return count
+}
+
+// readChecksum returns the SHA-256 from the checksum file next to an archive, if present
+func readChecksum(archive string) string {
+	data, err := os.ReadFile(archive + pack.ChecksumExtension)
+	if err != nil {
+		return ""
+	}
+
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return ""
+	}
+
+	return fields[0]
+}
@@ -0,0 +1,279 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package resolver
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+
+	"github.com/KubeRocketCI/kuberocketai/internal/version"
+)
+
+// anyVersion is the constraint used when a requirement does not restrict the version
+const anyVersion = "*"
+
+// Requirement is a version constraint on a pack
+type Requirement struct {
+	Name       string
+	Constraint string
+	// RequiredBy is the name@version of the requiring pack, empty for requests made by the user
+	RequiredBy string
+}
+
+// ParseRequirement parses a name[@constraint] request, e.g. shared-standards@^1.2
+func ParseRequirement(spec string) (Requirement, error) {
+	name, constraint, _ := strings.Cut(strings.TrimSpace(spec), "@")
+	if name == "" {
+		return Requirement{}, fmt.Errorf("invalid pack request %q: missing pack name", spec)
+	}
+
+	req := Requirement{Name: name, Constraint: constraint}
+	if _, err := req.constraint(); err != nil {
+		return Requirement{}, err
+	}
+
+	return req, nil
+}
+
+// String describes who requires which versions
+func (r Requirement) String() string {
+	constraint := r.Constraint
+	if constraint == "" {
+		constraint = anyVersion
+	}
+
+	if r.RequiredBy == "" {
+		return fmt.Sprintf("requested %s %s", r.Name, constraint)
+	}
+
+	return fmt.Sprintf("%s requires %s %s", r.RequiredBy, r.Name, constraint)
+}
+
+// constraint parses the version constraint of the requirement
+func (r Requirement) constraint() (*semver.Constraints, error) {
+	constraint := r.Constraint
+	if constraint == "" {
+		constraint = anyVersion
+	}
+
+	c, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version constraint %q for pack %s: %w", r.Constraint, r.Name, err)
+	}
+
+	return c, nil
+}
+
+// ConflictError explains why no version of a pack satisfies its requirements
+type ConflictError struct {
+	Pack         string
+	Requirements []Requirement
+	// Available lists the known versions, annotated when they are incompatible with the running CLI
+	Available []string
+}
+
+func (e *ConflictError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "cannot resolve pack %s:", e.Pack)
+	for _, req := range e.Requirements {
+		fmt.Fprintf(&b, "\n  - %s", req)
+	}
+
+	if len(e.Available) == 0 {
+		fmt.Fprintf(&b, "\n  no versions of %s are available in the configured registries or local packs", e.Pack)
+	} else {
+		fmt.Fprintf(&b, "\n  available versions: %s", strings.Join(e.Available, ", "))
+	}
+
+	return b.String()
+}
+
+// Resolver selects compatible pack versions from a catalog
+type Resolver struct {
+	catalog    *Catalog
+	cliVersion string
+}
+
+// New creates a resolver that only selects packs supported by the given CLI version
+func New(catalog *Catalog, cliVersion string) *Resolver {
+	return &Resolver{catalog: catalog, cliVersion: cliVersion}
+}
+
+// state is a partial solution of the resolution
+type state struct {
+	selected     map[string]Candidate
+	requirements map[string][]Requirement
+}
+
+// with returns a copy of the state with the candidate selected under the given requirements
+func (s state) with(name string, candidate *Candidate, reqs []Requirement) state {
+	next := state{
+		selected:     maps.Clone(s.selected),
+		requirements: maps.Clone(s.requirements),
+	}
+	if candidate != nil {
+		next.selected[name] = *candidate
+	}
+	next.requirements[name] = reqs
+
+	return next
+}
+
+// Resolve picks the newest version of every requested pack and its transitive dependencies
+// that satisfies all constraints, backtracking to older versions on conflicts.
+// Packs are returned in installation order, dependencies first.
+func (r *Resolver) Resolve(requests []Requirement) ([]Candidate, error) {
+	for _, req := range requests {
+		if _, err := req.constraint(); err != nil {
+			return nil, err
+		}
+	}
+
+	solution, err := r.solve(state{
+		selected:     make(map[string]Candidate),
+		requirements: make(map[string][]Requirement),
+	}, requests)
+	if err != nil {
+		return nil, err
+	}
+
+	return installOrder(solution.selected, requests), nil
+}
+
+// solve processes pending requirements depth first
+func (r *Resolver) solve(current state, pending []Requirement) (state, error) {
+	if len(pending) == 0 {
+		return current, nil
+	}
+
+	req, rest := pending[0], pending[1:]
+	name := strings.ToLower(req.Name)
+
+	constraint, err := req.constraint()
+	if err != nil {
+		return state{}, err
+	}
+
+	reqs := append(slices.Clone(current.requirements[name]), req)
+
+	if selected, ok := current.selected[name]; ok {
+		if !constraint.Check(selected.version) {
+			return state{}, r.conflict(name, reqs)
+		}
+		return r.solve(current.with(name, nil, reqs), rest)
+	}
+
+	var firstErr error
+	for _, candidate := range r.catalog.Versions(name) {
+		if !r.satisfies(candidate, reqs) {
+			continue
+		}
+
+		deps := make([]Requirement, 0, len(candidate.Dependencies))
+		for _, dep := range slices.Sorted(maps.Keys(candidate.Dependencies)) {
+			deps = append(deps, Requirement{Name: dep, Constraint: candidate.Dependencies[dep], RequiredBy: candidate.ID()})
+		}
+
+		solution, err := r.solve(current.with(name, &candidate, reqs), append(slices.Clone(rest), deps...))
+		if err == nil {
+			return solution, nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	if firstErr != nil {
+		return state{}, firstErr
+	}
+
+	return state{}, r.conflict(name, reqs)
+}
+
+// Select returns the newest version of a pack that satisfies the requirement without resolving
+// its dependencies or checking CLI compatibility, e.g. to inspect the files of a pack version
+func (r *Resolver) Select(req Requirement) (Candidate, error) {
+	constraint, err := req.constraint()
+	if err != nil {
+		return Candidate{}, err
+	}
+
+	for _, candidate := range r.catalog.Versions(req.Name) {
+		if constraint.Check(candidate.version) {
+			return candidate, nil
+		}
+	}
+
+	return Candidate{}, r.conflict(req.Name, []Requirement{req})
+}
+
+// satisfies reports whether the candidate meets all requirements and supports the running CLI
+func (r *Resolver) satisfies(candidate Candidate, reqs []Requirement) bool {
+	for _, req := range reqs {
+		constraint, err := req.constraint()
+		if err != nil || !constraint.Check(candidate.version) {
+			return false
+		}
+	}
+
+	return version.CheckMinimumVersion(r.cliVersion, candidate.MinCLIVersion) == nil
+}
+
+// conflict builds the error explaining why no version of the pack can be selected
+func (r *Resolver) conflict(name string, reqs []Requirement) error {
+	conflict := &ConflictError{Pack: name, Requirements: reqs}
+	for _, candidate := range r.catalog.Versions(name) {
+		entry := candidate.Version
+		if version.CheckMinimumVersion(r.cliVersion, candidate.MinCLIVersion) != nil {
+			entry += fmt.Sprintf(" (requires krci-ai >= %s, running %s)", candidate.MinCLIVersion, r.cliVersion)
+		}
+		conflict.Available = append(conflict.Available, entry)
+	}
+
+	return conflict
+}
+
+// installOrder returns the selected packs with every dependency before its dependents
+func installOrder(selected map[string]Candidate, requests []Requirement) []Candidate {
+	var (
+		order   []Candidate
+		visited = make(map[string]bool)
+		visit   func(name string)
+	)
+
+	visit = func(name string) {
+		candidate, ok := selected[name]
+		if !ok || visited[name] {
+			return
+		}
+		visited[name] = true
+
+		for _, dep := range slices.Sorted(maps.Keys(candidate.Dependencies)) {
+			visit(strings.ToLower(dep))
+		}
+		order = append(order, candidate)
+	}
+
+	for _, req := range requests {
+		visit(strings.ToLower(req.Name))
+	}
+
+	return order
+}
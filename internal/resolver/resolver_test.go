@@ -0,0 +1,238 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package resolver
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KubeRocketCI/kuberocketai/internal/pack"
+	"github.com/KubeRocketCI/kuberocketai/internal/registry"
+)
+
+func newTestCatalog(t *testing.T, candidates ...Candidate) *Catalog {
+	t.Helper()
+
+	catalog := NewCatalog()
+	for _, c := range candidates {
+		require.NoError(t, catalog.Add(c))
+	}
+
+	return catalog
+}
+
+func ids(candidates []Candidate) []string {
+	result := make([]string, 0, len(candidates))
+	for _, c := range candidates {
+		result = append(result, c.ID())
+	}
+
+	return result
+}
+
+func TestParseRequirement(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    Requirement
+		wantErr string
+	}{
+		{spec: "golden-agents", want: Requirement{Name: "golden-agents"}},
+		{spec: "golden-agents@^1.2", want: Requirement{Name: "golden-agents", Constraint: "^1.2"}},
+		{spec: "golden-agents@>=1.0, <2.0", want: Requirement{Name: "golden-agents", Constraint: ">=1.0, <2.0"}},
+		{spec: "@1.0.0", wantErr: "missing pack name"},
+		{spec: "golden-agents@latest", wantErr: "invalid version constraint"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := ParseRequirement(tt.spec)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestResolver_Resolve(t *testing.T) {
+	catalog := newTestCatalog(t,
+		Candidate{Name: "shared-standards", Version: "1.1.0"},
+		Candidate{Name: "shared-standards", Version: "1.2.0"},
+		Candidate{Name: "shared-standards", Version: "1.3.0"},
+		Candidate{Name: "shared-standards", Version: "2.0.0"},
+		Candidate{Name: "shared-standards", Version: "2.1.0", MinCLIVersion: "9.0.0"},
+		Candidate{Name: "golden-agents", Version: "1.0.0", Dependencies: map[string]string{"shared-standards": "^1.2"}},
+		Candidate{Name: "golden-agents", Version: "2.0.0", Dependencies: map[string]string{"shared-standards": "^2.0"}},
+		Candidate{Name: "qa-pack", Version: "0.1.0", Dependencies: map[string]string{"shared-standards": "~1.2.0"}},
+		Candidate{Name: "legacy-pack", Version: "1.0.0", Dependencies: map[string]string{"shared-standards": "<1.2"}},
+		Candidate{Name: "future-pack", Version: "1.0.0", MinCLIVersion: "9.0.0"},
+		Candidate{Name: "broken-pack", Version: "1.0.0", Dependencies: map[string]string{"missing-pack": "^1.0"}},
+	)
+
+	tests := []struct {
+		name     string
+		requests []string
+		want     []string
+		wantErr  []string
+	}{
+		{
+			name:     "newest compatible version with dependencies first",
+			requests: []string{"golden-agents"},
+			want:     []string{"shared-standards@2.0.0", "golden-agents@2.0.0"},
+		},
+		{
+			name:     "constraint on root",
+			requests: []string{"golden-agents@^1"},
+			want:     []string{"shared-standards@1.3.0", "golden-agents@1.0.0"},
+		},
+		{
+			name:     "backtracks to satisfy shared dependency",
+			requests: []string{"golden-agents", "qa-pack"},
+			want:     []string{"shared-standards@1.2.0", "golden-agents@1.0.0", "qa-pack@0.1.0"},
+		},
+		{
+			name:     "conflicting dependency constraints",
+			requests: []string{"legacy-pack", "qa-pack"},
+			wantErr: []string{
+				"cannot resolve pack shared-standards",
+				"legacy-pack@1.0.0 requires shared-standards <1.2",
+				"qa-pack@0.1.0 requires shared-standards ~1.2.0",
+				"available versions: 2.1.0 (requires krci-ai >= 9.0.0, running 1.0.0), 2.0.0, 1.3.0, 1.2.0, 1.1.0",
+			},
+		},
+		{
+			name:     "pack requires newer CLI",
+			requests: []string{"future-pack"},
+			wantErr:  []string{"requested future-pack *", "1.0.0 (requires krci-ai >= 9.0.0, running 1.0.0)"},
+		},
+		{
+			name:     "missing dependency",
+			requests: []string{"broken-pack"},
+			wantErr:  []string{"broken-pack@1.0.0 requires missing-pack ^1.0", "no versions of missing-pack are available"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []Requirement
+			for _, spec := range tt.requests {
+				req, err := ParseRequirement(spec)
+				require.NoError(t, err)
+				requests = append(requests, req)
+			}
+
+			got, err := New(catalog, "1.0.0").Resolve(requests)
+			if len(tt.wantErr) > 0 {
+				require.Error(t, err)
+				var conflict *ConflictError
+				assert.True(t, errors.As(err, &conflict))
+				for _, msg := range tt.wantErr {
+					assert.Contains(t, err.Error(), msg)
+				}
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, ids(got))
+		})
+	}
+}
+
+func TestResolver_DependencyCycle(t *testing.T) {
+	catalog := newTestCatalog(t,
+		Candidate{Name: "a", Version: "1.0.0", Dependencies: map[string]string{"b": "^1"}},
+		Candidate{Name: "b", Version: "1.0.0", Dependencies: map[string]string{"a": "^1"}},
+	)
+
+	got, err := New(catalog, "dev").Resolve([]Requirement{{Name: "a"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b@1.0.0", "a@1.0.0"}, ids(got))
+}
+
+func TestResolver_Select(t *testing.T) {
+	catalog := newTestCatalog(t,
+		Candidate{Name: "golden-agents", Version: "1.0.0", Dependencies: map[string]string{"missing-pack": "^1.0"}},
+		Candidate{Name: "golden-agents", Version: "1.1.0", MinCLIVersion: "9.0.0"},
+		Candidate{Name: "golden-agents", Version: "2.0.0"},
+	)
+	r := New(catalog, "1.0.0")
+
+	got, err := r.Select(Requirement{Name: "golden-agents", Constraint: "^1.0"})
+	require.NoError(t, err)
+	assert.Equal(t, "golden-agents@1.1.0", got.ID())
+
+	got, err = r.Select(Requirement{Name: "golden-agents", Constraint: "1.0.0"})
+	require.NoError(t, err)
+	assert.Equal(t, "golden-agents@1.0.0", got.ID())
+
+	_, err = r.Select(Requirement{Name: "golden-agents", Constraint: "^3"})
+	var conflict *ConflictError
+	require.ErrorAs(t, err, &conflict)
+	assert.Len(t, conflict.Available, 3)
+}
+
+func TestCatalog_Sources(t *testing.T) {
+	dir := t.TempDir()
+	manifest := &pack.Manifest{
+		Name:         "shared-standards",
+		Version:      "1.2.0",
+		Agents:       []string{"pm"},
+		Dependencies: map[string]string{"base": "^1"},
+	}
+	archive, checksum, err := pack.WriteFile(dir, manifest, map[string][]byte{"agents/pm.yaml": []byte("agent: {}\n")})
+	require.NoError(t, err)
+
+	index := `{"indexVersion": 1, "packs": [{"name": "shared-standards", "versions": [
+		{"version": "1.2.0", "url": "remote-1.2.0.tar.gz", "sha256": "remote"},
+		{"version": "1.3.0", "url": "remote-1.3.0.tar.gz", "sha256": "newer", "dependencies": {"base": "^2"}},
+		{"version": "not-a-version", "url": "broken.tar.gz"}
+	]}]}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, registry.IndexFile), []byte(index), 0644))
+
+	client := registry.NewClient("file://" + filepath.ToSlash(dir))
+	parsed, err := client.GetIndex(context.Background())
+	require.NoError(t, err)
+
+	catalog := NewCatalog()
+	require.NoError(t, catalog.AddDirectory(dir))
+	require.NoError(t, catalog.AddDirectory(filepath.Join(dir, "missing")))
+	catalog.AddIndex(client, parsed)
+
+	assert.Equal(t, 2, catalog.Len())
+
+	versions := catalog.Versions("Shared-Standards")
+	require.Len(t, versions, 2)
+
+	assert.Equal(t, "1.3.0", versions[0].Version)
+	assert.Equal(t, filepath.ToSlash(dir)+"/remote-1.3.0.tar.gz", versions[0].Location)
+	assert.Equal(t, map[string]string{"base": "^2"}, versions[0].Dependencies)
+
+	// Local packs are added first and take precedence over the registry
+	assert.Equal(t, "1.2.0", versions[1].Version)
+	assert.Equal(t, archive, versions[1].Location)
+	assert.Equal(t, checksum, versions[1].SHA256)
+	assert.Equal(t, OriginLocal, versions[1].Origin)
+	assert.Equal(t, map[string]string{"base": "^1"}, versions[1].Dependencies)
+}
@@ -0,0 +1,214 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Package roomodes maintains the krci-ai modes inside the Roo Code project modes file .roomodes,
+// which may also hold custom modes of the team. Modes whose slug does not start with the krci-ai
+// prefix belong to the user and are never changed.
+package roomodes
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// FileName is the project modes file of Roo Code
+	FileName = ".roomodes"
+	// Prefix starts the slug of the modes managed by krci-ai, keeping them apart from the
+	// built-in modes of Roo Code, e.g. architect
+	Prefix = "krci-ai-"
+
+	modesKey = "customModes"
+	slugKey  = "slug"
+)
+
+// Mode is a custom mode of Roo Code
+type Mode struct {
+	Slug               string   `yaml:"slug"`
+	Name               string   `yaml:"name"`
+	RoleDefinition     string   `yaml:"roleDefinition"`
+	WhenToUse          string   `yaml:"whenToUse,omitempty"`
+	CustomInstructions string   `yaml:"customInstructions"`
+	Groups             []string `yaml:"groups"`
+}
+
+// Upsert replaces the managed modes of content with modes, in place of the first managed mode or
+// after the modes of the user when content has none. Other modes and settings are kept as they are.
+func Upsert(content []byte, modes []Mode) ([]byte, error) {
+	doc, list, err := parse(content)
+	if err != nil {
+		return nil, err
+	}
+
+	generated := make([]*yaml.Node, 0, len(modes))
+	for _, mode := range modes {
+		var node yaml.Node
+		if err := node.Encode(mode); err != nil {
+			return nil, fmt.Errorf("failed to encode mode %s: %w", mode.Slug, err)
+		}
+		generated = append(generated, &node)
+	}
+
+	items := make([]*yaml.Node, 0, len(list.Content)+len(generated))
+	inserted := false
+	for _, item := range list.Content {
+		if !managed(item) {
+			items = append(items, item)
+			continue
+		}
+		if !inserted {
+			items = append(items, generated...)
+			inserted = true
+		}
+	}
+	if !inserted {
+		items = append(items, generated...)
+	}
+	list.Content = items
+
+	return encode(doc)
+}
+
+// Extract returns the managed modes of content encoded as a YAML list, false when there are none
+func Extract(content []byte) ([]byte, bool) {
+	_, list, err := parse(content)
+	if err != nil {
+		return nil, false
+	}
+
+	extracted := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+	for _, item := range list.Content {
+		if managed(item) {
+			extracted.Content = append(extracted.Content, item)
+		}
+	}
+	if len(extracted.Content) == 0 {
+		return nil, false
+	}
+
+	data, err := encode(extracted)
+	if err != nil {
+		return nil, false
+	}
+
+	return data, true
+}
+
+// Remove removes the managed modes from content, reporting whether content had any.
+// The result is empty when nothing of the user is left.
+func Remove(content []byte) ([]byte, bool, error) {
+	doc, list, err := parse(content)
+	if err != nil {
+		return nil, false, err
+	}
+
+	items := make([]*yaml.Node, 0, len(list.Content))
+	for _, item := range list.Content {
+		if !managed(item) {
+			items = append(items, item)
+		}
+	}
+	if len(items) == len(list.Content) {
+		return content, false, nil
+	}
+	list.Content = items
+
+	// Only the emptied list of modes is left
+	if len(items) == 0 && len(doc.Content[0].Content) == 2 {
+		return nil, true, nil
+	}
+
+	rest, err := encode(doc)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return rest, true, nil
+}
+
+// parse returns the document of content and its list of modes, added when missing
+func parse(content []byte) (*yaml.Node, *yaml.Node, error) {
+	doc := &yaml.Node{Kind: yaml.DocumentNode}
+	if len(bytes.TrimSpace(content)) > 0 {
+		if err := yaml.Unmarshal(content, doc); err != nil {
+			return nil, nil, fmt.Errorf("failed to parse %s: %w", FileName, err)
+		}
+	}
+	if len(doc.Content) == 0 {
+		doc.Content = []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, nil, fmt.Errorf("failed to parse %s: expected a mapping with %s", FileName, modesKey)
+	}
+	root.Style &^= yaml.FlowStyle
+
+	for idx := 0; idx+1 < len(root.Content); idx += 2 {
+		if root.Content[idx].Value != modesKey {
+			continue
+		}
+
+		list := root.Content[idx+1]
+		switch {
+		case list.Kind == yaml.SequenceNode:
+		case list.Kind == yaml.ScalarNode && list.Tag == "!!null":
+			*list = yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		default:
+			return nil, nil, fmt.Errorf("failed to parse %s: %s is not a list", FileName, modesKey)
+		}
+		list.Style &^= yaml.FlowStyle
+
+		return doc, list, nil
+	}
+
+	list := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+	root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: modesKey}, list)
+
+	return doc, list, nil
+}
+
+// managed reports whether a mode of the list is managed by krci-ai
+func managed(item *yaml.Node) bool {
+	if item.Kind != yaml.MappingNode {
+		return false
+	}
+
+	for idx := 0; idx+1 < len(item.Content); idx += 2 {
+		if item.Content[idx].Value == slugKey {
+			return strings.HasPrefix(item.Content[idx+1].Value, Prefix)
+		}
+	}
+
+	return false
+}
+
+// encode writes a YAML node with the indentation of Roo Code modes files
+func encode(node *yaml.Node) ([]byte, error) {
+	var out bytes.Buffer
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(node); err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", FileName, err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", FileName, err)
+	}
+
+	return out.Bytes(), nil
+}
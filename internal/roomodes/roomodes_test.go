@@ -0,0 +1,86 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package roomodes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpsert(t *testing.T) {
+	modes := []Mode{{Slug: Prefix + "dev", Name: "Developer", RoleDefinition: "You develop", Groups: []string{"read"}}}
+	generated := "  - slug: krci-ai-dev\n    name: Developer\n    roleDefinition: You develop\n    customInstructions: \"\"\n    groups:\n      - read\n"
+	team := "  - slug: team-reviewer\n    name: Reviewer\n    groups: [read]\n"
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{name: "empty file", content: "", want: "customModes:\n" + generated},
+		{name: "empty list", content: "customModes: []\n", want: "customModes:\n" + generated},
+		{name: "other settings", content: "version: 1\n", want: "version: 1\ncustomModes:\n" + generated},
+		{name: "append after user modes", content: "customModes:\n" + team, want: "customModes:\n" + team + generated},
+		{
+			name:    "replace managed modes in place",
+			content: "# Modes\ncustomModes:\n  - slug: krci-ai-pm\n    name: PM\n" + team + "  - slug: krci-ai-qa\n    name: QA\n",
+			want:    "# Modes\ncustomModes:\n" + generated + team,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, err := Upsert([]byte(tt.content), modes)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(content))
+		})
+	}
+
+	_, err := Upsert([]byte("customModes: not a list\n"), modes)
+	assert.Error(t, err)
+}
+
+func TestExtractAndRemove(t *testing.T) {
+	team := "customModes:\n  - slug: team-reviewer\n    name: Reviewer\n"
+	content, err := Upsert([]byte(team), []Mode{{Slug: Prefix + "dev", Name: "Developer"}})
+	require.NoError(t, err)
+
+	modes, ok := Extract(content)
+	assert.True(t, ok)
+	assert.Equal(t, "- slug: krci-ai-dev\n  name: Developer\n  roleDefinition: \"\"\n  customInstructions: \"\"\n  groups: []\n", string(modes))
+	_, ok = Extract([]byte(team))
+	assert.False(t, ok)
+
+	rest, found, err := Remove(content)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, team, string(rest))
+
+	rest, found, err = Remove([]byte(team))
+	require.NoError(t, err)
+	assert.False(t, found)
+	assert.Equal(t, team, string(rest))
+
+	// Nothing of the user is left
+	owned, err := Upsert(nil, []Mode{{Slug: Prefix + "dev"}})
+	require.NoError(t, err)
+	rest, found, err = Remove(owned)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Empty(t, rest)
+}
@@ -0,0 +1,116 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package section maintains krci-ai managed sections inside files shared with the user,
+// e.g. the list of agents in .github/copilot-instructions.md. Content outside the
+// section markers belongs to the user and is never changed.
+package section
+
+import (
+	"bytes"
+)
+
+const (
+	// Begin starts a managed section
+	Begin = "<!-- BEGIN krci-ai: managed section, changes are overwritten -->"
+	// End ends a managed section
+	End = "<!-- END krci-ai -->"
+)
+
+// Block returns the managed section with the given body, including its markers
+func Block(body []byte) []byte {
+	var block bytes.Buffer
+	block.WriteString(Begin + "\n")
+	block.Write(body)
+	if len(body) > 0 && !bytes.HasSuffix(body, []byte("\n")) {
+		block.WriteString("\n")
+	}
+	block.WriteString(End + "\n")
+
+	return block.Bytes()
+}
+
+// Extract returns the managed section of content including its markers, false when there is none
+func Extract(content []byte) ([]byte, bool) {
+	start, end, ok := bounds(content)
+	if !ok {
+		return nil, false
+	}
+
+	return content[start:end], true
+}
+
+// Upsert replaces the managed section of content with one holding body, or appends it
+// separated by a blank line when content has none
+func Upsert(content, body []byte) []byte {
+	block := Block(body)
+
+	start, end, ok := bounds(content)
+	if ok {
+		return bytes.Join([][]byte{content[:start], block, content[end:]}, nil)
+	}
+
+	var out bytes.Buffer
+	out.Write(content)
+	switch {
+	case len(content) == 0:
+	case bytes.HasSuffix(content, []byte("\n\n")):
+	case bytes.HasSuffix(content, []byte("\n")):
+		out.WriteString("\n")
+	default:
+		out.WriteString("\n\n")
+	}
+	out.Write(block)
+
+	return out.Bytes()
+}
+
+// Remove removes the managed section from content together with the blank line Upsert
+// added before it, reporting whether content had one
+func Remove(content []byte) ([]byte, bool) {
+	start, end, ok := bounds(content)
+	if !ok {
+		return content, false
+	}
+
+	before := content[:start]
+	if bytes.HasSuffix(before, []byte("\n\n")) {
+		before = before[:len(before)-1]
+	}
+
+	return append(bytes.Clone(before), content[end:]...), true
+}
+
+// bounds returns the offsets of the managed section in content, from its begin marker
+// through the line break after its end marker
+func bounds(content []byte) (int, int, bool) {
+	start := bytes.Index(content, []byte(Begin))
+	if start < 0 || (start > 0 && content[start-1] != '\n') {
+		return 0, 0, false
+	}
+
+	offset := bytes.Index(content[start:], []byte(End))
+	if offset < 0 {
+		return 0, 0, false
+	}
+
+	end := start + offset + len(End)
+	if end < len(content) && content[end] == '\n' {
+		end++
+	}
+
+	return start, end, true
+}
@@ -0,0 +1,76 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package section
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpsert(t *testing.T) {
+	block := Begin + "\n## Agents\n" + End + "\n"
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{name: "empty file", content: "", want: block},
+		{name: "append after text", content: "# Rules\nBe nice.\n", want: "# Rules\nBe nice.\n\n" + block},
+		{name: "append without trailing newline", content: "# Rules", want: "# Rules\n\n" + block},
+		{name: "append after blank line", content: "# Rules\n\n", want: "# Rules\n\n" + block},
+		{
+			name:    "replace existing section",
+			content: "# Rules\n\n" + Begin + "\n## Old\n" + End + "\nFooter\n",
+			want:    "# Rules\n\n" + block + "Footer\n",
+		},
+		{
+			name:    "marker not at line start is user content",
+			content: "see " + Begin + "\n",
+			want:    "see " + Begin + "\n\n" + block,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, string(Upsert([]byte(tt.content), []byte("## Agents\n"))))
+		})
+	}
+}
+
+func TestExtractAndRemove(t *testing.T) {
+	content := []byte("# Rules\n")
+	updated := Upsert(content, []byte("## Agents"))
+
+	block, ok := Extract(updated)
+	assert.True(t, ok)
+	assert.Equal(t, string(Block([]byte("## Agents\n"))), string(block))
+
+	removed, ok := Remove(updated)
+	assert.True(t, ok)
+	assert.Equal(t, string(content), string(removed))
+
+	_, ok = Extract(content)
+	assert.False(t, ok)
+	removed, ok = Remove(content)
+	assert.False(t, ok)
+	assert.Equal(t, string(content), string(removed))
+
+	// A begin marker without end marker is not a section
+	_, ok = Extract([]byte(Begin + "\n## Agents\n"))
+	assert.False(t, ok)
+}
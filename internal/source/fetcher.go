@@ -0,0 +1,359 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package source
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/KubeRocketCI/kuberocketai/internal/lockfile"
+)
+
+const (
+	// DefaultTimeout bounds tarball downloads
+	DefaultTimeout = 60 * time.Second
+	// MaxArchiveSize limits the size of downloaded and extracted archives
+	MaxArchiveSize = 100 << 20
+
+	directoryPermissions = 0755
+	filePermissions      = 0644
+)
+
+// Fetched is a framework pack made available on the local filesystem
+type Fetched struct {
+	// FrameworkDir is the directory containing agents, tasks, templates and data
+	FrameworkDir string
+	// Source describes the fetched pack for the lockfile
+	Source  lockfile.Source
+	tempDir string
+}
+
+// Cleanup removes temporary files created while fetching
+func (f *Fetched) Cleanup() {
+	if f.tempDir != "" {
+		_ = os.RemoveAll(f.tempDir)
+	}
+}
+
+// Fetcher downloads framework packs from git repositories, tarball URLs and local directories
+type Fetcher struct {
+	HTTPClient *http.Client
+	GitBinary  string
+}
+
+// NewFetcher creates a fetcher with default settings
+func NewFetcher() *Fetcher {
+	return &Fetcher{
+		HTTPClient: &http.Client{Timeout: DefaultTimeout},
+		GitBinary:  "git",
+	}
+}
+
+// Fetch makes the pack described by spec available locally.
+// Callers must call Cleanup on the result once the files are no longer needed.
+func (f *Fetcher) Fetch(ctx context.Context, spec Spec) (*Fetched, error) {
+	switch spec.Type {
+	case lockfile.SourceLocal:
+		return f.fetchLocal(spec)
+	case lockfile.SourceGit:
+		return f.withTempDir(func(dir string) (string, error) {
+			return f.fetchGit(ctx, spec, dir)
+		}, spec)
+	case lockfile.SourceTarball:
+		return f.withTempDir(func(dir string) (string, error) {
+			return f.fetchTarball(ctx, spec, dir)
+		}, spec)
+	default:
+		return nil, fmt.Errorf("unsupported source type %q", spec.Type)
+	}
+}
+
+// FetchRef exports the files under path at a ref of the git repository in repoDir,
+// e.g. the framework directory of the project at an earlier commit.
+// Callers must call Cleanup on the result once the files are no longer needed.
+func (f *Fetcher) FetchRef(ctx context.Context, repoDir, ref, path string) (*Fetched, error) {
+	if err := checkRef(ref); err != nil {
+		return nil, err
+	}
+
+	revision, err := f.git(ctx, repoDir, "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	if err != nil {
+		return nil, fmt.Errorf("ref %q not found in %s: %w", ref, repoDir, err)
+	}
+
+	spec := Spec{Type: lockfile.SourceGit, Location: repoDir, Ref: ref}
+	return f.withTempDir(func(dir string) (string, error) {
+		archive, err := f.git(ctx, repoDir, "archive", "--format=tar.gz", ref, "--", path)
+		if err != nil {
+			return "", fmt.Errorf("failed to export %s at %s: %w", path, ref, err)
+		}
+
+		if err := ExtractTarball(strings.NewReader(archive), dir); err != nil {
+			return "", fmt.Errorf("failed to extract %s at %s: %w", path, ref, err)
+		}
+
+		return strings.TrimSpace(revision), nil
+	}, spec)
+}
+
+// withTempDir runs fetch in a new temporary directory and locates the framework inside it
+func (f *Fetcher) withTempDir(fetch func(dir string) (string, error), spec Spec) (*Fetched, error) {
+	tempDir, err := os.MkdirTemp("", "krci-ai-source-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+
+	fetched := &Fetched{
+		Source:  lockfile.Source{Type: spec.Type, URL: spec.Location, Ref: spec.Ref},
+		tempDir: tempDir,
+	}
+
+	contentDir := filepath.Join(tempDir, "content")
+	revision, err := fetch(contentDir)
+	if err != nil {
+		fetched.Cleanup()
+		return nil, err
+	}
+	fetched.Source.Revision = revision
+
+	fetched.FrameworkDir, err = FindFrameworkDir(contentDir)
+	if err != nil {
+		fetched.Cleanup()
+		return nil, err
+	}
+
+	return fetched, nil
+}
+
+// fetchLocal uses a local directory in place
+func (f *Fetcher) fetchLocal(spec Spec) (*Fetched, error) {
+	dir, err := filepath.Abs(spec.Location)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve source directory %s: %w", spec.Location, err)
+	}
+
+	if !isDir(dir) {
+		return nil, fmt.Errorf("source directory %s does not exist", spec.Location)
+	}
+
+	frameworkDir, err := FindFrameworkDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Fetched{
+		FrameworkDir: frameworkDir,
+		Source:       lockfile.Source{Type: lockfile.SourceLocal, URL: spec.Location},
+	}, nil
+}
+
+// fetchGit clones the repository at the requested ref and returns the checked out commit
+func (f *Fetcher) fetchGit(ctx context.Context, spec Spec, dir string) (string, error) {
+	if err := checkRef(spec.Ref); err != nil {
+		return "", err
+	}
+
+	args := []string{"clone", "--quiet", "--depth", "1"}
+	if spec.Ref != "" {
+		args = append(args, "--branch", spec.Ref)
+	}
+
+	if _, err := f.git(ctx, "", append(args, "--", spec.Location, dir)...); err != nil {
+		if spec.Ref == "" {
+			return "", err
+		}
+
+		// The ref may be a commit, which cannot be cloned directly with --branch
+		_ = os.RemoveAll(dir)
+		if _, err := f.git(ctx, "", "clone", "--quiet", "--", spec.Location, dir); err != nil {
+			return "", err
+		}
+		if _, err := f.git(ctx, dir, "checkout", "--quiet", "--detach", spec.Ref); err != nil {
+			return "", fmt.Errorf("ref %q not found in %s: %w", spec.Ref, spec.Location, err)
+		}
+	}
+
+	revision, err := f.git(ctx, dir, "rev-parse", "HEAD")
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(revision), nil
+}
+
+// checkRef rejects refs git would take for an option
+func checkRef(ref string) error {
+	if strings.HasPrefix(ref, "-") {
+		return fmt.Errorf("invalid ref %q: refs must not start with '-'", ref)
+	}
+
+	return nil
+}
+
+// git runs a git command and returns its standard output
+func (f *Fetcher) git(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, f.GitBinary, args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return "", fmt.Errorf("git is required to install from git sources: %w", err)
+		}
+		return "", fmt.Errorf("git %s failed: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.String(), nil
+}
+
+// fetchTarball downloads or reads and extracts a gzipped tar archive, returning the archive SHA-256
+func (f *Fetcher) fetchTarball(ctx context.Context, spec Spec, dir string) (string, error) {
+	data, err := f.readTarball(ctx, spec.Location)
+	if err != nil {
+		return "", err
+	}
+
+	if err := ExtractTarball(bytes.NewReader(data), dir); err != nil {
+		return "", fmt.Errorf("failed to extract %s: %w", spec.Location, err)
+	}
+
+	return lockfile.Hash(data), nil
+}
+
+// readTarball returns the archive content from an http(s) URL or a local file
+func (f *Fetcher) readTarball(ctx context.Context, location string) ([]byte, error) {
+	if !strings.HasPrefix(location, "http://") && !strings.HasPrefix(location, "https://") {
+		info, err := os.Stat(location)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive %s: %w", location, err)
+		}
+		if info.Size() > MaxArchiveSize {
+			return nil, fmt.Errorf("archive %s exceeds the maximum size of %d bytes", location, MaxArchiveSize)
+		}
+
+		data, err := os.ReadFile(location)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive %s: %w", location, err)
+		}
+		return data, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := f.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", location, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download %s: unexpected status %s", location, resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, MaxArchiveSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", location, err)
+	}
+	if len(data) > MaxArchiveSize {
+		return nil, fmt.Errorf("archive %s exceeds the maximum size of %d bytes", location, MaxArchiveSize)
+	}
+
+	return data, nil
+}
+
+// ExtractTarball extracts a gzipped tar archive into dir.
+// Entries escaping dir and non-regular files such as symlinks are rejected or skipped.
+func ExtractTarball(r io.Reader, dir string) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return fmt.Errorf("failed to open gzip stream: %w", err)
+	}
+	defer func() { _ = gz.Close() }()
+
+	if err := os.MkdirAll(dir, directoryPermissions); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	var extracted int64
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read archive: %w", err)
+		}
+
+		target := filepath.Join(dir, filepath.FromSlash(header.Name))
+		if target != filepath.Clean(dir) && !strings.HasPrefix(target, filepath.Clean(dir)+string(os.PathSeparator)) {
+			return fmt.Errorf("archive entry %q escapes the target directory", header.Name)
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, directoryPermissions); err != nil {
+				return fmt.Errorf("failed to create directory %s: %w", target, err)
+			}
+		case tar.TypeReg:
+			extracted += header.Size
+			if extracted > MaxArchiveSize {
+				return fmt.Errorf("archive content exceeds the maximum size of %d bytes", MaxArchiveSize)
+			}
+
+			if err := writeFile(target, tr); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// writeFile writes a single archive entry to disk
+func writeFile(path string, r io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(path), directoryPermissions); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", path, err)
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, filePermissions)
+	if err != nil {
+		return fmt.Errorf("failed to create file %s: %w", path, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	if _, err := io.Copy(file, r); err != nil {
+		return fmt.Errorf("failed to write file %s: %w", path, err)
+	}
+
+	return nil
+}
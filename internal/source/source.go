@@ -0,0 +1,193 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package source
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/KubeRocketCI/kuberocketai/internal/lockfile"
+)
+
+// frameworkDirCandidates are the locations of the framework inside a fetched pack, in lookup order
+var frameworkDirCandidates = []string{
+	".",
+	".krci-ai",
+	filepath.Join("assets", "framework", "core"),
+	filepath.Join("framework", "core"),
+	filepath.Join("cmd", "krci-ai", "assets", "framework", "core"),
+}
+
+// Spec describes a framework pack location given as <git-url|https-tarball|local-dir>[@ref]
+type Spec struct {
+	Type     string
+	Location string
+	Ref      string
+}
+
+// String returns the spec in its command line form
+func (s Spec) String() string {
+	if s.Ref == "" {
+		return s.Location
+	}
+
+	return s.Location + "@" + s.Ref
+}
+
+// ParseSpec parses a pack location. Tarballs (remote or local files) are recognized by their
+// .tar.gz or .tgz extension, git repositories by their URL scheme or .git suffix, anything else is a local directory.
+func ParseSpec(raw string) (Spec, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return Spec{}, fmt.Errorf("source location is empty")
+	}
+
+	location, ref := splitRef(raw)
+	spec := Spec{Location: location, Ref: ref, Type: detectType(location)}
+
+	if spec.Ref != "" && spec.Type != lockfile.SourceGit {
+		return Spec{}, fmt.Errorf("ref %q is only supported for git sources, got %s source %s", spec.Ref, spec.Type, spec.Location)
+	}
+	if err := checkRef(spec.Ref); err != nil {
+		return Spec{}, err
+	}
+
+	return spec, nil
+}
+
+// splitRef splits the optional @ref suffix, ignoring the user part of scp-like git URLs (git@host:org/repo)
+func splitRef(raw string) (string, string) {
+	idx := strings.LastIndex(raw, "@")
+	if idx <= 0 || idx == len(raw)-1 {
+		return raw, ""
+	}
+
+	if idx < strings.LastIndex(raw, "/") || idx < strings.Index(raw, ":") {
+		return raw, ""
+	}
+
+	return raw[:idx], raw[idx+1:]
+}
+
+// detectType returns the source type for a location
+func detectType(location string) string {
+	if strings.HasPrefix(location, "git@") {
+		return lockfile.SourceGit
+	}
+
+	if parsed, err := url.Parse(location); err == nil && parsed.Scheme != "" && len(parsed.Scheme) > 1 {
+		switch parsed.Scheme {
+		case "http", "https":
+			if isTarball(parsed.Path) {
+				return lockfile.SourceTarball
+			}
+			return lockfile.SourceGit
+		default:
+			return lockfile.SourceGit
+		}
+	}
+
+	if strings.HasSuffix(strings.TrimSuffix(location, "/"), ".git") {
+		return lockfile.SourceGit
+	}
+
+	if isTarball(location) {
+		return lockfile.SourceTarball
+	}
+
+	return lockfile.SourceLocal
+}
+
+// isTarball reports whether the path looks like a gzipped tar archive
+func isTarball(path string) bool {
+	return strings.HasSuffix(path, ".tar.gz") || strings.HasSuffix(path, ".tgz")
+}
+
+// FindFrameworkDir locates the framework directory (the one containing agents/) inside a fetched pack.
+// Archives with a single top level directory, such as GitHub tarballs, are unwrapped first.
+func FindFrameworkDir(root string) (string, error) {
+	for _, candidate := range frameworkDirCandidates {
+		dir := filepath.Join(root, candidate)
+		if isDir(filepath.Join(dir, "agents")) {
+			return filepath.Clean(dir), nil
+		}
+	}
+
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return "", fmt.Errorf("failed to read source directory %s: %w", root, err)
+	}
+
+	if len(entries) == 1 && entries[0].IsDir() {
+		return FindFrameworkDir(filepath.Join(root, entries[0].Name()))
+	}
+
+	return "", fmt.Errorf("no framework found in %s: expected an agents directory", root)
+}
+
+// Digest returns the SHA-256 of the files of a fetched pack directory, verified for packs published
+// as git repositories or directories. It is the hash of a "<sha256>  ./<path>" line per file sorted
+// by path, the output of 'find . -type f -not -path "./.git/*" -exec sha256sum {} + | LC_ALL=C sort -k2'.
+// Git metadata is ignored.
+func Digest(dir string) (string, error) {
+	var lines []string
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if entry.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		lines = append(lines, fmt.Sprintf("%s  ./%s\n", lockfile.Hash(data), filepath.ToSlash(rel)))
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to compute digest of %s: %w", dir, err)
+	}
+	slices.SortFunc(lines, func(a, b string) int {
+		return strings.Compare(a[sha256.Size*2:], b[sha256.Size*2:])
+	})
+
+	sum := sha256.Sum256([]byte(strings.Join(lines, "")))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
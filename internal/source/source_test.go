@@ -0,0 +1,399 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package source
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KubeRocketCI/kuberocketai/internal/lockfile"
+)
+
+func TestParseSpec(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    Spec
+		wantErr string
+	}{
+		{
+			name: "https git repository with tag",
+			raw:  "https://github.com/org/agents.git@v1.2.0",
+			want: Spec{Type: lockfile.SourceGit, Location: "https://github.com/org/agents.git", Ref: "v1.2.0"},
+		},
+		{
+			name: "https git repository without suffix",
+			raw:  "https://github.com/org/agents",
+			want: Spec{Type: lockfile.SourceGit, Location: "https://github.com/org/agents"},
+		},
+		{
+			name: "scp-like git url",
+			raw:  "git@github.com:org/agents.git",
+			want: Spec{Type: lockfile.SourceGit, Location: "git@github.com:org/agents.git"},
+		},
+		{
+			name: "scp-like git url with ref",
+			raw:  "git@github.com:org/agents.git@main",
+			want: Spec{Type: lockfile.SourceGit, Location: "git@github.com:org/agents.git", Ref: "main"},
+		},
+		{
+			name: "local bare repository",
+			raw:  "/srv/git/agents.git@release",
+			want: Spec{Type: lockfile.SourceGit, Location: "/srv/git/agents.git", Ref: "release"},
+		},
+		{
+			name: "tarball",
+			raw:  "https://example.com/packs/agents.tar.gz",
+			want: Spec{Type: lockfile.SourceTarball, Location: "https://example.com/packs/agents.tar.gz"},
+		},
+		{
+			name: "local tarball",
+			raw:  "dist/golden-agents-1.2.0.tar.gz",
+			want: Spec{Type: lockfile.SourceTarball, Location: "dist/golden-agents-1.2.0.tar.gz"},
+		},
+		{
+			name: "local directory",
+			raw:  "../golden-agents",
+			want: Spec{Type: lockfile.SourceLocal, Location: "../golden-agents"},
+		},
+		{
+			name:    "tarball with ref",
+			raw:     "https://example.com/agents.tgz@v1",
+			wantErr: "only supported for git sources",
+		},
+		{
+			name:    "option-like ref",
+			raw:     "https://github.com/org/agents.git@--upload-pack=touch",
+			wantErr: "must not start with '-'",
+		},
+		{
+			name:    "empty",
+			raw:     " ",
+			wantErr: "source location is empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, err := ParseSpec(tt.raw)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, spec)
+		})
+	}
+}
+
+// writePack creates a minimal framework pack under dir
+func writePack(t *testing.T, dir string) {
+	t.Helper()
+	files := map[string]string{
+		"agents/pm.yaml":   "agent:\n  identity:\n    name: PM\n",
+		"tasks/prd.md":     "# PRD\n",
+		"data/standard.md": "# Standard\n",
+	}
+
+	for path, content := range files {
+		fullPath := filepath.Join(dir, filepath.FromSlash(path))
+		require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0755))
+		require.NoError(t, os.WriteFile(fullPath, []byte(content), 0644))
+	}
+}
+
+func TestFindFrameworkDir(t *testing.T) {
+	t.Run("framework at root", func(t *testing.T) {
+		root := t.TempDir()
+		writePack(t, root)
+
+		dir, err := FindFrameworkDir(root)
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Clean(root), dir)
+	})
+
+	t.Run("framework in .krci-ai", func(t *testing.T) {
+		root := t.TempDir()
+		writePack(t, filepath.Join(root, ".krci-ai"))
+
+		dir, err := FindFrameworkDir(root)
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(root, ".krci-ai"), dir)
+	})
+
+	t.Run("single top level directory", func(t *testing.T) {
+		root := t.TempDir()
+		writePack(t, filepath.Join(root, "agents-1.0.0", "framework", "core"))
+
+		dir, err := FindFrameworkDir(root)
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(root, "agents-1.0.0", "framework", "core"), dir)
+	})
+
+	t.Run("no framework", func(t *testing.T) {
+		_, err := FindFrameworkDir(t.TempDir())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no framework found")
+	})
+}
+
+func TestDigest(t *testing.T) {
+	root := t.TempDir()
+	writePack(t, root)
+
+	digest, err := Digest(root)
+	require.NoError(t, err)
+
+	// The digest can be computed with standard tools when publishing a pack
+	script := `find . -type f -not -path "./.git/*" -exec sha256sum {} + | LC_ALL=C sort -k2 | sha256sum | cut -d" " -f1`
+	cmd := exec.Command("sh", "-c", script)
+	cmd.Dir = root
+	out, err := cmd.Output()
+	require.NoError(t, err)
+	assert.Equal(t, string(bytes.TrimSpace(out)), digest)
+
+	// Git metadata does not count, content does
+	require.NoError(t, os.MkdirAll(filepath.Join(root, ".git"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, ".git", "HEAD"), []byte("ref: refs/heads/main\n"), 0644))
+	unchanged, err := Digest(root)
+	require.NoError(t, err)
+	assert.Equal(t, digest, unchanged)
+
+	require.NoError(t, os.WriteFile(filepath.Join(root, "agents", "extra.yaml"), []byte("extra"), 0644))
+	changed, err := Digest(root)
+	require.NoError(t, err)
+	assert.NotEqual(t, digest, changed)
+}
+
+func TestFetch_Local(t *testing.T) {
+	root := t.TempDir()
+	writePack(t, root)
+
+	fetched, err := NewFetcher().Fetch(context.Background(), Spec{Type: lockfile.SourceLocal, Location: root})
+	require.NoError(t, err)
+	defer fetched.Cleanup()
+
+	assert.Equal(t, filepath.Clean(root), fetched.FrameworkDir)
+	assert.Equal(t, lockfile.SourceLocal, fetched.Source.Type)
+
+	_, err = NewFetcher().Fetch(context.Background(), Spec{Type: lockfile.SourceLocal, Location: filepath.Join(root, "missing")})
+	assert.Error(t, err)
+}
+
+// runGit runs a git command in dir and fails the test on error
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+	)
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+	return string(bytes.TrimSpace(out))
+}
+
+func TestFetch_Git(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not available")
+	}
+
+	root := t.TempDir()
+	bare := filepath.Join(root, "agents.git")
+	work := filepath.Join(root, "work")
+
+	runGit(t, root, "init", "--quiet", "--bare", bare)
+	runGit(t, root, "init", "--quiet", work)
+	writePack(t, filepath.Join(work, ".krci-ai"))
+	runGit(t, work, "add", "-A")
+	runGit(t, work, "commit", "--quiet", "-m", "v1")
+	runGit(t, work, "tag", "v1")
+	firstCommit := runGit(t, work, "rev-parse", "HEAD")
+
+	require.NoError(t, os.WriteFile(filepath.Join(work, ".krci-ai", "agents", "dev.yaml"), []byte("agent: {}\n"), 0644))
+	runGit(t, work, "add", "-A")
+	runGit(t, work, "commit", "--quiet", "-m", "v2")
+	runGit(t, work, "push", "--quiet", bare, "HEAD:refs/heads/main", "--tags")
+	runGit(t, root, "--git-dir", bare, "symbolic-ref", "HEAD", "refs/heads/main")
+
+	tests := []struct {
+		name     string
+		ref      string
+		hasDev   bool
+		revision string
+	}{
+		{name: "default branch", hasDev: true},
+		{name: "tag", ref: "v1", revision: firstCommit},
+		{name: "commit", ref: firstCommit, revision: firstCommit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fetched, err := NewFetcher().Fetch(context.Background(), Spec{Type: lockfile.SourceGit, Location: bare, Ref: tt.ref})
+			require.NoError(t, err)
+			defer fetched.Cleanup()
+
+			assert.FileExists(t, filepath.Join(fetched.FrameworkDir, "agents", "pm.yaml"))
+			_, err = os.Stat(filepath.Join(fetched.FrameworkDir, "agents", "dev.yaml"))
+			assert.Equal(t, tt.hasDev, err == nil)
+
+			assert.Equal(t, lockfile.SourceGit, fetched.Source.Type)
+			assert.Equal(t, tt.ref, fetched.Source.Ref)
+			assert.Len(t, fetched.Source.Revision, 40)
+			if tt.revision != "" {
+				assert.Equal(t, tt.revision, fetched.Source.Revision)
+			}
+
+			fetched.Cleanup()
+			assert.NoDirExists(t, fetched.FrameworkDir)
+		})
+	}
+
+	t.Run("unknown ref", func(t *testing.T) {
+		_, err := NewFetcher().Fetch(context.Background(), Spec{Type: lockfile.SourceGit, Location: bare, Ref: "v9"})
+		require.Error(t, err)
+	})
+
+	t.Run("option-like ref", func(t *testing.T) {
+		_, err := NewFetcher().Fetch(context.Background(), Spec{Type: lockfile.SourceGit, Location: bare, Ref: "--upload-pack=touch"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "must not start with '-'")
+	})
+
+	t.Run("option-like location", func(t *testing.T) {
+		marker := filepath.Join(root, "injected")
+		_, err := NewFetcher().Fetch(context.Background(), Spec{Type: lockfile.SourceGit, Location: "--upload-pack=touch " + marker})
+		require.Error(t, err)
+		assert.NoFileExists(t, marker)
+	})
+}
+
+// buildTarball creates a gzipped tar archive with the given entries
+func buildTarball(t *testing.T, entries map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+
+	for name, content := range entries {
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     0644,
+			Size:     int64(len(content)),
+			Typeflag: tar.TypeReg,
+		}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func TestFetchRef(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not available")
+	}
+
+	work := t.TempDir()
+	runGit(t, work, "init", "--quiet")
+	writePack(t, filepath.Join(work, ".krci-ai"))
+	runGit(t, work, "add", "-A")
+	runGit(t, work, "commit", "--quiet", "-m", "v1")
+	firstCommit := runGit(t, work, "rev-parse", "HEAD")
+
+	require.NoError(t, os.WriteFile(filepath.Join(work, ".krci-ai", "agents", "dev.yaml"), []byte("agent: {}\n"), 0644))
+	runGit(t, work, "add", "-A")
+	runGit(t, work, "commit", "--quiet", "-m", "v2")
+
+	fetched, err := NewFetcher().FetchRef(context.Background(), work, "HEAD~1", ".krci-ai")
+	require.NoError(t, err)
+	defer fetched.Cleanup()
+
+	assert.FileExists(t, filepath.Join(fetched.FrameworkDir, "agents", "pm.yaml"))
+	assert.NoFileExists(t, filepath.Join(fetched.FrameworkDir, "agents", "dev.yaml"))
+	assert.Equal(t, firstCommit, fetched.Source.Revision)
+
+	_, err = NewFetcher().FetchRef(context.Background(), work, "no-such-ref", ".krci-ai")
+	assert.Error(t, err)
+}
+
+func TestFetch_Tarball(t *testing.T) {
+	archive := buildTarball(t, map[string]string{
+		"golden-agents-1.0.0/agents/pm.yaml": "agent: {}\n",
+		"golden-agents-1.0.0/tasks/prd.md":   "# PRD\n",
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/golden-agents.tar.gz" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(archive)
+	}))
+	defer server.Close()
+
+	fetched, err := NewFetcher().Fetch(context.Background(), Spec{Type: lockfile.SourceTarball, Location: server.URL + "/golden-agents.tar.gz"})
+	require.NoError(t, err)
+	defer fetched.Cleanup()
+
+	assert.FileExists(t, filepath.Join(fetched.FrameworkDir, "agents", "pm.yaml"))
+	assert.FileExists(t, filepath.Join(fetched.FrameworkDir, "tasks", "prd.md"))
+	assert.Equal(t, lockfile.Hash(archive), fetched.Source.Revision)
+
+	_, err = NewFetcher().Fetch(context.Background(), Spec{Type: lockfile.SourceTarball, Location: server.URL + "/missing.tar.gz"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unexpected status")
+}
+
+func TestFetch_LocalTarball(t *testing.T) {
+	archive := buildTarball(t, map[string]string{
+		"pack.yaml":      "name: golden\n",
+		"agents/pm.yaml": "agent: {}\n",
+	})
+	path := filepath.Join(t.TempDir(), "golden-1.0.0.tar.gz")
+	require.NoError(t, os.WriteFile(path, archive, 0644))
+
+	fetched, err := NewFetcher().Fetch(context.Background(), Spec{Type: lockfile.SourceTarball, Location: path})
+	require.NoError(t, err)
+	defer fetched.Cleanup()
+
+	assert.FileExists(t, filepath.Join(fetched.FrameworkDir, "agents", "pm.yaml"))
+	assert.Equal(t, lockfile.Hash(archive), fetched.Source.Revision)
+}
+
+func TestExtractTarball_RejectsPathTraversal(t *testing.T) {
+	archive := buildTarball(t, map[string]string{"../escape.txt": "x"})
+
+	err := ExtractTarball(bytes.NewReader(archive), t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "escapes the target directory")
+}
@@ -42,6 +42,7 @@ type Calculator struct {
 	engine     *Engine
 	discovery  DiscoveryInterface
 	projectDir string
+	bundleDir  string
 }
 
 // NewCalculator creates a new token calculator with GPT-4 tokenization
@@ -57,6 +58,22 @@ func NewCalculator(projectDir string) (*Calculator, error) {
 	return NewCalculatorWithDependencies(engine, discovery, projectDir), nil
 }
 
+// NewCalculatorForModel creates a new token calculator using the tokenizer of the given model
+func NewCalculatorForModel(projectDir, model string) (*Calculator, error) {
+	engine, err := NewEngineForModel(model)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCalculatorWithDependencies(engine, assets.NewDiscovery(projectDir), projectDir), nil
+}
+
+// WithBundleDir sets the directory where bundle files are looked up
+func (c *Calculator) WithBundleDir(bundleDir string) *Calculator {
+	c.bundleDir = bundleDir
+	return c
+}
+
 // NewCalculatorWithDependencies creates a new calculator with injected dependencies
 // This is the preferred constructor for testing and when you have custom dependencies
 func NewCalculatorWithDependencies(engine *Engine, discovery DiscoveryInterface, projectDir string) *Calculator {
@@ -295,7 +312,11 @@ func (c *Calculator) calculateAssetTokens(ctx context.Context, files []string, a
 // CalculateBundleTokens calculates tokens for an actual bundle file
 func (c *Calculator) CalculateBundleTokens(ctx context.Context, agents []string) (*BundleTokenInfo, error) {
 	bundleFilename := bundle.GenerateBundleFilename("", agents)
-	bundlePath := filepath.Join(c.projectDir, assets.KrciAIDir, assets.BundleDir, bundleFilename)
+	bundleDir := c.bundleDir
+	if bundleDir == "" {
+		bundleDir = filepath.Join(c.projectDir, assets.KrciAIDir, assets.BundleDir)
+	}
+	bundlePath := filepath.Join(bundleDir, bundleFilename)
 
 	// Read bundle file content
 	bundleContent, err := os.ReadFile(bundlePath)
@@ -0,0 +1,72 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package tokens
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tiktoken-go/tokenizer"
+)
+
+// DefaultModel is the model used for token counting when none is configured
+const DefaultModel = "gpt-4"
+
+// ModelCalculator implements TokenCalculator for any model supported by tiktoken
+type ModelCalculator struct {
+	encoding tokenizer.Codec
+}
+
+// NewModelCalculator creates a token calculator for the given model name (e.g. gpt-4o)
+func NewModelCalculator(model string) (*ModelCalculator, error) {
+	encoding, err := tokenizer.ForModel(tokenizer.Model(model))
+	if err != nil {
+		return nil, fmt.Errorf("unsupported token model %q: %w", model, err)
+	}
+
+	return &ModelCalculator{
+		encoding: encoding,
+	}, nil
+}
+
+// CalculateTokens calculates the number of tokens for the given text using the model encoding
+func (m *ModelCalculator) CalculateTokens(ctx context.Context, text string) (int, error) {
+	if text == "" {
+		return 0, nil
+	}
+
+	tokensCount, err := m.encoding.Count(text)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count tokens: %w", err)
+	}
+
+	return tokensCount, nil
+}
+
+// NewEngineForModel creates a token calculation engine for the given model.
+// An empty model or the default model uses the GPT-4 calculator.
+func NewEngineForModel(model string) (*Engine, error) {
+	if model == "" || model == DefaultModel {
+		return NewDefaultEngine()
+	}
+
+	calculator, err := NewModelCalculator(model)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewEngine(calculator), nil
+}
@@ -0,0 +1,400 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package transaction
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// StagingPrefix starts the name of staging directories created in the root directory
+const StagingPrefix = ".krci-ai-staging-"
+
+const (
+	stagedDir   = "new"
+	removedDir  = "removed"
+	originalDir = "old"
+)
+
+// rename moves files into place and back, replaced in tests to inject failures
+var rename = os.Rename
+
+// ErrRollbackFailed is returned by Commit when restoring the project after a failure failed too.
+// The staging directory with the replaced files is then kept so they can be recovered.
+var ErrRollbackFailed = errors.New("rollback failed")
+
+// Transaction stages files for a directory tree and moves them into place on Commit.
+// The staging directory is created inside the root so that files are moved with renames
+// on the same filesystem. Each file is replaced atomically; when moving any file fails,
+// the files already moved are restored and files created by the transaction are removed.
+type Transaction struct {
+	root string
+	dir  string
+}
+
+// move is a file moved into place during Commit
+type move struct {
+	target string
+	// original is where the replaced file was moved, empty when the target did not exist
+	original string
+	// createdDirs are the directories created for the target, deepest first
+	createdDirs []string
+	// installed reports whether the staged file was moved to the target
+	installed bool
+}
+
+// Begin starts a transaction for files inside the root directory
+func Begin(root string) (*Transaction, error) {
+	dir, err := os.MkdirTemp(root, StagingPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create staging directory: %w", err)
+	}
+
+	return &Transaction{root: root, dir: dir}, nil
+}
+
+// Path returns where the file for the target path is staged
+func (t *Transaction) Path(target string) (string, error) {
+	rel, err := t.rel(target)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(t.dir, stagedDir, rel), nil
+}
+
+// WriteFile stages the content of the target path
+func (t *Transaction) WriteFile(target string, data []byte, perm os.FileMode) error {
+	path, err := t.Path(target)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create staging directory for %s: %w", target, err)
+	}
+
+	if err := os.WriteFile(path, data, perm); err != nil {
+		return fmt.Errorf("failed to stage %s: %w", target, err)
+	}
+
+	return nil
+}
+
+// Remove stages the removal of the target path, discarding content staged for it.
+// Content staged for the target afterwards is installed as usual.
+func (t *Transaction) Remove(target string) error {
+	rel, err := t.rel(target)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(filepath.Join(t.dir, stagedDir, rel)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to discard staged %s: %w", target, err)
+	}
+
+	marker := filepath.Join(t.dir, removedDir, rel)
+	if err := os.MkdirAll(filepath.Dir(marker), 0755); err != nil {
+		return fmt.Errorf("failed to create staging directory for %s: %w", target, err)
+	}
+	if err := os.WriteFile(marker, nil, 0644); err != nil {
+		return fmt.Errorf("failed to stage removal of %s: %w", target, err)
+	}
+
+	return nil
+}
+
+// removed reports whether the removal of the target path is staged and no content was staged since
+func (t *Transaction) removed(target string) bool {
+	rel, err := t.rel(target)
+	if err != nil {
+		return false
+	}
+
+	if _, err := os.Stat(filepath.Join(t.dir, stagedDir, rel)); err == nil {
+		return false
+	}
+	_, err = os.Stat(filepath.Join(t.dir, removedDir, rel))
+	return err == nil
+}
+
+// Exists reports whether the target path exists once the transaction is committed
+func (t *Transaction) Exists(target string) bool {
+	if path, err := t.Path(target); err == nil {
+		if _, err := os.Stat(path); err == nil {
+			return true
+		}
+	}
+	if t.removed(target) {
+		return false
+	}
+
+	_, err := os.Stat(target)
+	return err == nil
+}
+
+// ReadFile reads the target path as it will be once the transaction is committed
+func (t *Transaction) ReadFile(target string) ([]byte, error) {
+	if path, err := t.Path(target); err == nil {
+		data, err := os.ReadFile(path)
+		if err == nil || !os.IsNotExist(err) {
+			return data, err
+		}
+	}
+	if t.removed(target) {
+		return nil, &fs.PathError{Op: "open", Path: target, Err: fs.ErrNotExist}
+	}
+
+	return os.ReadFile(target)
+}
+
+// Glob returns the paths matching the pattern once the transaction is committed, sorted
+func (t *Transaction) Glob(pattern string) ([]string, error) {
+	found, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+	matches := slices.DeleteFunc(found, t.removed)
+
+	stagedPattern, err := t.Path(pattern)
+	if err != nil {
+		return matches, nil
+	}
+	staged, err := filepath.Glob(stagedPattern)
+	if err != nil {
+		return nil, err
+	}
+
+	base := filepath.Join(t.dir, stagedDir)
+	for _, path := range staged {
+		rel, err := filepath.Rel(base, path)
+		if err != nil {
+			return nil, err
+		}
+		if target := filepath.Join(t.root, rel); !slices.Contains(matches, target) {
+			matches = append(matches, target)
+		}
+	}
+	slices.Sort(matches)
+
+	return matches, nil
+}
+
+// Commit moves the staged files into place, removes the files staged for removal and removes
+// the staging directory. When moving a file fails, all changes are rolled back. When the
+// rollback fails too, the staging directory holding the replaced files is kept.
+func (t *Transaction) Commit() (err error) {
+	defer func() {
+		if !errors.Is(err, ErrRollbackFailed) {
+			os.RemoveAll(t.dir)
+		}
+	}()
+
+	files, err := t.stagedFiles(stagedDir)
+	if err != nil {
+		return err
+	}
+	removals, err := t.stagedFiles(removedDir)
+	if err != nil {
+		return err
+	}
+
+	moves := make([]move, 0, len(files)+len(removals))
+	commit := func(m move, err error) error {
+		if err != nil {
+			if rollbackErr := rollback(append(moves, m)); rollbackErr != nil {
+				return fmt.Errorf("%w; %w: %v, the replaced files are kept in %s", err, ErrRollbackFailed, rollbackErr, filepath.Join(t.dir, originalDir))
+			}
+			return fmt.Errorf("%w, changes were rolled back", err)
+		}
+		moves = append(moves, m)
+		return nil
+	}
+
+	for _, rel := range files {
+		if err := commit(t.commitFile(rel)); err != nil {
+			return err
+		}
+	}
+	for _, rel := range removals {
+		// Content staged after the removal replaces the file instead
+		if _, staged := slices.BinarySearch(files, rel); staged {
+			continue
+		}
+		if err := commit(t.removeFile(rel)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Rollback discards the staged files
+func (t *Transaction) Rollback() error {
+	if err := os.RemoveAll(t.dir); err != nil {
+		return fmt.Errorf("failed to remove staging directory: %w", err)
+	}
+
+	return nil
+}
+
+// rel returns the path of a target relative to the root, refusing paths outside of it
+func (t *Transaction) rel(target string) (string, error) {
+	rel, err := filepath.Rel(t.root, target)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s is outside of %s", target, t.root)
+	}
+
+	return rel, nil
+}
+
+// stagedFiles returns the files staged in a directory of the staging directory relative to the root, sorted
+func (t *Transaction) stagedFiles(dir string) ([]string, error) {
+	base := filepath.Join(t.dir, dir)
+
+	var files []string
+	err := filepath.WalkDir(base, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if path == base && errors.Is(err, fs.ErrNotExist) {
+				return fs.SkipDir
+			}
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(base, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, rel)
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list staged files: %w", err)
+	}
+	slices.Sort(files)
+
+	return files, nil
+}
+
+// commitFile moves a staged file into place, moving the file it replaces aside.
+// The returned move records what was changed, also when moving failed half-way.
+func (t *Transaction) commitFile(rel string) (move, error) {
+	target := filepath.Join(t.root, rel)
+	m := move{target: target}
+
+	var err error
+	if m.createdDirs, err = mkdirAll(filepath.Dir(target)); err != nil {
+		return m, fmt.Errorf("failed to create directory for %s: %w", target, err)
+	}
+
+	if _, err := os.Lstat(target); err == nil {
+		original := filepath.Join(t.dir, originalDir, rel)
+		if err := os.MkdirAll(filepath.Dir(original), 0755); err != nil {
+			return m, fmt.Errorf("failed to prepare replacing %s: %w", target, err)
+		}
+		if err := rename(target, original); err != nil {
+			return m, fmt.Errorf("failed to move %s aside: %w", target, err)
+		}
+		m.original = original
+	}
+
+	if err := rename(filepath.Join(t.dir, stagedDir, rel), target); err != nil {
+		return m, fmt.Errorf("failed to install %s: %w", target, err)
+	}
+	m.installed = true
+
+	return m, nil
+}
+
+// removeFile moves a file staged for removal aside, so that a rollback can restore it
+func (t *Transaction) removeFile(rel string) (move, error) {
+	target := filepath.Join(t.root, rel)
+	m := move{target: target}
+
+	if _, err := os.Lstat(target); err != nil {
+		// Nothing to remove
+		return m, nil
+	}
+
+	original := filepath.Join(t.dir, originalDir, rel)
+	if err := os.MkdirAll(filepath.Dir(original), 0755); err != nil {
+		return m, fmt.Errorf("failed to prepare removing %s: %w", target, err)
+	}
+	if err := rename(target, original); err != nil {
+		return m, fmt.Errorf("failed to remove %s: %w", target, err)
+	}
+	m.original = original
+
+	return m, nil
+}
+
+// rollback undoes moves in reverse order
+func rollback(moves []move) error {
+	var errs []error
+	for idx := len(moves) - 1; idx >= 0; idx-- {
+		m := moves[idx]
+
+		if m.installed {
+			if err := os.Remove(m.target); err != nil && !os.IsNotExist(err) {
+				errs = append(errs, fmt.Errorf("failed to remove %s: %w", m.target, err))
+				continue
+			}
+		}
+		if m.original != "" {
+			if err := rename(m.original, m.target); err != nil {
+				errs = append(errs, fmt.Errorf("failed to restore %s: %w", m.target, err))
+				continue
+			}
+		}
+		for _, dir := range m.createdDirs {
+			// Directories still holding files of earlier moves are removed with those
+			if err := os.Remove(dir); err != nil {
+				break
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// mkdirAll creates a directory and its missing parents and returns the created directories, deepest first
+func mkdirAll(dir string) ([]string, error) {
+	var missing []string
+	for current := dir; ; current = filepath.Dir(current) {
+		if _, err := os.Stat(current); err == nil {
+			break
+		}
+		missing = append(missing, current)
+		if filepath.Dir(current) == current {
+			break
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	return missing, nil
+}
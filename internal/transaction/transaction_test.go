@@ -0,0 +1,257 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package transaction
+
+import (
+	"errors"
+	"io/fs"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// snapshot returns the files and directories of a tree with the content of files
+func snapshot(t *testing.T, root string) map[string]string {
+	t.Helper()
+
+	tree := make(map[string]string)
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			tree[rel+"/"] = ""
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		tree[rel] = string(data)
+		return nil
+	})
+	require.NoError(t, err)
+
+	return tree
+}
+
+// prepare creates a project with existing files and a transaction staging changes to it
+func prepare(t *testing.T) (string, *Transaction) {
+	t.Helper()
+
+	root := t.TempDir()
+	for path, content := range map[string]string{
+		".krci-ai/agents/pm.yaml":            "old pm\n",
+		".krci-ai/tasks/pm/create-prd.md":    "old task\n",
+		".claude/commands/krci-ai/pm.md":     "old command\n",
+		".claude/commands/krci-ai/qa.md":     "removed command\n",
+		"README.md":                          "project\n",
+		".krci-ai/templates/prd-template.md": "template\n",
+	} {
+		target := filepath.Join(root, filepath.FromSlash(path))
+		require.NoError(t, os.MkdirAll(filepath.Dir(target), 0755))
+		require.NoError(t, os.WriteFile(target, []byte(content), 0644))
+	}
+
+	txn, err := Begin(root)
+	require.NoError(t, err)
+
+	for path, content := range map[string]string{
+		".krci-ai/agents/pm.yaml":         "new pm\n",
+		".krci-ai/agents/dev.yaml":        "new dev\n",
+		".krci-ai/tasks/pm/create-prd.md": "new task\n",
+		".krci-ai/tasks/dev/implement.md": "new dev task\n",
+		".claude/commands/krci-ai/pm.md":  "new command\n",
+		".claude/commands/krci-ai/dev.md": "new dev command\n",
+		".cursor/rules/krci-ai/dev.mdc":   "new rule\n",
+	} {
+		require.NoError(t, txn.WriteFile(filepath.Join(root, filepath.FromSlash(path)), []byte(content), 0644))
+	}
+	require.NoError(t, txn.Remove(filepath.Join(root, ".claude", "commands", "krci-ai", "qa.md")))
+
+	return root, txn
+}
+
+func TestCommit(t *testing.T) {
+	root, txn := prepare(t)
+
+	require.NoError(t, txn.Commit())
+
+	tree := snapshot(t, root)
+	assert.Equal(t, "new pm\n", tree[filepath.Join(".krci-ai", "agents", "pm.yaml")])
+	assert.Equal(t, "new dev task\n", tree[filepath.Join(".krci-ai", "tasks", "dev", "implement.md")])
+	assert.Equal(t, "new rule\n", tree[filepath.Join(".cursor", "rules", "krci-ai", "dev.mdc")])
+	assert.Equal(t, "template\n", tree[filepath.Join(".krci-ai", "templates", "prd-template.md")])
+	assert.Equal(t, "project\n", tree["README.md"])
+	assert.NotContains(t, tree, filepath.Join(".claude", "commands", "krci-ai", "qa.md"))
+
+	matches, err := filepath.Glob(filepath.Join(root, StagingPrefix+"*"))
+	require.NoError(t, err)
+	assert.Empty(t, matches, "staging directory is removed")
+}
+
+func TestRollback(t *testing.T) {
+	root, txn := prepare(t)
+	before := snapshotWithoutStaging(t, root)
+
+	// Staged files are visible through the transaction only
+	data, err := txn.ReadFile(filepath.Join(root, ".krci-ai", "agents", "pm.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "new pm\n", string(data))
+	data, err = txn.ReadFile(filepath.Join(root, "README.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "project\n", string(data))
+	assert.True(t, txn.Exists(filepath.Join(root, ".krci-ai", "agents", "dev.yaml")))
+	assert.NoFileExists(t, filepath.Join(root, ".krci-ai", "agents", "dev.yaml"))
+
+	agents, err := txn.Glob(filepath.Join(root, ".krci-ai", "agents", "*.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(root, ".krci-ai", "agents", "dev.yaml"),
+		filepath.Join(root, ".krci-ai", "agents", "pm.yaml"),
+	}, agents)
+
+	// Removed files are gone for the transaction only
+	removed := filepath.Join(root, ".claude", "commands", "krci-ai", "qa.md")
+	assert.False(t, txn.Exists(removed))
+	_, err = txn.ReadFile(removed)
+	assert.True(t, os.IsNotExist(err))
+	assert.FileExists(t, removed)
+
+	commands, err := txn.Glob(filepath.Join(root, ".claude", "commands", "krci-ai", "*.md"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(root, ".claude", "commands", "krci-ai", "dev.md"),
+		filepath.Join(root, ".claude", "commands", "krci-ai", "pm.md"),
+	}, commands)
+
+	require.NoError(t, txn.Rollback())
+
+	assert.Equal(t, before, snapshot(t, root))
+}
+
+func TestRemoveThenWrite(t *testing.T) {
+	root, txn := prepare(t)
+	target := filepath.Join(root, ".claude", "commands", "krci-ai", "pm.md")
+
+	// Removal discards the staged content, content staged afterwards wins
+	require.NoError(t, txn.Remove(target))
+	assert.False(t, txn.Exists(target))
+	require.NoError(t, txn.WriteFile(target, []byte("rewritten command\n"), 0644))
+	assert.True(t, txn.Exists(target))
+
+	require.NoError(t, txn.Commit())
+
+	data, err := os.ReadFile(target)
+	require.NoError(t, err)
+	assert.Equal(t, "rewritten command\n", string(data))
+}
+
+func TestPathOutsideRoot(t *testing.T) {
+	root := t.TempDir()
+	txn, err := Begin(root)
+	require.NoError(t, err)
+	defer func() { _ = txn.Rollback() }()
+
+	assert.Error(t, txn.WriteFile(filepath.Join(root, "..", "escape.md"), []byte("x"), 0644))
+	assert.Error(t, txn.WriteFile(root, []byte("x"), 0644))
+}
+
+// TestCommitFailure injects a failure at every rename of the commit, in random order of
+// runs, and checks the project is left exactly as it was before the commit
+func TestCommitFailure(t *testing.T) {
+	// Count the renames of a successful commit
+	var total int
+	rename = func(from, to string) error {
+		total++
+		return os.Rename(from, to)
+	}
+	t.Cleanup(func() { rename = os.Rename })
+
+	_, txn := prepare(t)
+	require.NoError(t, txn.Commit())
+	require.Positive(t, total)
+
+	random := rand.New(rand.NewSource(42))
+	for _, failAt := range random.Perm(total) {
+		root, txn := prepare(t)
+		before := snapshotWithoutStaging(t, root)
+
+		var calls int
+		rename = func(from, to string) error {
+			if calls++; calls == failAt+1 {
+				return errors.New("injected failure")
+			}
+			return os.Rename(from, to)
+		}
+
+		err := txn.Commit()
+		require.Error(t, err, "failure at rename %d", failAt+1)
+		assert.Contains(t, err.Error(), "injected failure")
+		assert.Contains(t, err.Error(), "rolled back")
+		assert.NotErrorIs(t, err, ErrRollbackFailed)
+		assert.Equal(t, before, snapshot(t, root), "failure at rename %d", failAt+1)
+		assert.NoDirExists(t, txn.dir, "failure at rename %d", failAt+1)
+	}
+}
+
+// TestRollbackFailure fails the commit and its rollback and checks the replaced files are kept
+func TestRollbackFailure(t *testing.T) {
+	root, txn := prepare(t)
+
+	// Fail everything after the first files were replaced
+	var calls int
+	rename = func(from, to string) error {
+		if calls++; calls > 3 {
+			return errors.New("injected failure")
+		}
+		return os.Rename(from, to)
+	}
+	t.Cleanup(func() { rename = os.Rename })
+
+	err := txn.Commit()
+	require.ErrorIs(t, err, ErrRollbackFailed)
+	assert.Contains(t, err.Error(), filepath.Join(txn.dir, originalDir))
+
+	// The old command was moved aside and could not be restored, the staging directory has the only copy
+	data, err := os.ReadFile(filepath.Join(txn.dir, originalDir, ".claude", "commands", "krci-ai", "pm.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "old command\n", string(data))
+	assert.NoFileExists(t, filepath.Join(root, ".claude", "commands", "krci-ai", "pm.md"))
+}
+
+// snapshotWithoutStaging returns the snapshot of a tree ignoring staging directories
+func snapshotWithoutStaging(t *testing.T, root string) map[string]string {
+	t.Helper()
+
+	tree := snapshot(t, root)
+	for path := range tree {
+		if strings.HasPrefix(path, StagingPrefix) {
+			delete(tree, path)
+		}
+	}
+
+	return tree
+}
@@ -0,0 +1,293 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package uninstall
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/KubeRocketCI/kuberocketai/internal/backup"
+	"github.com/KubeRocketCI/kuberocketai/internal/lockfile"
+	"github.com/KubeRocketCI/kuberocketai/internal/roomodes"
+	"github.com/KubeRocketCI/kuberocketai/internal/section"
+)
+
+// Action is what the uninstall does with an installed file
+type Action string
+
+const (
+	// ActionRemoved is a removed file
+	ActionRemoved Action = "removed"
+	// ActionKept is a file with local changes kept because of --keep-local
+	ActionKept Action = "kept"
+	// ActionShared is a file still needed by other installed agents
+	ActionShared Action = "shared"
+	// ActionMissing is a recorded file that no longer exists
+	ActionMissing Action = "missing"
+)
+
+// Change describes what happens to a single file
+type Change struct {
+	// Path is slash separated and relative to the project root
+	Path   string `json:"path"`
+	Action Action `json:"action"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// Result lists the changes of an uninstall
+type Result struct {
+	Changes []Change `json:"changes"`
+	// Untracked counts files recorded without agents, which cannot be removed per agent
+	Untracked int `json:"untracked,omitempty"`
+	// BackupID is the backup of removed files with local changes
+	BackupID string `json:"backup_id,omitempty"`
+	// Complete is set when everything was uninstalled and the lockfile removed
+	Complete bool `json:"complete"`
+}
+
+// Count returns the number of files with the given action
+func (r *Result) Count(action Action) int {
+	count := 0
+	for _, change := range r.Changes {
+		if change.Action == action {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Options selects what to uninstall
+type Options struct {
+	ProjectDir   string
+	FrameworkDir string
+	// Lock records the installed files and is updated with the remaining ones
+	Lock *lockfile.Lockfile
+	// Agents limits the uninstall to the files of these agents
+	Agents []string
+	// IDEs limits the uninstall to the files generated for these IDE integrations
+	IDEs []string
+	// KeepLocal keeps files with local changes instead of backing them up and removing them
+	KeepLocal bool
+	// DryRun computes the changes without removing any file
+	DryRun bool
+}
+
+// Apply removes the installed files selected by the options. Only files recorded in the
+// lockfile are touched, so user authored files next to generated ones are left alone.
+func Apply(opts Options) (*Result, error) {
+	result := &Result{}
+	session := backup.NewSession(opts.ProjectDir, opts.FrameworkDir, "uninstall")
+
+	var removed []string
+	for _, file := range slices.Clone(opts.Lock.Files) {
+		selected, remaining, tracked := selectFile(opts, file)
+		if !tracked && (len(opts.Agents) > 0 || len(opts.IDEs) > 0) {
+			result.Untracked++
+		}
+		if !selected {
+			continue
+		}
+
+		if len(remaining) > 0 {
+			result.Changes = append(result.Changes, Change{Path: file.Path, Action: ActionShared, Reason: fmt.Sprintf("used by %v", remaining)})
+			opts.Lock.SetFileAgents(file.Path, remaining)
+			continue
+		}
+
+		change, err := remove(opts, file, session)
+		if err != nil {
+			return nil, err
+		}
+		result.Changes = append(result.Changes, change)
+		opts.Lock.RemoveFile(file.Path)
+		if change.Action == ActionRemoved {
+			removed = append(removed, file.Path)
+		}
+	}
+	result.BackupID = session.ID()
+
+	opts.Lock.RemoveAgents(opts.Agents...)
+	if len(opts.Agents) == 0 {
+		opts.Lock.RemoveIDEs(opts.IDEs...)
+	}
+
+	if opts.DryRun {
+		result.Complete = len(opts.Lock.Files) == 0
+		return result, nil
+	}
+
+	for _, path := range removed {
+		removeEmptyParents(opts.ProjectDir, filepath.Join(opts.ProjectDir, filepath.FromSlash(path)))
+	}
+
+	if len(opts.Lock.Files) > 0 {
+		if err := opts.Lock.Save(opts.FrameworkDir); err != nil {
+			return nil, err
+		}
+		return result, lockfile.PruneObjects(opts.FrameworkDir, opts.Lock)
+	}
+
+	// Nothing is installed anymore: drop the installation record and merge bases
+	if err := os.Remove(lockfile.GetPath(opts.FrameworkDir)); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to remove lockfile: %w", err)
+	}
+	if err := os.RemoveAll(filepath.Join(opts.FrameworkDir, lockfile.ObjectsDir)); err != nil {
+		return nil, fmt.Errorf("failed to remove installed file objects: %w", err)
+	}
+	removeEmptyParents(opts.ProjectDir, filepath.Join(opts.FrameworkDir, lockfile.FileName))
+	result.Complete = true
+
+	return result, nil
+}
+
+// selectFile reports whether a file is selected for removal, the agents that still need it,
+// and whether the file records its agents
+func selectFile(opts Options, file lockfile.File) (bool, []string, bool) {
+	tracked := len(file.Agents) > 0
+
+	if len(opts.IDEs) > 0 && !slices.Contains(opts.IDEs, file.IDE) {
+		return false, nil, tracked
+	}
+	if len(opts.Agents) == 0 {
+		return true, nil, tracked
+	}
+	if !tracked {
+		return false, nil, false
+	}
+
+	var remaining []string
+	matched := false
+	for _, agent := range file.Agents {
+		if slices.Contains(opts.Agents, agent) {
+			matched = true
+		} else {
+			remaining = append(remaining, agent)
+		}
+	}
+
+	return matched, remaining, true
+}
+
+// remove deletes a single installed file, backing up or keeping local changes
+func remove(opts Options, file lockfile.File, session *backup.Session) (Change, error) {
+	change := Change{Path: file.Path}
+	target := filepath.Join(opts.ProjectDir, filepath.FromSlash(file.Path))
+
+	status, err := lockfile.CheckEntry(opts.ProjectDir, file)
+	if err != nil {
+		return Change{}, err
+	}
+
+	switch {
+	case status == lockfile.StatusMissing:
+		change.Action = ActionMissing
+		return change, nil
+	case status == lockfile.StatusModified && opts.KeepLocal:
+		change.Action = ActionKept
+		change.Reason = "local changes"
+		return change, nil
+	}
+
+	change.Action = ActionRemoved
+	if opts.DryRun {
+		if status == lockfile.StatusModified {
+			change.Reason = "local changes would be backed up"
+		}
+		return change, nil
+	}
+
+	if status == lockfile.StatusModified {
+		if _, err := session.Protect(target, nil); err != nil {
+			return Change{}, err
+		}
+		change.Reason = "local changes backed up"
+	}
+
+	if file.Section {
+		return change, removeSection(target, file.Path)
+	}
+	if file.Modes {
+		return change, removeModes(target, file.Path)
+	}
+
+	if err := os.Remove(target); err != nil {
+		return Change{}, fmt.Errorf("failed to remove %s: %w", file.Path, err)
+	}
+
+	return change, nil
+}
+
+// removeSection removes the managed section from a file shared with the user,
+// and the file itself when nothing else is left
+func removeSection(target, path string) error {
+	data, err := os.ReadFile(target)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	rest, _ := section.Remove(data)
+	if len(bytes.TrimSpace(rest)) == 0 {
+		if err := os.Remove(target); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", path, err)
+		}
+		return nil
+	}
+
+	if err := os.WriteFile(target, rest, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// removeModes removes the krci-ai modes from the Roo Code modes file shared with the user,
+// and the file itself when nothing else is left
+func removeModes(target, path string) error {
+	data, err := os.ReadFile(target)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	rest, _, err := roomodes.Remove(data)
+	if err != nil {
+		return err
+	}
+	if len(rest) == 0 {
+		if err := os.Remove(target); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", path, err)
+		}
+		return nil
+	}
+
+	if err := os.WriteFile(target, rest, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// removeEmptyParents removes the empty directories above path up to the project root
+func removeEmptyParents(projectDir, path string) {
+	for dir := filepath.Dir(path); dir != projectDir && len(dir) > len(projectDir); dir = filepath.Dir(dir) {
+		if err := os.Remove(dir); err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,212 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package uninstall
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KubeRocketCI/kuberocketai/internal/lockfile"
+	"github.com/KubeRocketCI/kuberocketai/internal/roomodes"
+	"github.com/KubeRocketCI/kuberocketai/internal/section"
+)
+
+// setup installs a small framework with two agents sharing a task and Claude and VS Code files
+func setup(t *testing.T) (string, string, *lockfile.Lockfile) {
+	t.Helper()
+
+	projectDir := t.TempDir()
+	frameworkDir := filepath.Join(projectDir, ".krci-ai")
+	lock := lockfile.New()
+	lock.AddAgents("dev", "pm")
+	lock.AddIDEs("claude", "vscode")
+
+	install := func(path, ide string, agents ...string) {
+		target := filepath.Join(projectDir, filepath.FromSlash(path))
+		require.NoError(t, os.MkdirAll(filepath.Dir(target), 0755))
+		require.NoError(t, os.WriteFile(target, []byte(path), 0644))
+		hash, err := lockfile.StoreObject(frameworkDir, []byte(path))
+		require.NoError(t, err)
+		lock.SetFile(path, hash)
+		lock.AddFileOwners(path, ide, agents...)
+	}
+
+	install(".krci-ai/agents/pm.yaml", "", "pm")
+	install(".krci-ai/agents/dev.yaml", "", "dev")
+	install(".krci-ai/tasks/shared.md", "", "dev", "pm")
+	install(".claude/commands/krci-ai/pm.md", "claude", "pm")
+	install(".claude/commands/krci-ai/dev.md", "claude", "dev")
+	install(".github/chatmodes/pm.chatmode.md", "vscode", "pm")
+	require.NoError(t, lock.Save(frameworkDir))
+
+	// User authored files next to generated ones
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, ".github", "chatmodes", "mine.chatmode.md"), []byte("mine"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, ".krci-ai", "values.yaml"), []byte("project: x\n"), 0644))
+
+	return projectDir, frameworkDir, lock
+}
+
+func actions(result *Result) map[string]Action {
+	actions := make(map[string]Action)
+	for _, change := range result.Changes {
+		actions[change.Path] = change.Action
+	}
+	return actions
+}
+
+func TestApply(t *testing.T) {
+	t.Run("everything", func(t *testing.T) {
+		projectDir, frameworkDir, lock := setup(t)
+		require.NoError(t, os.WriteFile(filepath.Join(frameworkDir, "agents", "pm.yaml"), []byte("edited"), 0644))
+
+		result, err := Apply(Options{ProjectDir: projectDir, FrameworkDir: frameworkDir, Lock: lock})
+		require.NoError(t, err)
+		assert.True(t, result.Complete)
+		assert.Equal(t, 6, result.Count(ActionRemoved))
+		require.NotEmpty(t, result.BackupID)
+
+		assert.NoFileExists(t, lockfile.GetPath(frameworkDir))
+		assert.NoDirExists(t, filepath.Join(frameworkDir, lockfile.ObjectsDir))
+		assert.NoDirExists(t, filepath.Join(projectDir, ".claude"))
+		assert.FileExists(t, filepath.Join(projectDir, ".github", "chatmodes", "mine.chatmode.md"))
+		assert.FileExists(t, filepath.Join(frameworkDir, "values.yaml"))
+		assert.FileExists(t, filepath.Join(frameworkDir, ".backup", result.BackupID, ".krci-ai", "agents", "pm.yaml"))
+	})
+
+	t.Run("keep local changes", func(t *testing.T) {
+		projectDir, frameworkDir, lock := setup(t)
+		require.NoError(t, os.WriteFile(filepath.Join(frameworkDir, "agents", "pm.yaml"), []byte("edited"), 0644))
+
+		result, err := Apply(Options{ProjectDir: projectDir, FrameworkDir: frameworkDir, Lock: lock, KeepLocal: true})
+		require.NoError(t, err)
+		assert.Equal(t, ActionKept, actions(result)[".krci-ai/agents/pm.yaml"])
+		assert.Empty(t, result.BackupID)
+		assert.FileExists(t, filepath.Join(frameworkDir, "agents", "pm.yaml"))
+	})
+
+	t.Run("agent", func(t *testing.T) {
+		projectDir, frameworkDir, lock := setup(t)
+
+		result, err := Apply(Options{ProjectDir: projectDir, FrameworkDir: frameworkDir, Lock: lock, Agents: []string{"pm"}})
+		require.NoError(t, err)
+		assert.False(t, result.Complete)
+		assert.Equal(t, map[string]Action{
+			".krci-ai/agents/pm.yaml":          ActionRemoved,
+			".krci-ai/tasks/shared.md":         ActionShared,
+			".claude/commands/krci-ai/pm.md":   ActionRemoved,
+			".github/chatmodes/pm.chatmode.md": ActionRemoved,
+		}, actions(result))
+		assert.FileExists(t, filepath.Join(frameworkDir, "tasks", "shared.md"))
+
+		saved, err := lockfile.Load(frameworkDir)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"dev"}, saved.Agents)
+		file, ok := saved.GetFile(".krci-ai/tasks/shared.md")
+		require.True(t, ok)
+		assert.Equal(t, []string{"dev"}, file.Agents)
+		_, ok = saved.GetFile(".krci-ai/agents/pm.yaml")
+		assert.False(t, ok)
+
+		// Only the originals of the remaining files are kept
+		_, err = lockfile.LoadObject(frameworkDir, lockfile.Hash([]byte(".krci-ai/agents/pm.yaml")))
+		assert.ErrorIs(t, err, lockfile.ErrObjectNotFound)
+		_, err = lockfile.LoadObject(frameworkDir, file.SHA256)
+		assert.NoError(t, err)
+	})
+
+	t.Run("ide", func(t *testing.T) {
+		projectDir, frameworkDir, lock := setup(t)
+
+		result, err := Apply(Options{ProjectDir: projectDir, FrameworkDir: frameworkDir, Lock: lock, IDEs: []string{"claude"}})
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Count(ActionRemoved))
+		assert.NoDirExists(t, filepath.Join(projectDir, ".claude"))
+		assert.FileExists(t, filepath.Join(frameworkDir, "agents", "pm.yaml"))
+
+		saved, err := lockfile.Load(frameworkDir)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"vscode"}, saved.IDEs)
+		assert.Equal(t, []string{"dev", "pm"}, saved.Agents)
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		projectDir, frameworkDir, lock := setup(t)
+
+		result, err := Apply(Options{ProjectDir: projectDir, FrameworkDir: frameworkDir, Lock: lock, DryRun: true})
+		require.NoError(t, err)
+		assert.Equal(t, 6, result.Count(ActionRemoved))
+		assert.FileExists(t, lockfile.GetPath(frameworkDir))
+		assert.FileExists(t, filepath.Join(projectDir, ".claude", "commands", "krci-ai", "pm.md"))
+	})
+
+	t.Run("files without agents", func(t *testing.T) {
+		projectDir, frameworkDir, lock := setup(t)
+		lock.SetFileAgents(".krci-ai/agents/pm.yaml", nil)
+
+		result, err := Apply(Options{ProjectDir: projectDir, FrameworkDir: frameworkDir, Lock: lock, Agents: []string{"pm"}})
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Untracked)
+		assert.FileExists(t, filepath.Join(frameworkDir, "agents", "pm.yaml"))
+	})
+
+	t.Run("managed section", func(t *testing.T) {
+		projectDir, frameworkDir, lock := setup(t)
+		block := section.Block([]byte("## Agents\n"))
+		shared := filepath.Join(projectDir, ".github", "copilot-instructions.md")
+		owned := filepath.Join(projectDir, "AGENTS.md")
+		require.NoError(t, os.WriteFile(shared, section.Upsert([]byte("# Rules\n"), []byte("## Agents\n")), 0644))
+		require.NoError(t, os.WriteFile(owned, block, 0644))
+		for _, path := range []string{".github/copilot-instructions.md", "AGENTS.md"} {
+			lock.SetFile(path, lockfile.Hash(block))
+			lock.MarkSection(path)
+			lock.AddFileOwners(path, "copilot", "dev", "pm")
+		}
+
+		result, err := Apply(Options{ProjectDir: projectDir, FrameworkDir: frameworkDir, Lock: lock, IDEs: []string{"copilot"}})
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Count(ActionRemoved))
+
+		data, err := os.ReadFile(shared)
+		require.NoError(t, err)
+		assert.Equal(t, "# Rules\n", string(data))
+		assert.NoFileExists(t, owned)
+	})
+
+	t.Run("roo modes", func(t *testing.T) {
+		projectDir, frameworkDir, lock := setup(t)
+		team := "customModes:\n  - slug: team-reviewer\n    name: Reviewer\n"
+		content, err := roomodes.Upsert([]byte(team), []roomodes.Mode{{Slug: roomodes.Prefix + "pm", Name: "PM"}})
+		require.NoError(t, err)
+		modes, ok := roomodes.Extract(content)
+		require.True(t, ok)
+		require.NoError(t, os.WriteFile(filepath.Join(projectDir, roomodes.FileName), content, 0644))
+		lock.SetFile(roomodes.FileName, lockfile.Hash(modes))
+		lock.MarkModes(roomodes.FileName)
+		lock.AddFileOwners(roomodes.FileName, "roo", "pm")
+
+		result, err := Apply(Options{ProjectDir: projectDir, FrameworkDir: frameworkDir, Lock: lock, Agents: []string{"pm"}})
+		require.NoError(t, err)
+		assert.Equal(t, ActionRemoved, actions(result)[roomodes.FileName])
+
+		data, err := os.ReadFile(filepath.Join(projectDir, roomodes.FileName))
+		require.NoError(t, err)
+		assert.Equal(t, team, string(data))
+	})
+}
@@ -0,0 +1,239 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package upgrade
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/KubeRocketCI/kuberocketai/internal/lockfile"
+	"github.com/KubeRocketCI/kuberocketai/internal/merge"
+)
+
+// Action is what the upgrade does with a framework file
+type Action string
+
+const (
+	// ActionAdded is a file that is new in the upgraded framework
+	ActionAdded Action = "added"
+	// ActionUpdated is a file without local changes replaced by the new version
+	ActionUpdated Action = "updated"
+	// ActionMerged is a file whose local and upstream changes were merged cleanly
+	ActionMerged Action = "merged"
+	// ActionConflict is a file written with conflict markers
+	ActionConflict Action = "conflict"
+	// ActionKept is a file with local changes that did not change upstream
+	ActionKept Action = "kept"
+	// ActionUnchanged is a file that already matches the new version
+	ActionUnchanged Action = "unchanged"
+	// ActionSkipped is a file deleted locally that is not restored
+	ActionSkipped Action = "skipped"
+)
+
+const (
+	filePermissions      = 0644
+	directoryPermissions = 0755
+)
+
+// File is the new version of a framework file
+type File struct {
+	// Path is slash separated and relative to the framework directory
+	Path    string
+	Content []byte
+}
+
+// Change describes what happens to a single file
+type Change struct {
+	// Path is slash separated and relative to the project root
+	Path      string `json:"path"`
+	Action    Action `json:"action"`
+	Conflicts int    `json:"conflicts,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// Result lists the changes of an upgrade
+type Result struct {
+	Changes []Change `json:"changes"`
+}
+
+// Count returns the number of files with the given action
+func (r *Result) Count(action Action) int {
+	count := 0
+	for _, change := range r.Changes {
+		if change.Action == action {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Conflicts returns the files written with conflict markers
+func (r *Result) Conflicts() []Change {
+	return slices.DeleteFunc(slices.Clone(r.Changes), func(change Change) bool {
+		return change.Action != ActionConflict
+	})
+}
+
+// Options configures an upgrade
+type Options struct {
+	ProjectDir   string
+	FrameworkDir string
+	// Lock records the originally installed hashes and is updated with the new ones
+	Lock *lockfile.Lockfile
+	// RemoteLabel names the new version in conflict markers
+	RemoteLabel string
+	// DryRun computes the changes without writing any file
+	DryRun bool
+	// Stage runs write with the path a file is written to, e.g. to stage it in an installation
+	// that is committed at once. Files are written in place when it is nil.
+	Stage func(target string, write func(path string) error) error
+}
+
+// stage runs write with the path the target is written to
+func (o Options) stage(target string, write func(path string) error) error {
+	if o.Stage == nil {
+		return write(target)
+	}
+
+	return o.Stage(target, write)
+}
+
+// Apply upgrades the installed framework files to the given new versions using a three-way merge
+// of the original installed content, the current local content and the new content.
+func Apply(opts Options, files []File) (*Result, error) {
+	files = slices.Clone(files)
+	slices.SortFunc(files, func(a, b File) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	result := &Result{}
+	for _, file := range files {
+		change, content, err := plan(opts, file)
+		if err != nil {
+			return nil, err
+		}
+		result.Changes = append(result.Changes, change)
+
+		if opts.DryRun || change.Action == ActionKept || change.Action == ActionSkipped {
+			continue
+		}
+
+		if err := apply(opts, file, change, content); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// plan decides the action for a file and returns the content to write
+func plan(opts Options, file File) (Change, []byte, error) {
+	target := filepath.Join(opts.FrameworkDir, filepath.FromSlash(file.Path))
+	rel, err := filepath.Rel(opts.ProjectDir, target)
+	if err != nil {
+		return Change{}, nil, fmt.Errorf("failed to resolve path of %s: %w", file.Path, err)
+	}
+	change := Change{Path: filepath.ToSlash(rel)}
+
+	current, err := os.ReadFile(target)
+	exists := err == nil
+	if err != nil && !os.IsNotExist(err) {
+		return Change{}, nil, fmt.Errorf("failed to read %s: %w", target, err)
+	}
+
+	entry, tracked := opts.Lock.GetFile(change.Path)
+	newHash := lockfile.Hash(file.Content)
+
+	switch {
+	case !exists && tracked:
+		change.Action = ActionSkipped
+		change.Reason = "deleted locally"
+		return change, nil, nil
+	case !exists:
+		change.Action = ActionAdded
+		return change, file.Content, nil
+	case bytes.Equal(current, file.Content):
+		change.Action = ActionUnchanged
+		return change, file.Content, nil
+	case tracked && lockfile.Hash(current) == entry.SHA256:
+		change.Action = ActionUpdated
+		return change, file.Content, nil
+	case tracked && newHash == entry.SHA256:
+		change.Action = ActionKept
+		change.Reason = "local changes, no upstream changes"
+		return change, nil, nil
+	}
+
+	var base []byte
+	if !tracked {
+		change.Reason = "not recorded in the lockfile"
+	} else {
+		base, err = lockfile.LoadObject(opts.FrameworkDir, entry.SHA256)
+		if errors.Is(err, lockfile.ErrObjectNotFound) {
+			change.Reason = "original version not available"
+		} else if err != nil {
+			return Change{}, nil, err
+		}
+	}
+
+	merged := merge.Three(base, current, file.Content, merge.Labels{Local: "local", Remote: opts.RemoteLabel})
+	change.Action = ActionMerged
+	if merged.Conflicts > 0 {
+		change.Action = ActionConflict
+		change.Conflicts = merged.Conflicts
+	}
+
+	return change, merged.Content, nil
+}
+
+// apply writes the file and records the new version as the merge base of the next upgrade
+func apply(opts Options, file File, change Change, content []byte) error {
+	target := filepath.Join(opts.ProjectDir, filepath.FromSlash(change.Path))
+
+	if change.Action != ActionUnchanged {
+		err := opts.stage(target, func(path string) error {
+			if err := os.MkdirAll(filepath.Dir(path), directoryPermissions); err != nil {
+				return fmt.Errorf("failed to create directory for %s: %w", target, err)
+			}
+			if err := os.WriteFile(path, content, filePermissions); err != nil {
+				return fmt.Errorf("failed to write %s: %w", target, err)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	var hash string
+	err := opts.stage(opts.FrameworkDir, func(frameworkDir string) error {
+		var err error
+		hash, err = lockfile.StoreObject(frameworkDir, file.Content)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	opts.Lock.SetFile(change.Path, hash)
+
+	return nil
+}
@@ -0,0 +1,182 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package upgrade
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KubeRocketCI/kuberocketai/internal/lockfile"
+)
+
+// install writes a file as the installer does: content, lockfile hash and merge base object
+func install(t *testing.T, projectDir string, lock *lockfile.Lockfile, path, content string) {
+	t.Helper()
+
+	frameworkDir := filepath.Join(projectDir, ".krci-ai")
+	target := filepath.Join(frameworkDir, filepath.FromSlash(path))
+	require.NoError(t, os.MkdirAll(filepath.Dir(target), 0755))
+	require.NoError(t, os.WriteFile(target, []byte(content), 0644))
+
+	hash, err := lockfile.StoreObject(frameworkDir, []byte(content))
+	require.NoError(t, err)
+	lock.SetFile(".krci-ai/"+path, hash)
+}
+
+func writeLocal(t *testing.T, projectDir, path, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, ".krci-ai", filepath.FromSlash(path)), []byte(content), 0644))
+}
+
+func readLocal(t *testing.T, projectDir, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(projectDir, ".krci-ai", filepath.FromSlash(path)))
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestApply(t *testing.T) {
+	projectDir := t.TempDir()
+	frameworkDir := filepath.Join(projectDir, ".krci-ai")
+	lock := lockfile.New()
+
+	install(t, projectDir, lock, "agents/same.yaml", "same\n")
+	install(t, projectDir, lock, "agents/pristine.yaml", "a\nb\nc\n")
+	install(t, projectDir, lock, "agents/edited.yaml", "a\nb\nc\n")
+	install(t, projectDir, lock, "agents/merge.yaml", "one\ntwo\nthree\nfour\nfive\n")
+	install(t, projectDir, lock, "agents/conflict.yaml", "one\ntwo\nthree\n")
+	install(t, projectDir, lock, "data/deleted.md", "data\n")
+	install(t, projectDir, lock, "data/no-base.md", "base\n")
+	require.NoError(t, os.RemoveAll(filepath.Join(frameworkDir, lockfile.ObjectsDir)))
+	_, err := lockfile.StoreObject(frameworkDir, []byte("one\ntwo\nthree\nfour\nfive\n"))
+	require.NoError(t, err)
+	_, err = lockfile.StoreObject(frameworkDir, []byte("one\ntwo\nthree\n"))
+	require.NoError(t, err)
+
+	writeLocal(t, projectDir, "agents/edited.yaml", "a\nB\nc\n")
+	writeLocal(t, projectDir, "agents/merge.yaml", "ONE\ntwo\nthree\nfour\nfive\n")
+	writeLocal(t, projectDir, "agents/conflict.yaml", "one\nlocal\nthree\n")
+	writeLocal(t, projectDir, "data/no-base.md", "local\n")
+	require.NoError(t, os.Remove(filepath.Join(frameworkDir, "data", "deleted.md")))
+
+	files := []File{
+		{Path: "agents/same.yaml", Content: []byte("same\n")},
+		{Path: "agents/pristine.yaml", Content: []byte("a\nb\nc\nd\n")},
+		{Path: "agents/edited.yaml", Content: []byte("a\nb\nc\n")},
+		{Path: "agents/merge.yaml", Content: []byte("one\ntwo\nthree\nfour\nFIVE\n")},
+		{Path: "agents/conflict.yaml", Content: []byte("one\nupstream\nthree\n")},
+		{Path: "data/deleted.md", Content: []byte("new data\n")},
+		{Path: "data/no-base.md", Content: []byte("upstream\n")},
+		{Path: "tasks/new.md", Content: []byte("new task\n")},
+	}
+
+	opts := Options{ProjectDir: projectDir, FrameworkDir: frameworkDir, Lock: lock, RemoteLabel: "krci-ai 2.0.0"}
+
+	expected := map[string]Action{
+		".krci-ai/agents/conflict.yaml": ActionConflict,
+		".krci-ai/agents/edited.yaml":   ActionKept,
+		".krci-ai/agents/merge.yaml":    ActionMerged,
+		".krci-ai/agents/pristine.yaml": ActionUpdated,
+		".krci-ai/agents/same.yaml":     ActionUnchanged,
+		".krci-ai/data/deleted.md":      ActionSkipped,
+		".krci-ai/data/no-base.md":      ActionConflict,
+		".krci-ai/tasks/new.md":         ActionAdded,
+	}
+
+	t.Run("dry run does not write", func(t *testing.T) {
+		dryRun := opts
+		dryRun.DryRun = true
+
+		result, err := Apply(dryRun, files)
+		require.NoError(t, err)
+
+		actions := make(map[string]Action)
+		for _, change := range result.Changes {
+			actions[change.Path] = change.Action
+		}
+		assert.Equal(t, expected, actions)
+		assert.Len(t, result.Conflicts(), 2)
+
+		assert.Equal(t, "a\nb\nc\n", readLocal(t, projectDir, "agents/pristine.yaml"))
+		assert.NoFileExists(t, filepath.Join(frameworkDir, "tasks", "new.md"))
+	})
+
+	t.Run("apply", func(t *testing.T) {
+		result, err := Apply(opts, files)
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Count(ActionConflict))
+
+		assert.Equal(t, "a\nb\nc\nd\n", readLocal(t, projectDir, "agents/pristine.yaml"))
+		assert.Equal(t, "a\nB\nc\n", readLocal(t, projectDir, "agents/edited.yaml"))
+		assert.Equal(t, "ONE\ntwo\nthree\nfour\nFIVE\n", readLocal(t, projectDir, "agents/merge.yaml"))
+		assert.Equal(t, "one\n<<<<<<< local\nlocal\n=======\nupstream\n>>>>>>> krci-ai 2.0.0\nthree\n", readLocal(t, projectDir, "agents/conflict.yaml"))
+		assert.Equal(t, "new task\n", readLocal(t, projectDir, "tasks/new.md"))
+		assert.NoFileExists(t, filepath.Join(frameworkDir, "data", "deleted.md"))
+
+		for _, change := range result.Changes {
+			if change.Path == ".krci-ai/data/no-base.md" {
+				assert.Equal(t, "original version not available", change.Reason)
+			}
+		}
+
+		// The new version becomes the merge base of the next upgrade
+		entry, ok := lock.GetFile(".krci-ai/agents/merge.yaml")
+		require.True(t, ok)
+		assert.Equal(t, lockfile.Hash([]byte("one\ntwo\nthree\nfour\nFIVE\n")), entry.SHA256)
+		base, err := lockfile.LoadObject(frameworkDir, entry.SHA256)
+		require.NoError(t, err)
+		assert.Equal(t, "one\ntwo\nthree\nfour\nFIVE\n", string(base))
+
+		// Kept files keep their original merge base
+		entry, ok = lock.GetFile(".krci-ai/agents/edited.yaml")
+		require.True(t, ok)
+		assert.Equal(t, lockfile.Hash([]byte("a\nb\nc\n")), entry.SHA256)
+	})
+}
+
+func TestApplyStage(t *testing.T) {
+	projectDir := t.TempDir()
+	stagingDir := t.TempDir()
+	lock := lockfile.New()
+	install(t, projectDir, lock, "agents/pristine.yaml", "old\n")
+
+	// Staged writes go to the staging directory and leave the project untouched
+	stage := func(target string, write func(path string) error) error {
+		rel, err := filepath.Rel(projectDir, target)
+		require.NoError(t, err)
+		return write(filepath.Join(stagingDir, rel))
+	}
+	opts := Options{ProjectDir: projectDir, FrameworkDir: filepath.Join(projectDir, ".krci-ai"), Lock: lock, RemoteLabel: "new", Stage: stage}
+	_, err := Apply(opts, []File{
+		{Path: "agents/pristine.yaml", Content: []byte("new\n")},
+		{Path: "agents/added.yaml", Content: []byte("added\n")},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "old\n", readLocal(t, projectDir, "agents/pristine.yaml"))
+	assert.NoFileExists(t, filepath.Join(projectDir, ".krci-ai", "agents", "added.yaml"))
+	assert.Equal(t, "added\n", readLocal(t, stagingDir, "agents/added.yaml"))
+	assert.Equal(t, "new\n", readLocal(t, stagingDir, "agents/pristine.yaml"))
+
+	_, err = lockfile.LoadObject(filepath.Join(stagingDir, ".krci-ai"), lockfile.Hash([]byte("new\n")))
+	require.NoError(t, err)
+	_, err = lockfile.LoadObject(filepath.Join(projectDir, ".krci-ai"), lockfile.Hash([]byte("new\n")))
+	assert.ErrorIs(t, err, lockfile.ErrObjectNotFound)
+}
@@ -25,6 +25,7 @@ import (
 	"strings"
 
 	"github.com/KubeRocketCI/kuberocketai/internal/assets"
+	"github.com/KubeRocketCI/kuberocketai/internal/values"
 )
 
 // AgentStats holds statistics for a single agent
@@ -63,6 +64,7 @@ type ValidationIssue struct {
 // FrameworkAnalyzer provides comprehensive framework validation
 type FrameworkAnalyzer struct {
 	discovery *assets.Discovery
+	values    values.Values
 }
 
 // NewFrameworkAnalyzer creates a new framework analyzer
@@ -72,6 +74,12 @@ func NewFrameworkAnalyzer(discovery *assets.Discovery) *FrameworkAnalyzer {
 	}
 }
 
+// WithValues sets project values used to detect undefined variables in framework files
+func (a *FrameworkAnalyzer) WithValues(vals values.Values) *FrameworkAnalyzer {
+	a.values = vals
+	return a
+}
+
 // AnalyzeFramework performs comprehensive framework analysis
 func (a *FrameworkAnalyzer) AnalyzeFramework() ([]ValidationIssue, *FrameworkInsights, error) {
 	agents, err := a.discovery.GetAgents(context.Background())
@@ -102,6 +110,9 @@ func (a *FrameworkAnalyzer) AnalyzeFramework() ([]ValidationIssue, *FrameworkIns
 	deduplicatedXMLIssues := a.deduplicateXMLValidationIssues(fileUsage)
 	issues = append(issues, deduplicatedXMLIssues...)
 
+	// Report variables that have no value in the project values file
+	issues = append(issues, a.validateVariables(agents, fileUsage)...)
+
 	insights := a.buildInsights(agents, agentStats, templateUsage, taskUsage, dataFileUsage, totalReferences)
 	return issues, insights, nil
 }
@@ -330,6 +341,36 @@ func (a *FrameworkAnalyzer) deduplicateXMLValidationIssues(fileUsage map[string]
 	return issues
 }
 
+// validateVariables reports {{ .variable }} placeholders without a value in the project values file
+func (a *FrameworkAnalyzer) validateVariables(agents []assets.Agent, fileUsage map[string]*FileReference) []ValidationIssue {
+	filePaths := make([]string, 0, len(agents)+len(fileUsage))
+	for _, agent := range agents {
+		filePaths = append(filePaths, agent.FilePath)
+	}
+	for filePath := range fileUsage {
+		filePaths = append(filePaths, filePath)
+	}
+	sort.Strings(filePaths)
+
+	var issues []ValidationIssue
+	for _, filePath := range filePaths {
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			// Missing files are reported by existence validation
+			continue
+		}
+
+		for _, name := range values.Undefined(string(content), a.values) {
+			issues = append(issues, ValidationIssue{
+				File:    filePath,
+				Message: fmt.Sprintf("Undefined variable '{{ .%s }}' - File: %s (define it in %s/%s)", name, filePath, assets.KrciAIDir, values.FileName),
+			})
+		}
+	}
+
+	return issues
+}
+
 // formatMultipleReferences creates a consolidated reference string for files used by multiple agents/tasks
 func (a *FrameworkAnalyzer) formatMultipleReferences(fileRef *FileReference) string {
 	agentTaskMap := make(map[string][]string)
@@ -0,0 +1,63 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package validation
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KubeRocketCI/kuberocketai/internal/assets"
+	"github.com/KubeRocketCI/kuberocketai/internal/values"
+)
+
+func TestValidateVariables(t *testing.T) {
+	tempDir := t.TempDir()
+
+	agentPath := filepath.Join(tempDir, "agent.yaml")
+	require.NoError(t, os.WriteFile(agentPath, []byte("name: {{ .project.name }}"), 0644))
+
+	taskPath := filepath.Join(tempDir, "task.md")
+	require.NoError(t, os.WriteFile(taskPath, []byte("Repo: {{ .project.repo }}\nFill {{project_name}}"), 0644))
+
+	agents := []assets.Agent{{ShortName: "dev", FilePath: agentPath}}
+	fileUsage := map[string]*FileReference{
+		taskPath: {FilePath: taskPath, FileType: "task"},
+	}
+
+	t.Run("no values reports every variable", func(t *testing.T) {
+		analyzer := NewFrameworkAnalyzer(&assets.Discovery{})
+
+		issues := analyzer.validateVariables(agents, fileUsage)
+		require.Len(t, issues, 2)
+		assert.Contains(t, issues[0].Message, "{{ .project.name }}")
+		assert.Contains(t, issues[1].Message, "{{ .project.repo }}")
+	})
+
+	t.Run("defined values are not reported", func(t *testing.T) {
+		vals, err := values.Parse([]byte("project:\n  name: Rocket\n"))
+		require.NoError(t, err)
+		analyzer := NewFrameworkAnalyzer(&assets.Discovery{}).WithValues(vals)
+
+		issues := analyzer.validateVariables(agents, fileUsage)
+		require.Len(t, issues, 1)
+		assert.Equal(t, taskPath, issues[0].File)
+		assert.Contains(t, issues[0].Message, "{{ .project.repo }}")
+	})
+}
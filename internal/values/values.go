@@ -0,0 +1,154 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package values
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/KubeRocketCI/kuberocketai/internal/utils"
+)
+
+// FileName is the name of the project values file inside the framework directory
+const FileName = "values.yaml"
+
+// variablePattern matches placeholders such as {{ .project.name }}.
+// Plain placeholders like {{project_name}} used by templates are intentionally not matched,
+// they are filled by the LLM at runtime and must stay untouched.
+var variablePattern = regexp.MustCompile(`\{\{\s*\.([A-Za-z_][A-Za-z0-9_-]*(?:\.[A-Za-z_][A-Za-z0-9_-]*)*)\s*\}\}`)
+
+// Values holds project specific variables loaded from the values file
+type Values map[string]any
+
+// GetPath returns the path to the values file for the given framework directory
+func GetPath(frameworkDir string) string {
+	return filepath.Join(frameworkDir, FileName)
+}
+
+// Load reads values from the framework directory.
+// A missing values file is not an error, empty values are returned instead.
+func Load(frameworkDir string) (Values, error) {
+	path := GetPath(frameworkDir)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Values{}, nil
+		}
+		return nil, fmt.Errorf("failed to read values file %s: %w", path, err)
+	}
+
+	return Parse(data)
+}
+
+// Parse parses values from YAML content
+func Parse(data []byte) (Values, error) {
+	vals := Values{}
+	if err := yaml.Unmarshal(data, &vals); err != nil {
+		return nil, fmt.Errorf("failed to parse values file: %w", err)
+	}
+
+	return vals, nil
+}
+
+// Lookup resolves a dotted variable path (e.g. "project.name") to its string value.
+// Only scalar values can be substituted, maps and lists are reported as not found.
+func (v Values) Lookup(path string) (string, bool) {
+	var current any = v
+	for key := range strings.SplitSeq(path, ".") {
+		node, ok := asMap(current)
+		if !ok {
+			return "", false
+		}
+
+		current, ok = node[key]
+		if !ok {
+			return "", false
+		}
+	}
+
+	if _, isMap := asMap(current); isMap {
+		return "", false
+	}
+
+	switch current.(type) {
+	case []any, nil:
+		return "", false
+	default:
+		return fmt.Sprint(current), true
+	}
+}
+
+// asMap converts a decoded YAML node to a map.
+// The YAML decoder reuses the Values type for nested maps, so both forms are accepted.
+func asMap(node any) (map[string]any, bool) {
+	switch m := node.(type) {
+	case Values:
+		return m, true
+	case map[string]any:
+		return m, true
+	default:
+		return nil, false
+	}
+}
+
+// Render substitutes all defined variables in content.
+// Undefined variables are left as is so that 'krci-ai validate' can report them.
+func Render(content string, vals Values) string {
+	if !strings.Contains(content, "{{") {
+		return content
+	}
+
+	return variablePattern.ReplaceAllStringFunc(content, func(match string) string {
+		name := variablePattern.FindStringSubmatch(match)[1]
+		if value, ok := vals.Lookup(name); ok {
+			return value
+		}
+		return match
+	})
+}
+
+// Variables returns the sorted list of unique variable names referenced in content
+func Variables(content string) []string {
+	matches := variablePattern.FindAllStringSubmatch(content, -1)
+	if len(matches) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(matches))
+	for _, match := range matches {
+		names = append(names, match[1])
+	}
+
+	return utils.DeduplicateStrings(names)
+}
+
+// Undefined returns the sorted list of variables referenced in content that have no value
+func Undefined(content string, vals Values) []string {
+	var undefined []string
+	for _, name := range Variables(content) {
+		if _, ok := vals.Lookup(name); !ok {
+			undefined = append(undefined, name)
+		}
+	}
+
+	return undefined
+}
@@ -0,0 +1,110 @@
+/*
+Copyright © 2025 KubeRocketAI Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package values
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testValues = `project:
+  name: Rocket
+  repo: https://github.com/example/rocket
+  port: 8080
+standards:
+  languages:
+    - go
+`
+
+func TestLoad(t *testing.T) {
+	t.Run("missing file returns empty values", func(t *testing.T) {
+		vals, err := Load(t.TempDir())
+		require.NoError(t, err)
+		assert.Empty(t, vals)
+	})
+
+	t.Run("reads values file", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, FileName), []byte(testValues), 0644))
+
+		vals, err := Load(dir)
+		require.NoError(t, err)
+
+		name, ok := vals.Lookup("project.name")
+		assert.True(t, ok)
+		assert.Equal(t, "Rocket", name)
+	})
+
+	t.Run("invalid yaml", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, FileName), []byte("project: [unclosed"), 0644))
+
+		_, err := Load(dir)
+		require.Error(t, err)
+	})
+}
+
+func TestLookup(t *testing.T) {
+	vals, err := Parse([]byte(testValues))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		path  string
+		want  string
+		found bool
+	}{
+		{name: "nested string", path: "project.name", want: "Rocket", found: true},
+		{name: "number", path: "project.port", want: "8080", found: true},
+		{name: "missing key", path: "project.owner", found: false},
+		{name: "map is not scalar", path: "project", found: false},
+		{name: "list is not scalar", path: "standards.languages", found: false},
+		{name: "path through scalar", path: "project.name.first", found: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := vals.Lookup(tt.path)
+			assert.Equal(t, tt.found, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	vals, err := Parse([]byte(testValues))
+	require.NoError(t, err)
+
+	content := "Product: {{ .project.name }} ({{.project.repo}})\nOwner: {{ .project.owner }}\nTemplate: {{project_name}}"
+	got := Render(content, vals)
+
+	assert.Equal(t, "Product: Rocket (https://github.com/example/rocket)\nOwner: {{ .project.owner }}\nTemplate: {{project_name}}", got)
+}
+
+func TestVariablesAndUndefined(t *testing.T) {
+	vals, err := Parse([]byte(testValues))
+	require.NoError(t, err)
+
+	content := "{{ .project.name }} {{ .project.owner }} {{ .project.name }} {{ .team.lead }} {{placeholder}}"
+
+	assert.Equal(t, []string{"project.name", "project.owner", "team.lead"}, Variables(content))
+	assert.Equal(t, []string{"project.owner", "team.lead"}, Undefined(content, vals))
+	assert.Empty(t, Undefined("no variables here", vals))
+}
@@ -56,6 +56,30 @@ func CompareVersions(current, latest string) (bool, error) {
 	return latestVer.GreaterThan(currentVer), nil
 }
 
+// CheckMinimumVersion returns an error when current is older than the required minimum version.
+// Development builds whose version is not a semantic version satisfy any requirement.
+func CheckMinimumVersion(current, required string) error {
+	if required == "" {
+		return nil
+	}
+
+	requiredVer, err := ParseVersion(required)
+	if err != nil {
+		return fmt.Errorf("failed to parse required version: %w", err)
+	}
+
+	currentVer, err := semver.NewVersion(current)
+	if err != nil {
+		return nil
+	}
+
+	if currentVer.LessThan(requiredVer) {
+		return fmt.Errorf("requires krci-ai >= %s, but the running version is %s; run 'krci-ai check-updates' to upgrade", requiredVer, currentVer)
+	}
+
+	return nil
+}
+
 // GetCurrentVersion returns the current CLI version
 func GetCurrentVersion() string {
 	return Version
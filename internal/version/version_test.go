@@ -150,6 +150,34 @@ func TestCompareVersions(t *testing.T) {
 	}
 }
 
+func TestCheckMinimumVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  string
+		required string
+		wantErr  string
+	}{
+		{name: "no requirement", current: "1.0.0", required: ""},
+		{name: "same version", current: "1.2.0", required: "1.2.0"},
+		{name: "newer current", current: "v1.3.0", required: "1.2.0"},
+		{name: "older current", current: "1.1.9", required: "1.2.0", wantErr: "requires krci-ai >= 1.2.0"},
+		{name: "development build", current: "dev", required: "9.0.0"},
+		{name: "invalid requirement", current: "1.0.0", required: "latest", wantErr: "failed to parse required version"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckMinimumVersion(tt.current, tt.required)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
 func TestGetCurrentVersion(t *testing.T) {
 	// Test with default values
 	version := GetCurrentVersion()